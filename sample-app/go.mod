@@ -4,4 +4,10 @@ go 1.21
 
 require github.com/allscreenshots/allscreenshots-sdk-go v1.0.0
 
+require (
+	golang.org/x/net v0.33.0 // indirect
+	golang.org/x/text v0.21.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
 replace github.com/allscreenshots/allscreenshots-sdk-go => ../sdk
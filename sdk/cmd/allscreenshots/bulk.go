@@ -0,0 +1,512 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+// maxBulkURLs is the maximum number of URLs the API accepts in one bulk job.
+const maxBulkURLs = 100
+
+// maxSitemapDepth limits how many levels of nested sitemap indexes are followed.
+const maxSitemapDepth = 3
+
+// manifestEntry records the outcome of a single URL in a bulk run.
+type manifestEntry struct {
+	URL    string `json:"url"`
+	BulkID string `json:"bulkId,omitempty"`
+	JobID  string `json:"jobId,omitempty"`
+	Status string `json:"status"`
+	File   string `json:"file,omitempty"`
+	Bytes  int    `json:"bytes,omitempty"`
+	Error  string `json:"error,omitempty"`
+
+	format string // format reported by the API, used for the file extension
+}
+
+// bulkBatch tracks one bulk job created for a slice of the input URLs.
+type bulkBatch struct {
+	id     string
+	offset int
+	size   int
+	jobs   map[string]int // sub-job ID -> index into the manifest
+}
+
+func runBulk(ctx context.Context, args []string) error {
+	var g globalFlags
+	fs := newFlagSet("bulk", &g)
+	urlsFile := fs.String("urls", "", "file with one URL per line (\"-\" reads stdin)")
+	sitemap := fs.String("from-sitemap", "", "sitemap URL or file to read URLs from")
+	outDir := fs.String("out", ".", "directory to write screenshots to")
+	manifestPath := fs.String("manifest", "", "manifest path; .csv writes CSV, anything else JSON (default <out>/manifest.json)")
+	device := fs.String("device", "", "device preset applied to every URL")
+	format := fs.String("format", "", "output format: png, jpeg, webp, or pdf")
+	fullPage := fs.Bool("full-page", false, "capture the full scrollable page")
+	concurrency := fs.Int("concurrency", 4, "number of concurrent downloads")
+	pollInterval := fs.Duration("poll-interval", 2*time.Second, "interval between bulk status checks")
+	noProgress := fs.Bool("no-progress", false, "disable the progress bar")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *urlsFile == "" && *sitemap == "" {
+		return errors.New("one of --urls or --from-sitemap is required")
+	}
+	if *concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+
+	var urls []string
+	if *urlsFile != "" {
+		fromFile, err := readURLFile(*urlsFile)
+		if err != nil {
+			return err
+		}
+		urls = append(urls, fromFile...)
+	}
+	if *sitemap != "" {
+		fromSitemap, err := readSitemap(ctx, *sitemap, 0)
+		if err != nil {
+			return err
+		}
+		urls = append(urls, fromSitemap...)
+	}
+	urls = dedupeStrings(urls)
+	if len(urls) == 0 {
+		return errors.New("no URLs to capture")
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		return err
+	}
+	if *manifestPath == "" {
+		*manifestPath = filepath.Join(*outDir, "manifest.json")
+	}
+
+	client, err := g.newClient()
+	if err != nil {
+		return err
+	}
+	defaults := &allscreenshots.BulkDefaults{
+		Device:   *device,
+		Format:   *format,
+		FullPage: *fullPage,
+	}
+
+	entries := make([]manifestEntry, len(urls))
+	for i, u := range urls {
+		entries[i] = manifestEntry{URL: u, Status: string(allscreenshots.JobStatusQueued)}
+	}
+
+	var batches []*bulkBatch
+	for offset := 0; offset < len(urls); offset += maxBulkURLs {
+		end := offset + maxBulkURLs
+		if end > len(urls) {
+			end = len(urls)
+		}
+		req := &allscreenshots.BulkRequest{Defaults: defaults}
+		for _, u := range urls[offset:end] {
+			req.URLs = append(req.URLs, allscreenshots.BulkURLRequest{URL: u})
+		}
+		resp, err := client.CreateBulkJob(ctx, req)
+		if err != nil {
+			return fmt.Errorf("creating bulk job for URLs %d-%d: %w", offset+1, end, err)
+		}
+		for i := offset; i < end; i++ {
+			entries[i].BulkID = resp.ID
+		}
+		batches = append(batches, &bulkBatch{id: resp.ID, offset: offset, size: end - offset, jobs: map[string]int{}})
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		fetched  int
+		failed   int
+		tasks    = make(chan int, len(urls))
+		progress = newProgressBar(os.Stderr, len(urls), !*noProgress)
+	)
+
+	for w := 0; w < *concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range tasks {
+				mu.Lock()
+				entry := entries[idx]
+				mu.Unlock()
+
+				data, err := client.GetJobResult(ctx, entry.JobID)
+				if err == nil {
+					name := resultFileName(idx, entry.URL, extensionFor(entry.format, *format))
+					err = os.WriteFile(filepath.Join(*outDir, name), data, 0o644)
+					entry.File = name
+					entry.Bytes = len(data)
+				}
+
+				mu.Lock()
+				if err != nil {
+					entry.Status = "DOWNLOAD_FAILED"
+					entry.Error = err.Error()
+					entry.File = ""
+					entry.Bytes = 0
+					failed++
+				} else {
+					fetched++
+				}
+				entries[idx] = entry
+				progress.update(fetched, failed)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	queued := make([]bool, len(urls))
+	pending := batches
+	var pollErr error
+	for len(pending) > 0 && pollErr == nil {
+		var next []*bulkBatch
+		for _, b := range pending {
+			status, err := client.GetBulkJob(ctx, b.id)
+			if err != nil {
+				pollErr = fmt.Errorf("polling bulk job %s: %w", b.id, err)
+				break
+			}
+
+			mu.Lock()
+			for k, job := range status.Jobs {
+				idx, ok := b.indexFor(job, k, entries)
+				if !ok || queued[idx] {
+					continue
+				}
+				entries[idx].JobID = job.ID
+				entries[idx].Status = job.Status
+				switch allscreenshots.JobStatus(job.Status) {
+				case allscreenshots.JobStatusCompleted:
+					entries[idx].format = job.Format
+					queued[idx] = true
+					tasks <- idx
+				case allscreenshots.JobStatusFailed, allscreenshots.JobStatusCancelled:
+					entries[idx].Error = strings.TrimSpace(job.ErrorCode + " " + job.ErrorMessage)
+					queued[idx] = true
+					failed++
+				}
+			}
+			progress.update(fetched, failed)
+			mu.Unlock()
+
+			if !bulkDone(status) {
+				next = append(next, b)
+			}
+		}
+		pending = next
+		if len(pending) == 0 || pollErr != nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			pollErr = ctx.Err()
+		case <-time.After(*pollInterval):
+		}
+	}
+
+	close(tasks)
+	wg.Wait()
+	progress.finish()
+
+	if err := writeManifest(*manifestPath, entries); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d captured, %d failed; manifest written to %s\n", fetched, failed, *manifestPath)
+
+	if pollErr != nil {
+		return pollErr
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d URLs failed", failed, len(urls))
+	}
+	return nil
+}
+
+// indexFor maps a sub-job reported by the API to its manifest index. Jobs are
+// matched by ID once seen, then by position, falling back to the first
+// unassigned entry with the same URL.
+func (b *bulkBatch) indexFor(job allscreenshots.BulkJobDetailInfo, position int, entries []manifestEntry) (int, bool) {
+	if idx, ok := b.jobs[job.ID]; ok {
+		return idx, true
+	}
+	assigned := make(map[int]bool, len(b.jobs))
+	for _, idx := range b.jobs {
+		assigned[idx] = true
+	}
+
+	idx := -1
+	if position < b.size && !assigned[b.offset+position] && entries[b.offset+position].URL == job.URL {
+		idx = b.offset + position
+	} else {
+		for i := b.offset; i < b.offset+b.size; i++ {
+			if !assigned[i] && entries[i].URL == job.URL {
+				idx = i
+				break
+			}
+		}
+	}
+	if idx < 0 {
+		return 0, false
+	}
+	b.jobs[job.ID] = idx
+	return idx, true
+}
+
+// bulkDone reports whether a bulk job has reached a terminal state.
+func bulkDone(status *allscreenshots.BulkStatusResponse) bool {
+	switch allscreenshots.JobStatus(status.Status) {
+	case allscreenshots.JobStatusCompleted, allscreenshots.JobStatusFailed, allscreenshots.JobStatusCancelled:
+		return true
+	}
+	return status.TotalJobs > 0 && status.CompletedJobs+status.FailedJobs >= status.TotalJobs
+}
+
+// readURLFile reads one URL per line, skipping blank lines and # comments.
+func readURLFile(path string) ([]string, error) {
+	var r io.Reader
+	if path == "-" {
+		r = os.Stdin
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var urls []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+	return urls, scanner.Err()
+}
+
+// sitemapDocument covers both <urlset> sitemaps and <sitemapindex> indexes.
+type sitemapDocument struct {
+	URLs []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// readSitemap loads a sitemap from a URL or local file, following nested
+// sitemap indexes up to maxSitemapDepth levels.
+func readSitemap(ctx context.Context, location string, depth int) ([]string, error) {
+	if depth > maxSitemapDepth {
+		return nil, nil
+	}
+
+	rc, err := openLocation(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var r io.Reader = rc
+	if strings.HasSuffix(strings.ToLower(location), ".gz") {
+		gz, err := gzip.NewReader(rc)
+		if err != nil {
+			return nil, fmt.Errorf("sitemap %s: %w", location, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var doc sitemapDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parsing sitemap %s: %w", location, err)
+	}
+
+	var urls []string
+	for _, u := range doc.URLs {
+		if loc := strings.TrimSpace(u.Loc); loc != "" {
+			urls = append(urls, loc)
+		}
+	}
+	for _, s := range doc.Sitemaps {
+		nested, err := readSitemap(ctx, strings.TrimSpace(s.Loc), depth+1)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, nested...)
+	}
+	return urls, nil
+}
+
+// openLocation opens an http(s) URL or a local file for reading.
+func openLocation(ctx context.Context, location string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		return os.Open(location)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetching %s: %s", location, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// dedupeStrings removes duplicates while preserving order.
+func dedupeStrings(in []string) []string {
+	seen := make(map[string]bool, len(in))
+	out := in[:0]
+	for _, s := range in {
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	return out
+}
+
+// extensionFor picks a file extension from the job's reported format,
+// falling back to the requested format and then png.
+func extensionFor(jobFormat, requested string) string {
+	format := strings.ToLower(jobFormat)
+	if format == "" {
+		format = strings.ToLower(requested)
+	}
+	switch format {
+	case "":
+		return "png"
+	case "jpeg":
+		return "jpg"
+	}
+	return format
+}
+
+// resultFileName builds a stable, filesystem-safe name for a capture.
+func resultFileName(idx int, rawURL, ext string) string {
+	slug := rawURL
+	if i := strings.Index(slug, "://"); i >= 0 {
+		slug = slug[i+3:]
+	}
+
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(slug) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	name := strings.Trim(b.String(), "-")
+	if len(name) > 60 {
+		name = strings.TrimRight(name[:60], "-")
+	}
+	return fmt.Sprintf("%04d-%s.%s", idx+1, name, ext)
+}
+
+// writeManifest writes entries as CSV when path ends in .csv and as JSON otherwise.
+func writeManifest(path string, entries []manifestEntry) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		w := csv.NewWriter(f)
+		if err := w.Write([]string{"url", "bulk_id", "job_id", "status", "file", "bytes", "error"}); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			record := []string{e.URL, e.BulkID, e.JobID, e.Status, e.File, strconv.Itoa(e.Bytes), e.Error}
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return err
+		}
+		return f.Close()
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(entries); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// progressBar renders a single-line progress bar to a terminal.
+type progressBar struct {
+	w       io.Writer
+	total   int
+	enabled bool
+	last    string
+}
+
+func newProgressBar(f *os.File, total int, enabled bool) *progressBar {
+	if enabled {
+		info, err := f.Stat()
+		enabled = err == nil && info.Mode()&os.ModeCharDevice != 0
+	}
+	return &progressBar{w: f, total: total, enabled: enabled}
+}
+
+func (p *progressBar) update(done, failed int) {
+	if !p.enabled || p.total == 0 {
+		return
+	}
+	const width = 30
+	filled := (done + failed) * width / p.total
+	line := fmt.Sprintf("\r[%s%s] %d/%d done, %d failed",
+		strings.Repeat("#", filled), strings.Repeat("-", width-filled), done+failed, p.total, failed)
+	if line == p.last {
+		return
+	}
+	p.last = line
+	fmt.Fprint(p.w, line)
+}
+
+func (p *progressBar) finish() {
+	if p.enabled && p.last != "" {
+		fmt.Fprintln(p.w)
+	}
+}
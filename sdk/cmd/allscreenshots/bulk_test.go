@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBulkAPI serves one bulk job whose sub-jobs finish over two polls:
+// the first poll reports a completed, a processing, and a failed job, the
+// second reports all of them finished.
+type fakeBulkAPI struct {
+	t *testing.T
+
+	mu        sync.Mutex
+	submitted []string
+	polls     int
+	downloads map[string]int
+}
+
+func (a *fakeBulkAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/screenshots/bulk":
+		var req allscreenshots.BulkRequest
+		require.NoError(a.t, json.NewDecoder(r.Body).Decode(&req))
+		for _, u := range req.URLs {
+			a.submitted = append(a.submitted, u.URL)
+		}
+		json.NewEncoder(w).Encode(allscreenshots.BulkResponse{ID: "bulk-1", Status: "PROCESSING", TotalJobs: len(req.URLs)})
+	case r.URL.Path == "/v1/screenshots/bulk/bulk-1":
+		a.polls++
+		status := allscreenshots.BulkStatusResponse{ID: "bulk-1", Status: "PROCESSING", TotalJobs: 3}
+		second := "PROCESSING"
+		if a.polls > 1 {
+			status.Status, second = "COMPLETED", "COMPLETED"
+		}
+		status.Jobs = []allscreenshots.BulkJobDetailInfo{
+			{ID: "job-1", URL: "https://example.com/a", Status: "COMPLETED", Format: "png"},
+			{ID: "job-2", URL: "https://example.com/b", Status: second, Format: "jpeg"},
+			{ID: "job-3", URL: "https://example.com/c", Status: "FAILED", ErrorCode: "TIMEOUT", ErrorMessage: "page did not load"},
+		}
+		json.NewEncoder(w).Encode(status)
+	case strings.HasPrefix(r.URL.Path, "/v1/screenshots/jobs/") && strings.HasSuffix(r.URL.Path, "/result"):
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/screenshots/jobs/"), "/result")
+		a.downloads[id]++
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("image-" + id))
+	default:
+		a.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// runFakeBulk runs the bulk command against a fake API with the given extra
+// flags and returns the API and the output directory.
+func runFakeBulk(t *testing.T, extra ...string) (*fakeBulkAPI, string, error) {
+	isolate(t)
+	api := &fakeBulkAPI{t: t, downloads: map[string]int{}}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	urls := filepath.Join(dir, "urls.txt")
+	require.NoError(t, os.WriteFile(urls, []byte(strings.Join([]string{
+		"# pages to capture",
+		"https://example.com/a",
+		"",
+		"https://example.com/b",
+		"https://example.com/a",
+		"https://example.com/c",
+	}, "\n")), 0o644))
+	out := filepath.Join(dir, "shots")
+
+	args := append([]string{
+		"--api-key", "test-api-key",
+		"--base-url", server.URL,
+		"--urls", urls,
+		"--out", out,
+		"--poll-interval", "1ms",
+		"--no-progress",
+	}, extra...)
+	err := runBulk(context.Background(), args)
+	return api, out, err
+}
+
+func TestBulk_JSONManifest(t *testing.T) {
+	api, out, err := runFakeBulk(t)
+	assert.EqualError(t, err, "1 of 3 URLs failed")
+
+	assert.Equal(t, []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"}, api.submitted, "blank lines, comments, and duplicates are skipped")
+	assert.Equal(t, 2, api.polls)
+	assert.Equal(t, map[string]int{"job-1": 1, "job-2": 1}, api.downloads, "results are downloaded once, failed jobs never")
+
+	data, err := os.ReadFile(filepath.Join(out, "manifest.json"))
+	require.NoError(t, err)
+	var entries []manifestEntry
+	require.NoError(t, json.Unmarshal(data, &entries))
+	assert.Equal(t, []manifestEntry{
+		{URL: "https://example.com/a", BulkID: "bulk-1", JobID: "job-1", Status: "COMPLETED", File: "0001-example-com-a.png", Bytes: 11},
+		{URL: "https://example.com/b", BulkID: "bulk-1", JobID: "job-2", Status: "COMPLETED", File: "0002-example-com-b.jpg", Bytes: 11},
+		{URL: "https://example.com/c", BulkID: "bulk-1", JobID: "job-3", Status: "FAILED", Error: "TIMEOUT page did not load"},
+	}, entries)
+
+	image, err := os.ReadFile(filepath.Join(out, "0002-example-com-b.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, "image-job-2", string(image))
+	_, err = os.Stat(filepath.Join(out, "0003-example-com-c.png"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestBulk_CSVManifest(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "manifest.csv")
+	_, _, err := runFakeBulk(t, "--manifest", manifest, "--concurrency", "1")
+	assert.EqualError(t, err, "1 of 3 URLs failed")
+
+	f, err := os.Open(manifest)
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"url", "bulk_id", "job_id", "status", "file", "bytes", "error"},
+		{"https://example.com/a", "bulk-1", "job-1", "COMPLETED", "0001-example-com-a.png", "11", ""},
+		{"https://example.com/b", "bulk-1", "job-2", "COMPLETED", "0002-example-com-b.jpg", "11", ""},
+		{"https://example.com/c", "bulk-1", "job-3", "FAILED", "", "0", "TIMEOUT page did not load"},
+	}, records)
+}
+
+func TestBulk_Flags(t *testing.T) {
+	isolate(t)
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"no input", nil, "one of --urls or --from-sitemap is required"},
+		{"bad concurrency", []string{"--urls", "urls.txt", "--concurrency", "0"}, "--concurrency must be at least 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, runBulk(context.Background(), tt.args), tt.wantErr)
+		})
+	}
+}
+
+func TestResultFileName(t *testing.T) {
+	assert.Equal(t, "0001-example-com-pricing-plan-pro.png", resultFileName(0, "https://Example.com/pricing?plan=pro", "png"))
+	assert.Equal(t, "0012-example-com.jpg", resultFileName(11, "https://example.com/", extensionFor("jpeg", "")))
+	assert.Equal(t, "webp", extensionFor("", "WEBP"))
+	assert.Equal(t, "png", extensionFor("", ""))
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+// envWebhookSecret is read when --secret is not given.
+const envWebhookSecret = "ALLSCREENSHOTS_WEBHOOK_SECRET"
+
+func runListen(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("allscreenshots listen", flag.ContinueOnError)
+	port := fs.Int("port", 9000, "port to listen on")
+	path := fs.String("path", "/", "path to receive webhooks on")
+	secret := fs.String("secret", os.Getenv(envWebhookSecret), "webhook secret used to verify signatures (defaults to $"+envWebhookSecret+")")
+	forward := fs.String("forward", "", "forward every received webhook to this URL")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	l := &listener{out: os.Stdout, forwardURL: *forward, forwarder: &http.Client{Timeout: 30 * time.Second}}
+
+	if *secret == "" {
+		fmt.Fprintln(os.Stderr, "warning: no --secret given, signatures will not be verified")
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(*path, l.handler(*secret))
+	server := &http.Server{
+		Addr:              net.JoinHostPort("", strconv.Itoa(*port)),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.ListenAndServe() }()
+	fmt.Fprintf(os.Stderr, "Listening for webhooks on http://localhost:%d%s\n", *port, *path)
+	if *forward != "" {
+		fmt.Fprintf(os.Stderr, "Forwarding to %s\n", *forward)
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// listener prints received events and optionally forwards them.
+type listener struct {
+	mu         sync.Mutex
+	out        io.Writer
+	forwardURL string
+	forwarder  *http.Client
+}
+
+// handler returns the HTTP handler receiving webhooks. With a secret, requests
+// with a missing or wrong signature are rejected; without one, every request
+// is accepted and printed as unverified.
+func (l *listener) handler(secret string) http.Handler {
+	if secret != "" {
+		return allscreenshots.NewWebhookHandler(secret, func(ctx context.Context, event *allscreenshots.WebhookEvent) error {
+			return l.handle(ctx, event, true)
+		})
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(io.LimitReader(r.Body, allscreenshots.MaxWebhookPayloadSize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		event, err := allscreenshots.ParseWebhookEvent(payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		event.Signature = r.Header.Get(allscreenshots.WebhookSignatureHeader)
+		if err := l.handle(r.Context(), event, false); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (l *listener) handle(ctx context.Context, event *allscreenshots.WebhookEvent, verified bool) error {
+	l.print(event, verified)
+	if l.forwardURL == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.forwardURL, bytes.NewReader(event.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Forwarded payloads are byte-identical, so the original signature stays valid.
+	if event.Signature != "" {
+		req.Header.Set(allscreenshots.WebhookSignatureHeader, event.Signature)
+	}
+
+	resp, err := l.forwarder.Do(req)
+	if err != nil {
+		l.printf("  -> forward failed: %v\n", err)
+		return err
+	}
+	resp.Body.Close()
+	l.printf("  -> forwarded to %s: %s\n", l.forwardURL, resp.Status)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("forward target responded %s", resp.Status)
+	}
+	return nil
+}
+
+func (l *listener) print(event *allscreenshots.WebhookEvent, verified bool) {
+	state := "unverified"
+	if verified {
+		state = "verified"
+	}
+
+	var body bytes.Buffer
+	if err := json.Indent(&body, event.Payload, "  ", "  "); err != nil {
+		body.Reset()
+		body.Write(event.Payload)
+	}
+
+	l.printf("[%s] %s %s (%s)\n  %s\n", time.Now().Format("15:04:05"), event.Type, event.ID, state, body.String())
+}
+
+func (l *listener) printf(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(l.out, format, args...)
+}
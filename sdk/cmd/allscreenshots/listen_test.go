@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webhookPayload = `{"id":"evt-1","type":"job.completed","data":{"id":"job-1","status":"COMPLETED"}}`
+
+func TestListener_Signatures(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     string
+		signature  string
+		wantStatus int
+		wantOutput string
+	}{
+		{"valid signature", "s3cret", allscreenshots.ComputeWebhookSignature([]byte(webhookPayload), "s3cret"), http.StatusNoContent, "job.completed evt-1 (verified)"},
+		{"wrong secret", "s3cret", allscreenshots.ComputeWebhookSignature([]byte(webhookPayload), "other"), http.StatusUnauthorized, ""},
+		{"missing signature", "s3cret", "", http.StatusUnauthorized, ""},
+		{"no secret configured", "", "sha256=anything", http.StatusNoContent, "job.completed evt-1 (unverified)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			l := &listener{out: &out}
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(webhookPayload))
+			if tt.signature != "" {
+				req.Header.Set(allscreenshots.WebhookSignatureHeader, tt.signature)
+			}
+			rec := httptest.NewRecorder()
+			l.handler(tt.secret).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantOutput == "" {
+				assert.Empty(t, out.String())
+				return
+			}
+			assert.Contains(t, out.String(), tt.wantOutput)
+			assert.Contains(t, out.String(), `"status": "COMPLETED"`, "payloads are pretty-printed")
+		})
+	}
+}
+
+func TestListener_Forward(t *testing.T) {
+	signature := allscreenshots.ComputeWebhookSignature([]byte(webhookPayload), "s3cret")
+
+	var received []byte
+	var receivedSignature string
+	status := http.StatusOK
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		receivedSignature = r.Header.Get(allscreenshots.WebhookSignatureHeader)
+		w.WriteHeader(status)
+	}))
+	defer target.Close()
+
+	var out bytes.Buffer
+	l := &listener{out: &out, forwardURL: target.URL, forwarder: target.Client()}
+	send := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(webhookPayload))
+		req.Header.Set(allscreenshots.WebhookSignatureHeader, signature)
+		rec := httptest.NewRecorder()
+		l.handler("s3cret").ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusNoContent, send())
+	assert.Equal(t, webhookPayload, string(received), "forwarded payloads are byte-identical")
+	assert.Equal(t, signature, receivedSignature)
+	assert.Contains(t, out.String(), "-> forwarded to "+target.URL+": 200 OK")
+
+	status = http.StatusServiceUnavailable
+	assert.Equal(t, http.StatusInternalServerError, send(), "failed forwards are reported so the API retries")
+}
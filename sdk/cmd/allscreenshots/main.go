@@ -0,0 +1,148 @@
+// Command allscreenshots is a command-line interface for the Allscreenshots API.
+//
+// Usage:
+//
+//	allscreenshots <command> [flags]
+//
+// Run "allscreenshots help" for the list of available commands.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+// command describes a CLI subcommand.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+var commands = []command{
+	{name: "bulk", summary: "Capture many URLs with a bulk job and download the results", run: runBulk},
+	{name: "schedules", summary: "Manage scheduled captures", run: runSchedules},
+	{name: "usage", summary: "Show usage for the current billing period", run: runUsage},
+	{name: "quota", summary: "Show remaining quota", run: runQuota},
+	{name: "listen", summary: "Receive and print webhooks locally", run: runListen},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	if name == "help" || name == "-h" || name == "--help" {
+		printUsage()
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		if err := cmd.run(ctx, os.Args[2:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				os.Exit(2)
+			}
+			fmt.Fprintf(os.Stderr, "allscreenshots %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "allscreenshots: unknown command %q\n\n", name)
+	printUsage()
+	os.Exit(2)
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: allscreenshots <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, `Run "allscreenshots <command> -h" for command-specific flags.`)
+}
+
+// globalFlags holds the connection flags shared by every command.
+type globalFlags struct {
+	apiKey     string
+	baseURL    string
+	timeout    time.Duration
+	profile    string
+	configPath string
+}
+
+// register adds the shared flags to fs.
+func (g *globalFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&g.apiKey, "api-key", "", "API key (defaults to $"+allscreenshots.EnvAPIKey+")")
+	fs.StringVar(&g.baseURL, "base-url", "", "API base URL")
+	fs.DurationVar(&g.timeout, "timeout", 0, "HTTP timeout per request")
+	fs.StringVar(&g.profile, "profile", "", "configuration profile to use (defaults to $"+allscreenshots.EnvProfile+")")
+	fs.StringVar(&g.configPath, "config", "", "configuration file (defaults to ~/.config/allscreenshots/config.yaml)")
+}
+
+// newClient builds an API client from the configuration file, if any, with
+// the shared flags taking precedence over profile settings.
+func (g *globalFlags) newClient() (*allscreenshots.Client, error) {
+	var opts []allscreenshots.ClientOption
+	if g.apiKey != "" {
+		opts = append(opts, allscreenshots.WithAPIKey(g.apiKey))
+	}
+	if g.baseURL != "" {
+		opts = append(opts, allscreenshots.WithBaseURL(g.baseURL))
+	}
+	if g.timeout > 0 {
+		opts = append(opts, allscreenshots.WithTimeout(g.timeout))
+	}
+
+	// A profile requested explicitly must exist; the default config file is
+	// only used when it has a matching profile.
+	if g.profile != "" || g.configPath != "" || os.Getenv(allscreenshots.EnvProfile) != "" {
+		return allscreenshots.NewClientFromConfig(g.configPath, g.profile, opts...)
+	}
+	if allscreenshots.ConfigExists("") {
+		cfg, err := allscreenshots.LoadConfig("")
+		if err != nil {
+			return nil, err
+		}
+		if profile, err := cfg.Profile(""); err == nil {
+			profileOpts, err := profile.ClientOptions()
+			if err != nil {
+				return nil, err
+			}
+			opts = append(profileOpts, opts...)
+		}
+	}
+	return allscreenshots.NewClient(opts...), nil
+}
+
+// newFlagSet creates a flag set for a subcommand with the shared flags registered.
+func newFlagSet(name string, g *globalFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet("allscreenshots "+name, flag.ContinueOnError)
+	g.register(fs)
+	return fs
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
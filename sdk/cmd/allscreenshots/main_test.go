@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/require"
+)
+
+// isolate keeps a test away from the user's configuration file and
+// environment, so commands only see the flags the test passes.
+func isolate(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(allscreenshots.EnvConfigFile, "")
+	t.Setenv(allscreenshots.EnvProfile, "")
+	t.Setenv(allscreenshots.EnvAPIKey, "")
+}
+
+// captureStdout runs fn and returns what it wrote to stdout.
+func captureStdout(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	out := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		out <- buf.String()
+	}()
+
+	runErr := fn()
+	w.Close()
+	return <-out, runErr
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"gopkg.in/yaml.v3"
+)
+
+// scheduleSubcommands maps "schedules <name>" to its implementation.
+var scheduleSubcommands = []command{
+	{name: "list", summary: "List schedules", run: runSchedulesList},
+	{name: "create", summary: "Create a schedule", run: runSchedulesCreate},
+	{name: "pause", summary: "Pause a schedule", run: scheduleAction("pause", (*allscreenshots.Client).PauseSchedule)},
+	{name: "resume", summary: "Resume a paused schedule", run: scheduleAction("resume", (*allscreenshots.Client).ResumeSchedule)},
+	{name: "trigger", summary: "Run a schedule immediately", run: scheduleAction("trigger", (*allscreenshots.Client).TriggerSchedule)},
+	{name: "history", summary: "Show a schedule's execution history", run: runSchedulesHistory},
+	{name: "apply", summary: "Sync schedules from a YAML file", run: runSchedulesApply},
+}
+
+func runSchedules(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprintln(os.Stderr, "Usage: allscreenshots schedules <subcommand> [flags]")
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, "Subcommands:")
+		for _, sub := range scheduleSubcommands {
+			fmt.Fprintf(os.Stderr, "  %-10s %s\n", sub.name, sub.summary)
+		}
+		return flag.ErrHelp
+	}
+
+	for _, sub := range scheduleSubcommands {
+		if sub.name == args[0] {
+			return sub.run(ctx, args[1:])
+		}
+	}
+	return fmt.Errorf("unknown subcommand %q", args[0])
+}
+
+func runSchedulesList(ctx context.Context, args []string) error {
+	var g globalFlags
+	fs := newFlagSet("schedules list", &g)
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := g.newClient()
+	if err != nil {
+		return err
+	}
+	list, err := client.ListSchedules(ctx)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(list)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tSTATUS\tSCHEDULE\tNEXT RUN\tURL")
+	for _, s := range list.Schedules {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", s.ID, s.Name, s.Status, s.Schedule, formatTime(s.NextExecutionAt), scheduleURLs(&s))
+	}
+	return tw.Flush()
+}
+
+func runSchedulesCreate(ctx context.Context, args []string) error {
+	var g globalFlags
+	fs := newFlagSet("schedules create", &g)
+	req := &allscreenshots.CreateScheduleRequest{}
+	opts := &allscreenshots.ScheduleScreenshotOptions{}
+	fs.StringVar(&req.Name, "name", "", "schedule name (required)")
+	fs.StringVar(&req.URL, "url", "", "URL to capture (required)")
+	fs.StringVar(&req.Schedule, "cron", "", "cron expression (required)")
+	fs.StringVar(&req.Timezone, "timezone", "", "IANA timezone for the cron expression")
+	fs.StringVar(&req.WebhookURL, "webhook-url", "", "webhook to notify after each run")
+	fs.IntVar(&req.RetentionDays, "retention-days", 0, "days to keep results (1-365)")
+	fs.StringVar(&opts.Device, "device", "", "device preset")
+	fs.StringVar(&opts.Format, "format", "", "output format")
+	fs.BoolVar(&opts.FullPage, "full-page", false, "capture the full scrollable page")
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if opts.Device != "" || opts.Format != "" || opts.FullPage {
+		req.Options = opts
+	}
+
+	client, err := g.newClient()
+	if err != nil {
+		return err
+	}
+	schedule, err := client.CreateSchedule(ctx, req)
+	if err != nil {
+		return err
+	}
+	return printSchedule(schedule, *asJSON)
+}
+
+// scheduleAction builds a subcommand that applies a single-ID operation.
+func scheduleAction(name string, action func(*allscreenshots.Client, context.Context, string) (*allscreenshots.ScheduleResponse, error)) func(context.Context, []string) error {
+	return func(ctx context.Context, args []string) error {
+		var g globalFlags
+		fs := newFlagSet("schedules "+name, &g)
+		asJSON := fs.Bool("json", false, "print JSON instead of a table")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return fmt.Errorf("usage: allscreenshots schedules %s [flags] <schedule-id>", name)
+		}
+
+		client, err := g.newClient()
+		if err != nil {
+			return err
+		}
+		schedule, err := action(client, ctx, fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		return printSchedule(schedule, *asJSON)
+	}
+}
+
+func runSchedulesHistory(ctx context.Context, args []string) error {
+	var g globalFlags
+	fs := newFlagSet("schedules history", &g)
+	limit := fs.Int("limit", 20, "maximum number of executions to show")
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: allscreenshots schedules history [flags] <schedule-id>")
+	}
+
+	client, err := g.newClient()
+	if err != nil {
+		return err
+	}
+	history, err := client.GetScheduleHistory(ctx, fs.Arg(0), *limit)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(history)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tEXECUTED\tSTATUS\tRENDER MS\tRESULT / ERROR")
+	for _, e := range history.Executions {
+		detail := e.ResultURL
+		if e.ErrorMessage != "" {
+			detail = e.ErrorCode + " " + e.ErrorMessage
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", e.ID, formatTime(e.ExecutedAt), e.Status, e.RenderTimeMs, detail)
+	}
+	return tw.Flush()
+}
+
+// scheduleFile is the document format read by "schedules apply".
+type scheduleFile struct {
+	Schedules []allscreenshots.CreateScheduleRequest `json:"schedules"`
+}
+
+func runSchedulesApply(ctx context.Context, args []string) error {
+	var g globalFlags
+	fs := newFlagSet("schedules apply", &g)
+	file := fs.String("f", "", "YAML file describing the desired schedules (required)")
+	prune := fs.Bool("prune", false, "delete schedules that are not in the file")
+	dryRun := fs.Bool("dry-run", false, "show the plan without changing anything")
+	asJSON := fs.Bool("json", false, "print JSON instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return errors.New("-f is required")
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	var doc scheduleFile
+	if err := decodeYAML(data, &doc); err != nil {
+		return fmt.Errorf("parsing %s: %w", *file, err)
+	}
+
+	client, err := g.newClient()
+	if err != nil {
+		return err
+	}
+	result, err := client.SyncSchedules(ctx, doc.Schedules, &allscreenshots.ScheduleSyncOptions{
+		Prune:  *prune,
+		DryRun: *dryRun,
+	})
+	if result != nil && *asJSON {
+		if printErr := printJSON(result); printErr != nil {
+			return printErr
+		}
+	} else if result != nil {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ACTION\tNAME\tID")
+		for _, c := range result.Changes {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", c.Action, c.Name, c.ID)
+		}
+		tw.Flush()
+	}
+	return err
+}
+
+func printSchedule(s *allscreenshots.ScheduleResponse, asJSON bool) error {
+	if asJSON {
+		return printJSON(s)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID\t%s\n", s.ID)
+	fmt.Fprintf(tw, "Name\t%s\n", s.Name)
+	fmt.Fprintf(tw, "Status\t%s\n", s.Status)
+	fmt.Fprintf(tw, "Schedule\t%s %s\n", s.Schedule, s.Timezone)
+	if len(s.URLs) > 0 {
+		fmt.Fprintf(tw, "URLs\t%s\n", strings.Join(s.URLs, ", "))
+	} else {
+		fmt.Fprintf(tw, "URL\t%s\n", s.URL)
+	}
+	fmt.Fprintf(tw, "Next run\t%s\n", formatTime(s.NextExecutionAt))
+	fmt.Fprintf(tw, "Runs\t%d (%d ok, %d failed)\n", s.ExecutionCount, s.SuccessCount, s.FailureCount)
+	return tw.Flush()
+}
+
+// scheduleURLs summarizes the URL or URLs a schedule captures for a table cell.
+func scheduleURLs(s *allscreenshots.ScheduleResponse) string {
+	switch len(s.URLs) {
+	case 0:
+		return s.URL
+	case 1:
+		return s.URLs[0]
+	}
+	return fmt.Sprintf("%s (+%d more)", s.URLs[0], len(s.URLs)-1)
+}
+
+// decodeYAML decodes a YAML document into v using v's JSON field names, so
+// files use the same keys as the API (e.g. fullPage, retentionDays).
+func decodeYAML(data []byte, v interface{}) error {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, v)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scheduleFileYAML is the desired state used by the apply tests: homepage
+// matches the existing schedule, pricing changes its cron expression, and
+// docs is new. The existing "legacy" schedule is not in the file.
+const scheduleFileYAML = `
+schedules:
+  - name: homepage
+    url: https://example.com
+    schedule: "0 * * * *"
+  - name: pricing
+    url: https://example.com/pricing
+    schedule: "0 9 * * *"
+    options:
+      fullPage: true
+  - name: docs
+    url: https://example.com/docs
+    schedule: "0 0 * * 1"
+`
+
+// fakeScheduleAPI serves a fixed list of schedules and records every
+// request that would change them.
+type fakeScheduleAPI struct {
+	t *testing.T
+
+	mu       sync.Mutex
+	mutating []string
+	created  []allscreenshots.CreateScheduleRequest
+	updated  []allscreenshots.UpdateScheduleRequest
+}
+
+func (a *fakeScheduleAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/schedules":
+		json.NewEncoder(w).Encode(allscreenshots.ScheduleListResponse{Schedules: []allscreenshots.ScheduleResponse{
+			{ID: "s-1", Name: "homepage", URL: "https://example.com", Schedule: "0 * * * *", Status: "ACTIVE"},
+			{ID: "s-2", Name: "pricing", URL: "https://example.com/pricing", Schedule: "0 8 * * *", Status: "ACTIVE"},
+			{ID: "s-3", Name: "legacy", URL: "https://old.example.com", Schedule: "0 0 * * *", Status: "PAUSED"},
+		}})
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/schedules":
+		var req allscreenshots.CreateScheduleRequest
+		require.NoError(a.t, json.NewDecoder(r.Body).Decode(&req))
+		a.created = append(a.created, req)
+		a.mutating = append(a.mutating, "POST "+r.URL.Path)
+		json.NewEncoder(w).Encode(allscreenshots.ScheduleResponse{ID: "s-4", Name: req.Name, URL: req.URL, Schedule: req.Schedule})
+	case r.Method == http.MethodPut:
+		var req allscreenshots.UpdateScheduleRequest
+		require.NoError(a.t, json.NewDecoder(r.Body).Decode(&req))
+		a.updated = append(a.updated, req)
+		a.mutating = append(a.mutating, "PUT "+r.URL.Path)
+		json.NewEncoder(w).Encode(allscreenshots.ScheduleResponse{ID: "s-2", Name: req.Name, URL: req.URL, Schedule: req.Schedule})
+	case r.Method == http.MethodDelete:
+		a.mutating = append(a.mutating, "DELETE "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		a.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestSchedulesApply(t *testing.T) {
+	tests := []struct {
+		name         string
+		flags        []string
+		wantMutating []string
+		wantOutput   [][]string
+	}{
+		{
+			name:         "apply",
+			wantMutating: []string{"PUT /v1/schedules/s-2", "POST /v1/schedules"},
+			wantOutput: [][]string{
+				{"ACTION", "NAME", "ID"},
+				{"unchanged", "homepage", "s-1"},
+				{"update", "pricing", "s-2"},
+				{"create", "docs", "s-4"},
+			},
+		},
+		{
+			name:         "prune",
+			flags:        []string{"--prune"},
+			wantMutating: []string{"PUT /v1/schedules/s-2", "POST /v1/schedules", "DELETE /v1/schedules/s-3"},
+			wantOutput: [][]string{
+				{"ACTION", "NAME", "ID"},
+				{"unchanged", "homepage", "s-1"},
+				{"update", "pricing", "s-2"},
+				{"create", "docs", "s-4"},
+				{"delete", "legacy", "s-3"},
+			},
+		},
+		{
+			name:  "dry run",
+			flags: []string{"--prune", "--dry-run"},
+			wantOutput: [][]string{
+				{"ACTION", "NAME", "ID"},
+				{"unchanged", "homepage", "s-1"},
+				{"update", "pricing", "s-2"},
+				{"create", "docs"},
+				{"delete", "legacy", "s-3"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolate(t)
+			api := &fakeScheduleAPI{t: t}
+			server := httptest.NewServer(api)
+			defer server.Close()
+
+			file := filepath.Join(t.TempDir(), "schedules.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(scheduleFileYAML), 0o644))
+
+			args := append([]string{"apply", "--api-key", "test-api-key", "--base-url", server.URL, "-f", file}, tt.flags...)
+			out, err := captureStdout(t, func() error { return runSchedules(context.Background(), args) })
+			require.NoError(t, err)
+
+			var rows [][]string
+			for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+				rows = append(rows, strings.Fields(line))
+			}
+			assert.Equal(t, tt.wantOutput, rows)
+			assert.Equal(t, tt.wantMutating, api.mutating)
+		})
+	}
+}
+
+func TestSchedulesApply_JSON(t *testing.T) {
+	isolate(t)
+	api := &fakeScheduleAPI{t: t}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "schedules.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(scheduleFileYAML), 0o644))
+
+	out, err := captureStdout(t, func() error {
+		return runSchedules(context.Background(), []string{"apply", "--api-key", "test-api-key", "--base-url", server.URL, "-f", file, "--json"})
+	})
+	require.NoError(t, err)
+
+	var result allscreenshots.ScheduleSyncResult
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, 1, result.Count(allscreenshots.ScheduleSyncCreate))
+	assert.Equal(t, 1, result.Count(allscreenshots.ScheduleSyncUpdate))
+	assert.Equal(t, 1, result.Count(allscreenshots.ScheduleSyncUnchanged))
+
+	require.Len(t, api.created, 1)
+	assert.Equal(t, "docs", api.created[0].Name)
+	assert.Equal(t, "0 0 * * 1", api.created[0].Schedule)
+	require.Len(t, api.updated, 1)
+	assert.Equal(t, "0 9 * * *", api.updated[0].Schedule)
+	require.NotNil(t, api.updated[0].Options)
+	assert.True(t, api.updated[0].Options.FullPage, "YAML keys use the API's field names")
+}
+
+func TestSchedulesApply_Errors(t *testing.T) {
+	isolate(t)
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("schedules: [\n"), 0o644))
+	duplicate := filepath.Join(dir, "duplicate.yaml")
+	require.NoError(t, os.WriteFile(duplicate, []byte(`
+schedules:
+  - {name: a, url: "https://example.com", schedule: "0 * * * *"}
+  - {name: a, url: "https://example.com/b", schedule: "0 * * * *"}
+`), 0o644))
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"missing file flag", []string{"apply"}, "-f is required"},
+		{"invalid YAML", []string{"apply", "-f", invalid}, "parsing " + invalid},
+		{"duplicate names", []string{"apply", "--api-key", "test-api-key", "-f", duplicate}, "duplicate schedule name a"},
+		{"unknown subcommand", []string{"rename"}, `unknown subcommand "rename"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runSchedules(context.Background(), tt.args)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSchedulesList(t *testing.T) {
+	isolate(t)
+	server := httptest.NewServer(&fakeScheduleAPI{t: t})
+	defer server.Close()
+
+	out, err := captureStdout(t, func() error {
+		return runSchedules(context.Background(), []string{"list", "--api-key", "test-api-key", "--base-url", server.URL})
+	})
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, []string{"ID", "NAME", "STATUS", "SCHEDULE", "NEXT", "RUN", "URL"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"s-3", "legacy", "PAUSED", "0", "0", "*", "*", "*", "-", "https://old.example.com"}, strings.Fields(lines[3]))
+}
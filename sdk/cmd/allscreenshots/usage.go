@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+func runUsage(ctx context.Context, args []string) error {
+	var g globalFlags
+	fs := newFlagSet("usage", &g)
+	asJSON := fs.Bool("json", false, "print JSON instead of a summary")
+	watch := fs.Duration("watch", 0, "refresh at this interval until interrupted (e.g. 30s)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := g.newClient()
+	if err != nil {
+		return err
+	}
+	return refresh(ctx, *watch, func() error {
+		usage, err := client.GetUsage(ctx)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(usage)
+		}
+		return printUsageSummary(os.Stdout, usage)
+	})
+}
+
+func runQuota(ctx context.Context, args []string) error {
+	var g globalFlags
+	fs := newFlagSet("quota", &g)
+	asJSON := fs.Bool("json", false, "print JSON instead of a summary")
+	watch := fs.Duration("watch", 0, "refresh at this interval until interrupted (e.g. 30s)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := g.newClient()
+	if err != nil {
+		return err
+	}
+	return refresh(ctx, *watch, func() error {
+		quota, err := client.GetQuotaStatus(ctx)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(quota)
+		}
+		return printQuotaSummary(os.Stdout, quota)
+	})
+}
+
+// refresh runs render once, or repeatedly every interval until ctx is done
+// when interval is positive. On a terminal the screen is cleared between runs.
+func refresh(ctx context.Context, interval time.Duration, render func() error) error {
+	if interval <= 0 {
+		return render()
+	}
+
+	info, err := os.Stdout.Stat()
+	clear := err == nil && info.Mode()&os.ModeCharDevice != 0
+	for {
+		if clear {
+			fmt.Print("\033[H\033[2J")
+		}
+		if err := render(); err != nil {
+			if ctx.Err() != nil {
+				// Interrupted while fetching: stop like between refreshes.
+				return nil
+			}
+			return err
+		}
+		if clear {
+			fmt.Printf("\nUpdated %s, refreshing every %s (Ctrl+C to stop)\n", time.Now().Format("15:04:05"), interval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+func printUsageSummary(w io.Writer, u *allscreenshots.UsageResponse) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Tier\t%s\n", u.Tier)
+	if p := u.CurrentPeriod; p != nil {
+		fmt.Fprintf(tw, "Period\t%s to %s\n", p.PeriodStart, p.PeriodEnd)
+		fmt.Fprintf(tw, "Screenshots\t%d\n", p.ScreenshotsCount)
+		fmt.Fprintf(tw, "Bandwidth\t%s\n", p.BandwidthFormatted)
+	}
+	if q := u.Quota; q != nil {
+		writeQuotaRows(tw, q.Screenshots, q.Bandwidth)
+	}
+	if t := u.Totals; t != nil {
+		fmt.Fprintf(tw, "All-time screenshots\t%d\n", t.ScreenshotsCount)
+		fmt.Fprintf(tw, "All-time bandwidth\t%s\n", t.BandwidthFormatted)
+	}
+	return tw.Flush()
+}
+
+func printQuotaSummary(w io.Writer, q *allscreenshots.QuotaStatusResponse) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Tier\t%s\n", q.Tier)
+	writeQuotaRows(tw, q.Screenshots, q.Bandwidth)
+	if q.PeriodEnds != "" {
+		fmt.Fprintf(tw, "Period ends\t%s\n", q.PeriodEnds)
+	}
+	return tw.Flush()
+}
+
+func writeQuotaRows(w io.Writer, s *allscreenshots.QuotaDetailResponse, b *allscreenshots.BandwidthQuotaResponse) {
+	if s != nil {
+		fmt.Fprintf(w, "Screenshot quota\t%s %d/%d used, %d remaining\n", meter(s.PercentUsed), s.Used, s.Limit, s.Remaining)
+	}
+	if b != nil {
+		fmt.Fprintf(w, "Bandwidth quota\t%s %s/%s used, %s remaining\n", meter(b.PercentUsed), b.UsedFormatted, b.LimitFormatted, b.RemainingFormatted)
+	}
+}
+
+// meter renders a percentage as a small text gauge, e.g. "[####------] 40%".
+func meter(percent int) string {
+	const width = 10
+	filled := percent * width / 100
+	if filled < 0 {
+		filled = 0
+	}
+	if filled > width {
+		filled = width
+	}
+	return fmt.Sprintf("[%s%s] %3d%%", strings.Repeat("#", filled), strings.Repeat("-", width-filled), percent)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	usageBody = `{
+		"tier": "PRO",
+		"currentPeriod": {"periodStart": "2024-06-01", "periodEnd": "2024-06-30", "screenshotsCount": 420, "bandwidthBytes": 1048576, "bandwidthFormatted": "1.0 MB"},
+		"quota": {
+			"screenshots": {"limit": 1000, "used": 420, "remaining": 580, "percentUsed": 42},
+			"bandwidth": {"limitFormatted": "10 GB", "usedFormatted": "1.0 MB", "remainingFormatted": "9.9 GB", "percentUsed": 0}
+		},
+		"totals": {"screenshotsCount": 12345, "bandwidthFormatted": "3.2 GB"}
+	}`
+	quotaBody = `{
+		"tier": "PRO",
+		"screenshots": {"limit": 1000, "used": 950, "remaining": 50, "percentUsed": 95},
+		"periodEnds": "2024-06-30"
+	}`
+)
+
+// newUsageServer serves the usage and quota endpoints and counts requests.
+func newUsageServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/usage":
+			w.Write([]byte(usageBody))
+		case "/v1/usage/quota":
+			w.Write([]byte(quotaBody))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestUsageCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(context.Context, []string) error
+		args []string
+		want string
+	}{
+		{
+			name: "usage summary",
+			run:  runUsage,
+			want: "" +
+				"Tier                  PRO\n" +
+				"Period                2024-06-01 to 2024-06-30\n" +
+				"Screenshots           420\n" +
+				"Bandwidth             1.0 MB\n" +
+				"Screenshot quota      [####------]  42% 420/1000 used, 580 remaining\n" +
+				"Bandwidth quota       [----------]   0% 1.0 MB/10 GB used, 9.9 GB remaining\n" +
+				"All-time screenshots  12345\n" +
+				"All-time bandwidth    3.2 GB\n",
+		},
+		{
+			name: "quota summary",
+			run:  runQuota,
+			want: "" +
+				"Tier              PRO\n" +
+				"Screenshot quota  [#########-]  95% 950/1000 used, 50 remaining\n" +
+				"Period ends       2024-06-30\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolate(t)
+			var calls atomic.Int32
+			server := newUsageServer(t, &calls)
+
+			out, err := captureStdout(t, func() error {
+				return tt.run(context.Background(), []string{"--api-key", "test-api-key", "--base-url", server.URL})
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out)
+		})
+	}
+}
+
+func TestUsageCommands_JSON(t *testing.T) {
+	isolate(t)
+	var calls atomic.Int32
+	server := newUsageServer(t, &calls)
+	args := []string{"--api-key", "test-api-key", "--base-url", server.URL, "--json"}
+
+	out, err := captureStdout(t, func() error { return runUsage(context.Background(), args) })
+	require.NoError(t, err)
+	var usage allscreenshots.UsageResponse
+	require.NoError(t, json.Unmarshal([]byte(out), &usage))
+	assert.Equal(t, "PRO", usage.Tier)
+	assert.Equal(t, 420, usage.CurrentPeriod.ScreenshotsCount)
+
+	out, err = captureStdout(t, func() error { return runQuota(context.Background(), args) })
+	require.NoError(t, err)
+	var quota allscreenshots.QuotaStatusResponse
+	require.NoError(t, json.Unmarshal([]byte(out), &quota))
+	assert.Equal(t, 50, quota.Screenshots.Remaining)
+}
+
+func TestUsageCommands_Watch(t *testing.T) {
+	isolate(t)
+	var calls atomic.Int32
+	server := newUsageServer(t, &calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		for calls.Load() < 3 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+	}()
+
+	_, err := captureStdout(t, func() error {
+		return runQuota(ctx, []string{"--api-key", "test-api-key", "--base-url", server.URL, "--json", "--watch", "5ms"})
+	})
+	require.NoError(t, err, "interrupting a watch is not an error")
+	assert.GreaterOrEqual(t, calls.Load(), int32(3))
+}
+
+func TestMeter(t *testing.T) {
+	assert.Equal(t, "[----------]   0%", meter(0))
+	assert.Equal(t, "[#####-----]  50%", meter(50))
+	assert.Equal(t, "[##########] 120%", meter(120))
+	assert.Equal(t, "[----------]  -5%", meter(-5))
+}
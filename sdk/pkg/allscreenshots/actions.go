@@ -0,0 +1,111 @@
+package allscreenshots
+
+import "fmt"
+
+// Action types supported in ScreenshotRequest.Actions.
+const (
+	ActionClick    = "click"
+	ActionType     = "type"
+	ActionScrollTo = "scrollTo"
+	ActionWaitFor  = "waitFor"
+	ActionEvaluate = "evaluate"
+)
+
+// Limits on pre-capture actions.
+const (
+	maxActions            = 50
+	maxActionScriptLength = 10000
+	maxActionTextLength   = 10000
+	maxActionWait         = 30000
+)
+
+// Action is a single interaction step performed, in order, after the page
+// loads and before the screenshot is taken. Build actions with Click, Type,
+// ScrollTo, WaitFor, and Evaluate.
+type Action struct {
+	// Type of the action: click, type, scrollTo, waitFor, or evaluate
+	Type string `json:"type"`
+	// Selector is the CSS selector the action targets
+	Selector string `json:"selector,omitempty"`
+	// Text to type into the element (type actions)
+	Text string `json:"text,omitempty"`
+	// Timeout in milliseconds to wait (waitFor actions, 0-30000)
+	Timeout int `json:"timeout,omitempty"`
+	// Script is JavaScript evaluated in the page (evaluate actions)
+	Script string `json:"script,omitempty"`
+}
+
+// Click returns an action that clicks the element matching selector.
+//
+// Example:
+//
+//	req.Actions = []allscreenshots.Action{
+//	    allscreenshots.Click("#menu-toggle"),
+//	    allscreenshots.WaitFor(".menu.open", 2000),
+//	}
+func Click(selector string) Action {
+	return Action{Type: ActionClick, Selector: selector}
+}
+
+// Type returns an action that types text into the element matching selector.
+func Type(selector, text string) Action {
+	return Action{Type: ActionType, Selector: selector, Text: text}
+}
+
+// ScrollTo returns an action that scrolls the element matching selector into view.
+func ScrollTo(selector string) Action {
+	return Action{Type: ActionScrollTo, Selector: selector}
+}
+
+// WaitFor returns an action that waits up to ms milliseconds for the element
+// matching selector to appear. With an empty selector it pauses for ms.
+func WaitFor(selector string, ms int) Action {
+	return Action{Type: ActionWaitFor, Selector: selector, Timeout: ms}
+}
+
+// Evaluate returns an action that runs js in the page.
+func Evaluate(js string) Action {
+	return Action{Type: ActionEvaluate, Script: js}
+}
+
+// validateActions validates a list of pre-capture actions.
+func validateActions(actions []Action) error {
+	if len(actions) > maxActions {
+		return &ValidationError{Field: "actions", Message: fmt.Sprintf("maximum %d actions allowed", maxActions)}
+	}
+	for i, a := range actions {
+		field := fmt.Sprintf("actions[%d]", i)
+		switch a.Type {
+		case ActionClick, ActionScrollTo:
+			if a.Selector == "" {
+				return &ValidationError{Field: field + ".selector", Message: "selector is required for " + a.Type}
+			}
+		case ActionType:
+			if a.Selector == "" {
+				return &ValidationError{Field: field + ".selector", Message: "selector is required for type"}
+			}
+			if len(a.Text) > maxActionTextLength {
+				return &ValidationError{Field: field + ".text", Message: fmt.Sprintf("text must be at most %d characters", maxActionTextLength)}
+			}
+		case ActionWaitFor:
+			if a.Timeout < 0 || a.Timeout > maxActionWait {
+				return &ValidationError{Field: field + ".timeout", Message: fmt.Sprintf("timeout must be between 0 and %d", maxActionWait)}
+			}
+			if a.Selector == "" && a.Timeout == 0 {
+				return &ValidationError{Field: field, Message: "waitFor requires a selector or a timeout"}
+			}
+		case ActionEvaluate:
+			if a.Script == "" {
+				return &ValidationError{Field: field + ".script", Message: "script is required for evaluate"}
+			}
+			if len(a.Script) > maxActionScriptLength {
+				return &ValidationError{Field: field + ".script", Message: fmt.Sprintf("script must be at most %d characters", maxActionScriptLength)}
+			}
+		case "":
+			return &ValidationError{Field: field + ".type", Message: "action type is required"}
+		default:
+			return &ValidationError{Field: field + ".type", Message: fmt.Sprintf("unknown action type %q", a.Type)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package allscreenshots
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActions_JSON(t *testing.T) {
+	req := &ScreenshotRequest{
+		URL: "https://example.com",
+		Actions: []Action{
+			Click("#menu"),
+			Type("input[name=q]", "shoes"),
+			WaitFor(".results", 2000),
+			Evaluate("window.scrollTo(0, 0)"),
+		},
+	}
+
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"actions":[{"type":"click","selector":"#menu"},{"type":"type","selector":"input[name=q]","text":"shoes"},{"type":"waitFor","selector":".results","timeout":2000},{"type":"evaluate","script":"window.scrollTo(0, 0)"}]`)
+}
+
+func TestActions_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions []Action
+		wantErr string
+	}{
+		{
+			name:    "valid actions",
+			actions: []Action{Click("#a"), ScrollTo("#footer"), WaitFor("", 500)},
+			wantErr: "",
+		},
+		{
+			name:    "click without selector",
+			actions: []Action{Click("")},
+			wantErr: "selector is required for click",
+		},
+		{
+			name:    "wait without selector or timeout",
+			actions: []Action{WaitFor("", 0)},
+			wantErr: "waitFor requires a selector or a timeout",
+		},
+		{
+			name:    "wait too long",
+			actions: []Action{WaitFor(".x", 60000)},
+			wantErr: "timeout must be between 0 and 30000",
+		},
+		{
+			name:    "empty script",
+			actions: []Action{Evaluate("")},
+			wantErr: "script is required for evaluate",
+		},
+		{
+			name:    "script too long",
+			actions: []Action{Evaluate(strings.Repeat("x", 10001))},
+			wantErr: "script must be at most 10000 characters",
+		},
+		{
+			name:    "unknown type",
+			actions: []Action{{Type: "hover", Selector: "#a"}},
+			wantErr: `unknown action type "hover"`,
+		},
+		{
+			name:    "too many actions",
+			actions: make([]Action, 51),
+			wantErr: "maximum 50 actions allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateScreenshotRequest(&ScreenshotRequest{URL: "https://example.com", Actions: tt.actions})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package allscreenshots
+
+import "strings"
+
+// DefaultAPIVersion is the API version requests are made against by default.
+const DefaultAPIVersion = "v1"
+
+// WithAPIVersion sets the API version requests are made against, e.g. "v2".
+// Endpoints can be pinned to another version with WithEndpointVersion while
+// migrating.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(allscreenshots.WithAPIVersion("v2"))
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) {
+		if version != "" {
+			c.apiVersion = version
+		}
+	}
+}
+
+// WithEndpointVersion makes requests to endpoints under prefix use version,
+// overriding WithAPIVersion. prefix is an unversioned path such as
+// "/screenshots/compose"; it matches whole path segments, and the longest
+// matching prefix wins.
+//
+// Example:
+//
+//	// Everything on v1, except the compose endpoints already migrated to v2
+//	client := allscreenshots.NewClient(
+//	    allscreenshots.WithEndpointVersion("/screenshots/compose", "v2"),
+//	)
+func WithEndpointVersion(prefix, version string) ClientOption {
+	return func(c *Client) {
+		if c.endpointVersions == nil {
+			c.endpointVersions = make(map[string]string)
+		}
+		c.endpointVersions["/"+strings.Trim(prefix, "/")] = version
+	}
+}
+
+// endpoint returns the versioned request path for an unversioned API path
+// such as "/screenshots/jobs/abc".
+func (c *Client) endpoint(path string) string {
+	version := c.apiVersion
+	if version == "" {
+		version = DefaultAPIVersion
+	}
+
+	p := path
+	if i := strings.IndexByte(p, '?'); i >= 0 {
+		p = p[:i]
+	}
+	longest := -1
+	for prefix, v := range c.endpointVersions {
+		if len(prefix) > longest && (p == prefix || strings.HasPrefix(p, prefix+"/")) {
+			version, longest = v, len(prefix)
+		}
+	}
+	return "/" + version + path
+}
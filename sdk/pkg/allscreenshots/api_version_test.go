@@ -0,0 +1,62 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Endpoint(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ClientOption
+		path string
+		want string
+	}{
+		{name: "default", path: "/screenshots/jobs", want: "/v1/screenshots/jobs"},
+		{name: "client version", opts: []ClientOption{WithAPIVersion("v2")}, path: "/schedules", want: "/v2/schedules"},
+		{
+			name: "endpoint override",
+			opts: []ClientOption{WithEndpointVersion("/screenshots/compose", "v2")},
+			path: "/screenshots/compose/jobs/abc",
+			want: "/v2/screenshots/compose/jobs/abc",
+		},
+		{
+			name: "override matches whole segments",
+			opts: []ClientOption{WithEndpointVersion("/screenshots/compose", "v2")},
+			path: "/screenshots/composer",
+			want: "/v1/screenshots/composer",
+		},
+		{
+			name: "longest prefix wins",
+			opts: []ClientOption{WithAPIVersion("v2"), WithEndpointVersion("screenshots/", "v1"), WithEndpointVersion("/screenshots/bulk", "v3")},
+			path: "/screenshots/bulk?limit=5",
+			want: "/v3/screenshots/bulk?limit=5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(tt.opts...)
+			assert.Equal(t, tt.want, client.endpoint(tt.path))
+		})
+	}
+}
+
+func TestClient_WithAPIVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/usage/quota", r.URL.Path)
+		json.NewEncoder(w).Encode(QuotaStatusResponse{Tier: "pro"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithAPIVersion("v2"))
+	status, err := client.GetQuotaStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "pro", status.Tier)
+}
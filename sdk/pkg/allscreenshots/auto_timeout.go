@@ -0,0 +1,54 @@
+package allscreenshots
+
+import (
+	"context"
+	"time"
+)
+
+// autoTimeoutMargin is how much earlier than the caller's deadline the API
+// is asked to give up, leaving time for the error response to arrive.
+const autoTimeoutMargin = time.Second
+
+// Bounds of ScreenshotRequest.Timeout, in milliseconds.
+const (
+	minRequestTimeout = 1000
+	maxRequestTimeout = 60000
+)
+
+// WithAutoTimeout makes synchronous captures that do not set
+// ScreenshotRequest.Timeout derive one from the context deadline: slightly
+// under the time remaining, clamped to 1000-60000 ms. The API then gives up
+// on a slow page before the client stops waiting for it, instead of
+// finishing a render nobody receives.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(allscreenshots.WithAutoTimeout())
+//
+//	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+//	defer cancel()
+//	// Sent with a timeout of about 19000 ms.
+//	imageData, err := client.Screenshot(ctx, &allscreenshots.ScreenshotRequest{URL: "https://example.com"})
+func WithAutoTimeout() ClientOption {
+	return func(c *Client) {
+		c.autoTimeout = true
+	}
+}
+
+// applyAutoTimeout returns req with a timeout derived from the deadline of
+// ctx, when auto timeouts are enabled and req sets none. The caller's
+// request is never modified.
+func (c *Client) applyAutoTimeout(ctx context.Context, req *ScreenshotRequest) *ScreenshotRequest {
+	if !c.autoTimeout || req.Timeout != 0 {
+		return req
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return req
+	}
+
+	ms := int((time.Until(deadline) - autoTimeoutMargin) / time.Millisecond)
+	out := *req
+	out.Timeout = min(max(ms, minRequestTimeout), maxRequestTimeout)
+	return &out
+}
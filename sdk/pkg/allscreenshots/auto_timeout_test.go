@@ -0,0 +1,116 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithAutoTimeout(t *testing.T) {
+	var timeouts []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		timeouts = append(timeouts, body.Timeout)
+		w.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithAutoTimeout())
+	req := &ScreenshotRequest{URL: "https://example.com"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	_, err := client.Screenshot(ctx, req)
+	require.NoError(t, err)
+	assert.Zero(t, req.Timeout)
+
+	short, cancelShort := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancelShort()
+	_, err = client.Screenshot(short, req)
+	require.NoError(t, err)
+
+	long, cancelLong := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancelLong()
+	_, err = client.Screenshot(long, req)
+	require.NoError(t, err)
+
+	_, err = client.Screenshot(ctx, &ScreenshotRequest{URL: "https://example.com", Timeout: 5000})
+	require.NoError(t, err)
+
+	_, err = client.Screenshot(context.Background(), req)
+	require.NoError(t, err)
+
+	require.Len(t, timeouts, 5)
+	assert.InDelta(t, 19000, timeouts[0], 500)
+	assert.Equal(t, 1000, timeouts[1])
+	assert.Equal(t, 60000, timeouts[2])
+	assert.Equal(t, 5000, timeouts[3])
+	assert.Zero(t, timeouts[4])
+}
+
+func TestClient_AutoTimeoutDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.NotContains(t, body, "timeout")
+		w.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	_, err := client.Screenshot(ctx, &ScreenshotRequest{URL: "https://example.com"})
+	require.NoError(t, err)
+}
+
+func TestClient_WithAutoTimeout_ScreenshotAndStore(t *testing.T) {
+	var timeout int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		timeout = body.Timeout
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithAutoTimeout())
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := client.ScreenshotAndStore(ctx, &ScreenshotRequest{URL: "https://example.com"}, &recordingUploader{}, "shots/example.png")
+	require.NoError(t, err)
+	assert.InDelta(t, 19000, timeout, 500)
+}
+
+func TestClient_WithAutoTimeout_ScreenshotToPresignedURL(t *testing.T) {
+	var timeout int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			return
+		}
+		var body ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		timeout = body.Timeout
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithAutoTimeout())
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	err := client.ScreenshotToPresignedURL(ctx, &ScreenshotRequest{URL: "https://example.com"}, server.URL+"/upload?sig=1")
+	require.NoError(t, err)
+	assert.InDelta(t, 19000, timeout, 500)
+}
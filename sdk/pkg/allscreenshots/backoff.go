@@ -0,0 +1,82 @@
+package allscreenshots
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffStrategy selects how the wait between retries is computed from the
+// bounds set with WithRetryWait.
+type BackoffStrategy int
+
+const (
+	// BackoffFullJitter waits a random duration between zero and the
+	// exponential backoff. Retries from many clients spread out evenly, so
+	// it is the default.
+	BackoffFullJitter BackoffStrategy = iota
+	// BackoffEqualJitter waits half the exponential backoff plus a random
+	// duration up to the other half, trading some spread for a minimum wait.
+	BackoffEqualJitter
+	// BackoffConstant always waits the minimum retry wait.
+	BackoffConstant
+	// BackoffDecorrelated waits a random duration between the minimum wait
+	// and three times the previous wait, so each retry's wait depends on the
+	// last one rather than the attempt number.
+	BackoffDecorrelated
+)
+
+// String returns the strategy's name.
+func (s BackoffStrategy) String() string {
+	switch s {
+	case BackoffFullJitter:
+		return "full-jitter"
+	case BackoffEqualJitter:
+		return "equal-jitter"
+	case BackoffConstant:
+		return "constant"
+	case BackoffDecorrelated:
+		return "decorrelated"
+	}
+	return "unknown"
+}
+
+// WithBackoffStrategy sets how the wait between retries is computed. Waits
+// always stay within the bounds set with WithRetryWait.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(
+//	    allscreenshots.WithBackoffStrategy(allscreenshots.BackoffDecorrelated),
+//	    allscreenshots.WithRetryWait(500*time.Millisecond, 20*time.Second),
+//	)
+func WithBackoffStrategy(strategy BackoffStrategy) ClientOption {
+	return func(c *Client) {
+		c.backoff = strategy
+	}
+}
+
+// calculateBackoff calculates the wait before a retry attempt. prev is the
+// wait before the previous attempt, or zero before the first retry.
+func (c *Client) calculateBackoff(attempt int, prev time.Duration) time.Duration {
+	base := float64(c.retryWaitMin)
+	limit := float64(c.retryWaitMax)
+
+	// Exponential backoff: min * 2^(attempt-1), capped at max
+	exp := math.Min(base*math.Pow(2, float64(attempt-1)), limit)
+
+	var backoff float64
+	switch c.backoff {
+	case BackoffEqualJitter:
+		backoff = exp/2 + rand.Float64()*exp/2
+	case BackoffConstant:
+		backoff = base
+	case BackoffDecorrelated:
+		upper := math.Max(float64(prev)*3, base)
+		backoff = base + rand.Float64()*(upper-base)
+	default:
+		backoff = rand.Float64() * exp
+	}
+
+	return time.Duration(math.Min(backoff, limit))
+}
@@ -0,0 +1,59 @@
+package allscreenshots
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy BackoffStrategy
+		attempt  int
+		prev     time.Duration
+		min, max time.Duration
+	}{
+		{name: "full jitter first retry", strategy: BackoffFullJitter, attempt: 1, min: 0, max: 1 * time.Second},
+		{name: "full jitter third retry", strategy: BackoffFullJitter, attempt: 3, min: 0, max: 4 * time.Second},
+		{name: "full jitter capped", strategy: BackoffFullJitter, attempt: 10, min: 0, max: 30 * time.Second},
+		{name: "equal jitter third retry", strategy: BackoffEqualJitter, attempt: 3, min: 2 * time.Second, max: 4 * time.Second},
+		{name: "equal jitter capped", strategy: BackoffEqualJitter, attempt: 10, min: 15 * time.Second, max: 30 * time.Second},
+		{name: "constant", strategy: BackoffConstant, attempt: 5, min: 1 * time.Second, max: 1 * time.Second},
+		{name: "decorrelated first retry", strategy: BackoffDecorrelated, attempt: 1, min: 1 * time.Second, max: 1 * time.Second},
+		{name: "decorrelated after previous wait", strategy: BackoffDecorrelated, attempt: 2, prev: 2 * time.Second, min: 1 * time.Second, max: 6 * time.Second},
+		{name: "decorrelated capped", strategy: BackoffDecorrelated, attempt: 4, prev: 25 * time.Second, min: 1 * time.Second, max: 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(
+				WithRetryWait(1*time.Second, 30*time.Second),
+				WithBackoffStrategy(tt.strategy),
+			)
+			for i := 0; i < 100; i++ {
+				wait := client.calculateBackoff(tt.attempt, tt.prev)
+				assert.GreaterOrEqual(t, wait, tt.min)
+				assert.LessOrEqual(t, wait, tt.max)
+			}
+		})
+	}
+}
+
+func TestCalculateBackoff_FullJitterSpreads(t *testing.T) {
+	client := NewClient(WithRetryWait(1*time.Second, 30*time.Second))
+
+	// Retries from many clients must not line up on the same instant.
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 50; i++ {
+		seen[client.calculateBackoff(4, 0)] = true
+	}
+	assert.Greater(t, len(seen), 40)
+}
+
+func TestBackoffStrategy_String(t *testing.T) {
+	assert.Equal(t, "full-jitter", BackoffFullJitter.String())
+	assert.Equal(t, "decorrelated", BackoffDecorrelated.String())
+	assert.Equal(t, "unknown", BackoffStrategy(99).String())
+}
@@ -0,0 +1,82 @@
+// Package baseline stores approved reference screenshots for visual
+// regression testing.
+//
+// A Store keeps two images per Key: the approved baseline returned by Get,
+// and a candidate written by Update. Approve promotes the candidate to be
+// the new baseline, so a changed page only becomes the reference once
+// someone has reviewed it.
+package baseline
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotFound is returned when a key has no baseline, or no candidate to approve.
+var ErrNotFound = errors.New("baseline: not found")
+
+// Key identifies a baseline by the page and the way it was rendered.
+type Key struct {
+	// URL of the captured page
+	URL string `json:"url"`
+	// Device preset name, if any
+	Device string `json:"device,omitempty"`
+	// Width of the viewport, if set explicitly
+	Width int `json:"width,omitempty"`
+	// Height of the viewport, if set explicitly
+	Height int `json:"height,omitempty"`
+}
+
+// ID returns a stable, filesystem- and object-key-safe identifier for k. It
+// starts with a readable slug of the URL and ends with a hash of the whole key.
+func (k Key) ID() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s\n%d\n%d", k.URL, k.Device, k.Width, k.Height)))
+	hash := hex.EncodeToString(sum[:])[:16]
+
+	slug := k.URL
+	slug = strings.TrimPrefix(slug, "https://")
+	slug = strings.TrimPrefix(slug, "http://")
+	slug = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '-'
+	}, slug)
+	slug = strings.Trim(slug, "-")
+	if len(slug) > 64 {
+		slug = slug[:64]
+	}
+	if slug == "" {
+		return hash
+	}
+	return slug + "-" + hash
+}
+
+// String returns a human-readable description of k.
+func (k Key) String() string {
+	s := k.URL
+	if k.Device != "" {
+		s += " [" + k.Device + "]"
+	}
+	if k.Width > 0 || k.Height > 0 {
+		s += fmt.Sprintf(" [%dx%d]", k.Width, k.Height)
+	}
+	return s
+}
+
+// Store persists baseline and candidate images. Implementations must be safe
+// for concurrent use.
+type Store interface {
+	// Get returns the approved baseline for key, or ErrNotFound.
+	Get(ctx context.Context, key Key) ([]byte, error)
+	// Update records image as the candidate for key, replacing any previous candidate.
+	Update(ctx context.Context, key Key, image []byte) error
+	// Approve promotes the candidate for key to be its baseline, or returns
+	// ErrNotFound if there is no candidate.
+	Approve(ctx context.Context, key Key) error
+}
@@ -0,0 +1,97 @@
+package baseline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey_ID(t *testing.T) {
+	desktop := Key{URL: "https://example.com/pricing?plan=pro", Device: "Desktop HD"}
+	mobile := Key{URL: "https://example.com/pricing?plan=pro", Device: "iPhone 14"}
+
+	assert.True(t, strings.HasPrefix(desktop.ID(), "example-com-pricing-plan-pro-"))
+	assert.Equal(t, desktop.ID(), desktop.ID())
+	assert.NotEqual(t, desktop.ID(), mobile.ID())
+	assert.NotEqual(t, desktop.ID(), Key{URL: desktop.URL, Width: 1280, Height: 720}.ID())
+	assert.Equal(t, "https://example.com [1280x720]", Key{URL: "https://example.com", Width: 1280, Height: 720}.String())
+}
+
+// memObjects is an in-memory ObjectClient.
+type memObjects struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (m *memObjects) GetObject(ctx context.Context, bucket, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[bucket+"/"+key]
+	if !ok {
+		return nil, fmt.Errorf("no such key %q: %w", key, ErrNotFound)
+	}
+	return data, nil
+}
+
+func (m *memObjects) PutObject(ctx context.Context, bucket, key string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[bucket+"/"+key] = data
+	return nil
+}
+
+func (m *memObjects) DeleteObject(ctx context.Context, bucket, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, bucket+"/"+key)
+	return nil
+}
+
+func TestStores(t *testing.T) {
+	fsStore, err := NewFSStore(t.TempDir())
+	require.NoError(t, err)
+	objects := &memObjects{objects: map[string][]byte{}}
+
+	stores := map[string]Store{
+		"fs": fsStore,
+		"s3": NewS3Store(objects, "bucket", "baselines"),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			key := Key{URL: "https://example.com", Device: "Desktop HD"}
+
+			_, err := store.Get(ctx, key)
+			assert.ErrorIs(t, err, ErrNotFound)
+			assert.ErrorIs(t, store.Approve(ctx, key), ErrNotFound)
+
+			require.NoError(t, store.Update(ctx, key, []byte("v1")))
+			_, err = store.Get(ctx, key)
+			assert.ErrorIs(t, err, ErrNotFound, "candidates are not baselines until approved")
+
+			require.NoError(t, store.Approve(ctx, key))
+			data, err := store.Get(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("v1"), data)
+
+			require.NoError(t, store.Update(ctx, key, []byte("v2")))
+			data, err = store.Get(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("v1"), data)
+
+			require.NoError(t, store.Approve(ctx, key))
+			data, err = store.Get(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("v2"), data)
+			assert.ErrorIs(t, store.Approve(ctx, key), ErrNotFound)
+		})
+	}
+
+	assert.Contains(t, objects.objects, "bucket/baselines/"+Key{URL: "https://example.com", Device: "Desktop HD"}.ID()+".png")
+}
@@ -0,0 +1,82 @@
+package baseline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FSStore keeps baselines as files in a directory: <id>.png for the baseline
+// and <id>.candidate.png for the candidate.
+type FSStore struct {
+	dir string
+}
+
+// NewFSStore returns a store rooted at dir, creating it if needed.
+//
+// Example:
+//
+//	store, err := baseline.NewFSStore("testdata/baselines")
+func NewFSStore(dir string) (*FSStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("baseline: failed to create directory: %w", err)
+	}
+	return &FSStore{dir: dir}, nil
+}
+
+// Get returns the approved baseline for key.
+func (s *FSStore) Get(ctx context.Context, key Key) ([]byte, error) {
+	data, err := os.ReadFile(s.baselinePath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("baseline: failed to read %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// Update records image as the candidate for key.
+func (s *FSStore) Update(ctx context.Context, key Key, image []byte) error {
+	// Write to a temporary file first so readers never see a partial image.
+	tmp, err := os.CreateTemp(s.dir, ".candidate-*")
+	if err != nil {
+		return fmt.Errorf("baseline: failed to write %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(image); err != nil {
+		tmp.Close()
+		return fmt.Errorf("baseline: failed to write %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("baseline: failed to write %s: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), s.candidatePath(key)); err != nil {
+		return fmt.Errorf("baseline: failed to write %s: %w", key, err)
+	}
+	return nil
+}
+
+// Approve promotes the candidate for key to be its baseline.
+func (s *FSStore) Approve(ctx context.Context, key Key) error {
+	err := os.Rename(s.candidatePath(key), s.baselinePath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("baseline: failed to approve %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *FSStore) baselinePath(key Key) string {
+	return filepath.Join(s.dir, key.ID()+".png")
+}
+
+func (s *FSStore) candidatePath(key Key) string {
+	return filepath.Join(s.dir, key.ID()+".candidate.png")
+}
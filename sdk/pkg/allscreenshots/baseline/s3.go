@@ -0,0 +1,82 @@
+package baseline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+)
+
+// ObjectClient is the subset of an S3-compatible client used by S3Store.
+// Adapt the AWS SDK, MinIO, or any other client to it; GetObject must return
+// an error wrapping ErrNotFound when the object does not exist.
+type ObjectClient interface {
+	GetObject(ctx context.Context, bucket, key string) ([]byte, error)
+	PutObject(ctx context.Context, bucket, key string, data []byte) error
+	DeleteObject(ctx context.Context, bucket, key string) error
+}
+
+// S3Store keeps baselines in an S3-compatible bucket under a key prefix:
+// <prefix>/<id>.png for the baseline and <prefix>/<id>.candidate.png for the
+// candidate.
+type S3Store struct {
+	client ObjectClient
+	bucket string
+	prefix string
+}
+
+// NewS3Store returns a store that keeps baselines in bucket under prefix.
+//
+// Example:
+//
+//	store := baseline.NewS3Store(myS3Adapter, "visual-tests", "baselines/main")
+func NewS3Store(client ObjectClient, bucket, prefix string) *S3Store {
+	return &S3Store{client: client, bucket: bucket, prefix: prefix}
+}
+
+// Get returns the approved baseline for key.
+func (s *S3Store) Get(ctx context.Context, key Key) ([]byte, error) {
+	data, err := s.client.GetObject(ctx, s.bucket, s.baselineKey(key))
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("baseline: failed to read %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// Update records image as the candidate for key.
+func (s *S3Store) Update(ctx context.Context, key Key, image []byte) error {
+	if err := s.client.PutObject(ctx, s.bucket, s.candidateKey(key), image); err != nil {
+		return fmt.Errorf("baseline: failed to write %s: %w", key, err)
+	}
+	return nil
+}
+
+// Approve promotes the candidate for key to be its baseline. The candidate is
+// copied before it is deleted, so an interrupted approval can be retried.
+func (s *S3Store) Approve(ctx context.Context, key Key) error {
+	data, err := s.client.GetObject(ctx, s.bucket, s.candidateKey(key))
+	if errors.Is(err, ErrNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("baseline: failed to approve %s: %w", key, err)
+	}
+	if err := s.client.PutObject(ctx, s.bucket, s.baselineKey(key), data); err != nil {
+		return fmt.Errorf("baseline: failed to approve %s: %w", key, err)
+	}
+	if err := s.client.DeleteObject(ctx, s.bucket, s.candidateKey(key)); err != nil {
+		return fmt.Errorf("baseline: failed to approve %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *S3Store) baselineKey(key Key) string {
+	return path.Join(s.prefix, key.ID()+".png")
+}
+
+func (s *S3Store) candidateKey(key Key) string {
+	return path.Join(s.prefix, key.ID()+".candidate.png")
+}
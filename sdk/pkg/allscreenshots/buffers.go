@@ -0,0 +1,79 @@
+package allscreenshots
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+const (
+	// copyBufferSize is the size of the buffers used to stream response
+	// bodies.
+	copyBufferSize = 32 << 10
+	// maxPreallocSize caps how much memory is allocated up front from a
+	// response's Content-Length; larger bodies grow as they are read.
+	maxPreallocSize = 32 << 20
+	// maxPooledBufferSize is the largest read buffer returned to the pool,
+	// so one huge capture does not pin its memory for the life of the process.
+	maxPooledBufferSize = 8 << 20
+)
+
+// copyBufferPool holds buffers for streaming response bodies to writers.
+var copyBufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, copyBufferSize)
+		return &buf
+	},
+}
+
+// readBufferPool holds buffers for reading bodies of unknown length.
+var readBufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// copyBody streams r to w through a pooled buffer.
+func copyBody(w io.Writer, r io.Reader) (int64, error) {
+	buf := copyBufferPool.Get().(*[]byte)
+	defer copyBufferPool.Put(buf)
+	return io.CopyBuffer(w, r, *buf)
+}
+
+// readBody reads r to the end. size is the body's Content-Length, or -1 if
+// unknown; a known size is allocated exactly once, and an unknown one is
+// read through a pooled buffer and copied out at its final length.
+func readBody(r io.Reader, size int64) ([]byte, error) {
+	if size <= 0 || size > maxPreallocSize {
+		return readPooled(r)
+	}
+
+	data := make([]byte, size)
+	n, err := io.ReadFull(r, data)
+	if err != nil {
+		return data[:n], err
+	}
+	// net/http enforces Content-Length, but a custom transport might not;
+	// pick up anything past it.
+	rest, err := readPooled(r)
+	if len(rest) > 0 {
+		data = append(data, rest...)
+	}
+	return data, err
+}
+
+// readPooled reads r to the end through a pooled buffer.
+func readPooled(r io.Reader) ([]byte, error) {
+	buf := readBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			readBufferPool.Put(buf)
+		}
+	}()
+
+	_, err := buf.ReadFrom(r)
+	data := make([]byte, buf.Len())
+	copy(data, buf.Bytes())
+	return data, err
+}
@@ -0,0 +1,58 @@
+package allscreenshots
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBody(t *testing.T) {
+	body := strings.Repeat("0123456789", 1000)
+
+	tests := []struct {
+		name    string
+		size    int64
+		want    string
+		wantErr error
+	}{
+		{name: "known length", size: int64(len(body)), want: body},
+		{name: "unknown length", size: -1, want: body},
+		{name: "length too small", size: 10, want: body},
+		{name: "length too large", size: int64(len(body)) + 5, want: body, wantErr: io.ErrUnexpectedEOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := readBody(iotest.HalfReader(strings.NewReader(body)), tt.size)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestReadBody_DoesNotShareBuffers(t *testing.T) {
+	first, err := readBody(strings.NewReader("first"), -1)
+	require.NoError(t, err)
+	second, err := readBody(strings.NewReader("second"), -1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "first", string(first))
+	assert.Equal(t, "second", string(second))
+}
+
+func TestCopyBody(t *testing.T) {
+	var out bytes.Buffer
+	n, err := copyBody(struct{ io.Writer }{&out}, strings.NewReader("streamed"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), n)
+	assert.Equal(t, "streamed", out.String())
+}
@@ -0,0 +1,150 @@
+package allscreenshots
+
+import (
+	"context"
+)
+
+// ResultIterator yields the jobs of a bulk job as they finish, together with
+// their image data. Create one with IterateBulkResults and advance it with
+// Next until it returns false, then check Err.
+type ResultIterator struct {
+	client *Client
+	ctx    context.Context
+	bulkID string
+	poll   pollConfig
+	poller *poller
+
+	started  bool
+	finished bool
+	seen     map[string]bool
+	pending  []BulkJobDetailInfo
+
+	job  BulkJobDetailInfo
+	data []byte
+	err  error
+}
+
+// IterateBulkResults returns an iterator over the jobs of a bulk job that
+// yields each job once it has finished, so results can be processed while
+// the rest of the batch is still running. Completed jobs come with their
+// image data; failed and cancelled jobs are yielded without data so callers
+// can account for them. The bulk job is polled with the intervals set by
+// opts.
+//
+// Example:
+//
+//	it := client.IterateBulkResults(ctx, bulk.ID)
+//	for it.Next() {
+//	    job := it.Job()
+//	    if job.Status != string(allscreenshots.JobStatusCompleted) {
+//	        log.Printf("%s failed: %s", job.URL, job.ErrorMessage)
+//	        continue
+//	    }
+//	    os.WriteFile(job.ID+".png", it.Data(), 0644)
+//	}
+//	if err := it.Err(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) IterateBulkResults(ctx context.Context, bulkID string, opts ...PollOption) *ResultIterator {
+	it := &ResultIterator{
+		client: c,
+		ctx:    ctx,
+		bulkID: bulkID,
+		poll:   newPollConfig(opts),
+		seen:   make(map[string]bool),
+	}
+	it.poller = it.poll.newPoller("bulk job " + bulkID)
+	if bulkID == "" {
+		it.err = &ValidationError{Field: "bulkId", Message: "bulk job ID is required"}
+	}
+	return it
+}
+
+// Next advances to the next finished job, waiting for one if necessary. It
+// returns false when every job has been yielded or an error occurred.
+func (it *ResultIterator) Next() bool {
+	it.job, it.data = BulkJobDetailInfo{}, nil
+	if it.err != nil {
+		return false
+	}
+
+	for len(it.pending) == 0 {
+		if it.finished {
+			return false
+		}
+		if err := it.refresh(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.job, it.pending = it.pending[0], it.pending[1:]
+	if it.job.Status == string(JobStatusCompleted) {
+		data, err := it.client.GetBulkJobResult(it.ctx, it.bulkID, it.job.ID)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.data = data
+	}
+	return true
+}
+
+// Job returns the job yielded by the last call to Next.
+func (it *ResultIterator) Job() BulkJobDetailInfo {
+	return it.job
+}
+
+// Data returns the image data of the job yielded by the last call to Next,
+// or nil if the job did not complete.
+func (it *ResultIterator) Data() []byte {
+	return it.data
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ResultIterator) Err() error {
+	return it.err
+}
+
+// refresh polls the bulk job, waiting first if the previous poll found no
+// newly finished jobs, and queues the jobs that finished since.
+func (it *ResultIterator) refresh() error {
+	if it.started {
+		if err := it.poller.wait(it.ctx); err != nil {
+			return err
+		}
+	}
+	it.started = true
+
+	status, err := it.client.GetBulkJob(it.ctx, it.bulkID)
+	if err != nil {
+		return err
+	}
+
+	finished := 0
+	for _, job := range status.Jobs {
+		if !isFinishedStatus(JobStatus(job.Status)) {
+			continue
+		}
+		finished++
+		if !it.seen[job.ID] {
+			it.seen[job.ID] = true
+			it.pending = append(it.pending, job)
+		}
+	}
+	if len(it.pending) > 0 {
+		// Results are arriving; check again soon.
+		it.poller.reset()
+	}
+	allFinished := len(status.Jobs) > 0 && finished == len(status.Jobs) && len(status.Jobs) >= status.TotalJobs
+	it.finished = allFinished || isFinishedStatus(JobStatus(status.Status))
+	if it.poll.progress != nil {
+		it.poll.progress(finished, len(status.Jobs))
+	}
+	return nil
+}
+
+// isFinishedStatus reports whether a job or bulk job status is final.
+func isFinishedStatus(status JobStatus) bool {
+	return status == JobStatusCompleted || status == JobStatusFailed || status == JobStatusCancelled
+}
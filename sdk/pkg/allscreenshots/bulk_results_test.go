@@ -0,0 +1,112 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetBulkJobResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots/bulk/bulk-1/jobs/job-2/result", r.URL.Path)
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png-2"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	data, err := client.GetBulkJobResult(context.Background(), "bulk-1", "job-2")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png-2"), data)
+
+	_, err = client.GetBulkJobResult(context.Background(), "bulk-1", "")
+	assert.True(t, IsValidationError(err))
+}
+
+func TestClient_IterateBulkResults(t *testing.T) {
+	polls := []BulkStatusResponse{
+		{ID: "bulk-1", Status: "PROCESSING", TotalJobs: 3, Jobs: []BulkJobDetailInfo{
+			{ID: "job-1", Status: "COMPLETED"}, {ID: "job-2", Status: "PROCESSING"}, {ID: "job-3", Status: "QUEUED"},
+		}},
+		{ID: "bulk-1", Status: "PROCESSING", TotalJobs: 3, Jobs: []BulkJobDetailInfo{
+			{ID: "job-1", Status: "COMPLETED"}, {ID: "job-2", Status: "PROCESSING"}, {ID: "job-3", Status: "QUEUED"},
+		}},
+		{ID: "bulk-1", Status: "COMPLETED", TotalJobs: 3, Jobs: []BulkJobDetailInfo{
+			{ID: "job-1", Status: "COMPLETED"}, {ID: "job-2", Status: "FAILED", ErrorMessage: "timeout"}, {ID: "job-3", Status: "COMPLETED"},
+		}},
+	}
+	var poll atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/screenshots/bulk/bulk-1":
+			i := int(poll.Add(1)) - 1
+			if i >= len(polls) {
+				i = len(polls) - 1
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(polls[i])
+		case strings.HasSuffix(r.URL.Path, "/result"):
+			id := strings.Split(r.URL.Path, "/")[6]
+			assert.NotEqual(t, "job-2", id, "failed jobs have no result")
+			w.Write([]byte("png-" + id))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	var progress []int
+	it := client.IterateBulkResults(context.Background(), "bulk-1",
+		WithPollInterval(time.Millisecond),
+		WithPollProgress(func(completed, total int) { progress = append(progress, completed) }),
+	)
+
+	var ids []string
+	for it.Next() {
+		job := it.Job()
+		ids = append(ids, job.ID)
+		if job.Status == "COMPLETED" {
+			assert.Equal(t, []byte("png-"+job.ID), it.Data())
+		} else {
+			assert.Nil(t, it.Data())
+			assert.Equal(t, "timeout", job.ErrorMessage)
+		}
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"job-1", "job-2", "job-3"}, ids)
+	assert.Equal(t, []int{1, 1, 3}, progress)
+	assert.Equal(t, int32(3), poll.Load())
+	assert.False(t, it.Next())
+}
+
+func TestClient_IterateBulkResults_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(BulkStatusResponse{ID: "bulk-1", Status: "PROCESSING", TotalJobs: 1,
+			Jobs: []BulkJobDetailInfo{{ID: "job-1", Status: "PROCESSING"}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	it := client.IterateBulkResults(context.Background(), "")
+	assert.False(t, it.Next())
+	assert.True(t, IsValidationError(it.Err()))
+
+	it = client.IterateBulkResults(context.Background(), "bulk-1",
+		WithPollInterval(5*time.Millisecond), WithPollTimeout(20*time.Millisecond))
+	assert.False(t, it.Next())
+	var timeoutErr *TimeoutError
+	assert.ErrorAs(t, it.Err(), &timeoutErr)
+}
@@ -0,0 +1,104 @@
+// Package cache provides ScreenshotCache implementations for
+// allscreenshots.WithCache: an in-process LRU cache and a Redis-backed
+// cache that several processes can share.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultMaxBytes is the capacity of a Memory cache created with a
+// non-positive size.
+const DefaultMaxBytes = 64 << 20
+
+// ErrMiss is returned by a RedisClient when a key does not exist.
+var ErrMiss = errors.New("cache: miss")
+
+// Memory is an in-process cache that evicts the least recently used entries
+// once its total size exceeds a limit.
+type Memory struct {
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	order    *list.List
+	entries  map[string]*list.Element
+	now      func() time.Time
+}
+
+// memoryEntry is a cached image in a Memory cache.
+type memoryEntry struct {
+	key     string
+	data    []byte
+	expires time.Time
+}
+
+// NewMemory creates an in-process cache holding up to maxBytes of images
+// (default 64 MiB). Images larger than the whole cache are not stored.
+func NewMemory(maxBytes int64) *Memory {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	return &Memory{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Get returns a copy of the image stored under key.
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := el.Value.(*memoryEntry)
+	if !m.now().Before(entry.expires) {
+		m.remove(el)
+		return nil, false, nil
+	}
+	m.order.MoveToFront(el)
+	return append([]byte(nil), entry.data...), true, nil
+}
+
+// Set stores a copy of data under key for ttl, so callers may go on
+// modifying data.
+func (m *Memory) Set(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.remove(el)
+	}
+	if int64(len(data)) > m.maxBytes || ttl <= 0 {
+		return nil
+	}
+	entry := &memoryEntry{key: key, data: append([]byte(nil), data...), expires: m.now().Add(ttl)}
+	m.entries[key] = m.order.PushFront(entry)
+	m.size += int64(len(data))
+	for m.size > m.maxBytes {
+		m.remove(m.order.Back())
+	}
+	return nil
+}
+
+// Len returns the number of cached images, including expired ones not yet evicted.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// remove deletes el from the cache. The caller must hold m.mu.
+func (m *Memory) remove(el *list.Element) {
+	entry := m.order.Remove(el).(*memoryEntry)
+	delete(m.entries, entry.key)
+	m.size -= int64(len(entry.data))
+}
@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ allscreenshots.ScreenshotCache = (*Memory)(nil)
+	_ allscreenshots.ScreenshotCache = (*Redis)(nil)
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(10)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	require.NoError(t, m.Set(ctx, "a", []byte("aaaa"), time.Minute))
+	require.NoError(t, m.Set(ctx, "b", []byte("bbbb"), time.Minute))
+
+	data, ok, err := m.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("aaaa"), data)
+
+	// "b" is now the least recently used entry and is evicted.
+	require.NoError(t, m.Set(ctx, "c", []byte("cccc"), time.Minute))
+	_, ok, _ = m.Get(ctx, "b")
+	assert.False(t, ok)
+	assert.Equal(t, 2, m.Len())
+
+	require.NoError(t, m.Set(ctx, "huge", make([]byte, 11), time.Minute))
+	_, ok, _ = m.Get(ctx, "huge")
+	assert.False(t, ok, "images larger than the cache are not stored")
+
+	now = now.Add(time.Minute)
+	_, ok, _ = m.Get(ctx, "a")
+	assert.False(t, ok, "expired entries are misses")
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestMemory_Copies(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(0)
+
+	data := []byte("image")
+	require.NoError(t, m.Set(ctx, "a", data, time.Minute))
+	data[0] = 'X'
+
+	got, ok, err := m.Get(ctx, "a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []byte("image"), got)
+
+	got[0] = 'Y'
+	got, _, _ = m.Get(ctx, "a")
+	assert.Equal(t, []byte("image"), got, "modifying a returned image does not change the cached one")
+}
+
+type fakeRedis struct {
+	data map[string][]byte
+	ttl  time.Duration
+	err  error
+}
+
+func (f *fakeRedis) Get(ctx context.Context, key string) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	data, ok := f.data[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	return data, nil
+}
+
+func (f *fakeRedis) Set(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	f.data[key] = data
+	f.ttl = ttl
+	return nil
+}
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+	rdb := &fakeRedis{data: map[string][]byte{}}
+	r := NewRedis(rdb, "shots:")
+
+	_, ok, err := r.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, r.Set(ctx, "k", []byte("png"), time.Hour))
+	assert.Equal(t, []byte("png"), rdb.data["shots:k"])
+	assert.Equal(t, time.Hour, rdb.ttl)
+
+	data, ok, err := r.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("png"), data)
+
+	rdb.err = errors.New("connection refused")
+	_, _, err = r.Get(ctx, "k")
+	assert.EqualError(t, err, "connection refused")
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RedisClient is the subset of a Redis client used by Redis. Adapt go-redis
+// or any other client to it; Get must return an error wrapping ErrMiss when
+// the key does not exist.
+//
+// Example adapter for github.com/redis/go-redis/v9:
+//
+//	type goRedis struct{ rdb *redis.Client }
+//
+//	func (r goRedis) Get(ctx context.Context, key string) ([]byte, error) {
+//	    data, err := r.rdb.Get(ctx, key).Bytes()
+//	    if errors.Is(err, redis.Nil) {
+//	        return nil, cache.ErrMiss
+//	    }
+//	    return data, err
+//	}
+//
+//	func (r goRedis) Set(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+//	    return r.rdb.Set(ctx, key, data, ttl).Err()
+//	}
+type RedisClient interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, data []byte, ttl time.Duration) error
+}
+
+// Redis is a cache shared through Redis, so every process using the same
+// server and prefix benefits from each other's captures. Redis expires
+// entries itself.
+type Redis struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedis returns a cache that stores images in Redis under prefix.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(
+//	    allscreenshots.WithCache(cache.NewRedis(goRedis{rdb}, "screenshots:")),
+//	)
+func NewRedis(client RedisClient, prefix string) *Redis {
+	return &Redis{client: client, prefix: prefix}
+}
+
+// Get returns the image stored under key.
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	data, err := r.client.Get(ctx, r.prefix+key)
+	if errors.Is(err, ErrMiss) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// Set stores data under key for ttl.
+func (r *Redis) Set(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	return r.client.Set(ctx, r.prefix+key, data, ttl)
+}
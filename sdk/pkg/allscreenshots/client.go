@@ -3,16 +3,16 @@ package allscreenshots
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
-	"math"
-	"math/rand"
 	"net/http"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -29,8 +29,8 @@ const (
 	DefaultRetryWaitMax = 30 * time.Second
 	// EnvAPIKey is the environment variable name for the API key.
 	EnvAPIKey = "ALLSCREENSHOTS_API_KEY"
-
-	userAgent = "allscreenshots-sdk-go/1.0.0"
+	// ProjectHeader is the request header that scopes a request to a project.
+	ProjectHeader = "X-Project-ID"
 )
 
 // Client is the Allscreenshots API client.
@@ -41,7 +41,42 @@ type Client struct {
 	maxRetries   int
 	retryWaitMin time.Duration
 	retryWaitMax time.Duration
+	backoff      BackoffStrategy
 	userAgent    string
+	uaSuffixes   []string
+	project      string
+	defaults     requestDefaults
+	cache        ScreenshotCache
+	flights      *flightGroup
+
+	apiVersion       string
+	endpointVersions map[string]string
+	debug            *debugLogger
+	maxResponseSize  int64
+	compression      *requestCompression
+
+	retryNonIdempotent bool
+	autoTimeout        bool
+	urlNormalization   *NormalizeOptions
+	quotaGuard         *quotaGuard
+	concurrency        *concurrencyLimiter
+	contextHeaders     ContextHeaderMapper
+
+	presetsMu sync.RWMutex
+	presets   map[string]*ScreenshotRequest
+
+	rateLimitMu sync.Mutex
+	rateLimit   *RateLimit
+
+	lifecycle lifecycle
+}
+
+// requestDefaults holds capture options applied to requests that do not set them.
+type requestDefaults struct {
+	Device     string
+	Format     string
+	BlockLevel string
+	Viewport   *ViewportConfig
 }
 
 // ClientOption is a function that configures the client.
@@ -66,8 +101,10 @@ func NewClient(opts ...ClientOption) *Client {
 		maxRetries:   DefaultMaxRetries,
 		retryWaitMin: DefaultRetryWaitMin,
 		retryWaitMax: DefaultRetryWaitMax,
-		userAgent:    userAgent,
+		userAgent:    defaultUserAgent(),
+		apiVersion:   DefaultAPIVersion,
 	}
+	c.lifecycle.closing = make(chan struct{})
 
 	for _, opt := range opts {
 		opt(c)
@@ -126,6 +163,72 @@ func WithUserAgent(ua string) ClientOption {
 	}
 }
 
+// WithUserAgentSuffix appends a product token identifying the application
+// that embeds the SDK to the user agent, keeping the SDK's own token so
+// traffic can still be attributed to the SDK version. It can be given more
+// than once; suffixes are appended in order.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(
+//	    allscreenshots.WithUserAgentSuffix("myapp/2.3"),
+//	)
+//	// User-Agent: allscreenshots-sdk-go/1.0.0 myapp/2.3
+func WithUserAgentSuffix(suffix string) ClientOption {
+	return func(c *Client) {
+		if suffix = strings.TrimSpace(suffix); suffix != "" {
+			c.uaSuffixes = append(c.uaSuffixes, suffix)
+		}
+	}
+}
+
+// userAgentHeader returns the User-Agent sent with requests.
+func (c *Client) userAgentHeader() string {
+	if len(c.uaSuffixes) == 0 {
+		return c.userAgent
+	}
+	return c.userAgent + " " + strings.Join(c.uaSuffixes, " ")
+}
+
+// WithProject scopes every request to a project, so jobs, schedules, and
+// usage are created in and listed from that project only.
+func WithProject(projectID string) ClientOption {
+	return func(c *Client) {
+		c.project = projectID
+	}
+}
+
+// WithDefaultDevice sets the device preset used by requests that specify
+// neither Device nor Viewport.
+func WithDefaultDevice(device string) ClientOption {
+	return func(c *Client) {
+		c.defaults.Device = device
+	}
+}
+
+// WithDefaultFormat sets the output format used by requests that do not set Format.
+func WithDefaultFormat(format string) ClientOption {
+	return func(c *Client) {
+		c.defaults.Format = format
+	}
+}
+
+// WithDefaultBlockLevel sets the blocking level used by requests that do not set BlockLevel.
+func WithDefaultBlockLevel(level string) ClientOption {
+	return func(c *Client) {
+		c.defaults.BlockLevel = level
+	}
+}
+
+// WithDefaultViewport sets the viewport used by requests that specify neither
+// Device nor Viewport. A default device set with WithDefaultDevice takes
+// precedence.
+func WithDefaultViewport(viewport ViewportConfig) ClientOption {
+	return func(c *Client) {
+		c.defaults.Viewport = &viewport
+	}
+}
+
 // request performs an HTTP request with retries.
 func (c *Client) request(ctx context.Context, method, path string, body interface{}, result interface{}) error {
 	return c.requestRaw(ctx, method, path, body, func(resp *http.Response) error {
@@ -141,7 +244,7 @@ func (c *Client) requestBinary(ctx context.Context, method, path string, body in
 	var data []byte
 	err := c.requestRaw(ctx, method, path, body, func(resp *http.Response) error {
 		var readErr error
-		data, readErr = io.ReadAll(resp.Body)
+		data, readErr = readBody(resp.Body, resp.ContentLength)
 		return readErr
 	})
 	return data, err
@@ -149,37 +252,78 @@ func (c *Client) requestBinary(ctx context.Context, method, path string, body in
 
 // requestRaw performs an HTTP request with a custom response handler.
 func (c *Client) requestRaw(ctx context.Context, method, path string, body interface{}, handler func(*http.Response) error) error {
+	return c.requestRawHeader(ctx, method, path, body, nil, handler)
+}
+
+// requestRawHeader is requestRaw with extra request headers, such as Range.
+func (c *Client) requestRawHeader(ctx context.Context, method, path string, body interface{}, header http.Header, handler func(*http.Response) error) error {
 	if c.apiKey == "" {
 		return &ValidationError{Field: "apiKey", Message: "API key is required"}
 	}
+	done, err := c.track()
+	if err != nil {
+		return err
+	}
+	defer done()
 
 	var bodyReader io.Reader
-	if body != nil {
-		jsonData, err := json.Marshal(body)
+	var payload []byte
+	contentType := "application/json"
+	if enc, ok := body.(*encodedBody); ok {
+		payload, contentType = enc.data, enc.contentType
+	} else if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("allscreenshots: failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(jsonData)
 	}
 
+	// Absolute URLs (e.g. signed result URLs) are used as is; the API key is
+	// only sent to the API's own origin.
 	reqURL := c.baseURL + path
+	sendAPIKey := true
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		reqURL = path
+		sendAPIKey = sameOrigin(path, c.baseURL)
+	}
+
+	var compressed []byte
+	if sendAPIKey && contentType == "application/json" {
+		var err error
+		if compressed, err = c.compressBody(payload); err != nil {
+			return err
+		}
+	}
+
+	// Failures that leave it unknown whether the API processed the request
+	// are only retried when repeating it is safe.
+	retryAmbiguous := isIdempotentRequest(method, path)
+	var idempotencyKey string
+	if !retryAmbiguous && c.retryNonIdempotent && sendAPIKey && header.Get(IdempotencyKeyHeader) == "" {
+		idempotencyKey = newIdempotencyKey()
+		retryAmbiguous = true
+	}
 
 	var lastErr error
+	var attempts []AttemptInfo
+	var prevWait time.Duration
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		var wait time.Duration
 		if attempt > 0 {
-			// Calculate exponential backoff with jitter
-			wait := c.calculateBackoff(attempt)
+			wait = c.calculateBackoff(attempt, prevWait)
+			prevWait = wait
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return timeoutError(ctx.Err())
 			case <-time.After(wait):
 			}
+		}
 
-			// Reset body reader for retry
-			if body != nil {
-				jsonData, _ := json.Marshal(body)
-				bodyReader = bytes.NewReader(jsonData)
-			}
+		if compressed != nil {
+			bodyReader = bytes.NewReader(compressed)
+		} else if body != nil {
+			bodyReader = bytes.NewReader(payload)
 		}
 
 		req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
@@ -187,59 +331,159 @@ func (c *Client) requestRaw(ctx context.Context, method, path string, body inter
 			return fmt.Errorf("allscreenshots: failed to create request: %w", err)
 		}
 
-		req.Header.Set("X-API-Key", c.apiKey)
-		req.Header.Set("User-Agent", c.userAgent)
+		if sendAPIKey {
+			c.setContextHeaders(ctx, req.Header)
+			req.Header.Set("X-API-Key", c.apiKey)
+			if c.project != "" {
+				req.Header.Set(ProjectHeader, c.project)
+			}
+		}
+		req.Header.Set("User-Agent", c.userAgentHeader())
 		if body != nil {
-			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Content-Type", contentType)
+		}
+		if compressed != nil {
+			req.Header.Set("Content-Encoding", "gzip")
 		}
 		req.Header.Set("Accept", "application/json")
+		if idempotencyKey != "" {
+			req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
 
+		if c.debug != nil {
+			c.debug.request(attempt+1, method, reqURL, payload)
+		}
+		start := time.Now()
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
-			lastErr = &NetworkError{Message: "request failed", Cause: err}
-			if isRetryableError(err) {
+			if c.debug != nil {
+				c.debug.failure(attempt+1, method, reqURL, err, time.Since(start))
+			}
+			if isTimeout(err) {
+				lastErr = &TimeoutError{Message: "request timed out", Cause: err}
+			} else {
+				lastErr = &NetworkError{Message: "request failed", Cause: err}
+			}
+			if isRetryableError(err) && (retryAmbiguous || isConnectError(err)) {
+				attempts = append(attempts, AttemptInfo{Attempt: attempt + 1, Err: lastErr, Wait: wait})
 				continue
 			}
 			return lastErr
 		}
 
+		if c.debug != nil {
+			c.debug.response(attempt+1, method, reqURL, resp, time.Since(start))
+		}
+		if sendAPIKey {
+			c.recordRateLimit(resp)
+		}
+
 		// Handle response
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if err := c.limitResponse(resp); err != nil {
+				resp.Body.Close()
+				return err
+			}
 			err := handler(resp)
 			resp.Body.Close()
-			return err
+			return timeoutError(err)
 		}
 
 		// Parse error response
-		apiErr := c.parseErrorResponse(resp)
+		apiErr := timeoutError(c.parseErrorResponse(resp))
 		resp.Body.Close()
 
-		if isRetryableStatus(resp.StatusCode) {
+		if compressed != nil && resp.StatusCode == http.StatusUnsupportedMediaType {
+			// The API does not accept compressed bodies; resend this request,
+			// and send later ones, uncompressed.
+			c.compression.rejected.Store(true)
+			compressed = nil
+			attempt--
+			continue
+		}
+
+		if isRetryableStatus(resp.StatusCode) && (retryAmbiguous || isUnprocessedStatus(resp.StatusCode)) {
 			lastErr = apiErr
+			attempts = append(attempts, AttemptInfo{Attempt: attempt + 1, StatusCode: resp.StatusCode, Err: apiErr, Wait: wait})
 			continue
 		}
 
 		return apiErr
 	}
 
-	return &RetryError{Attempts: c.maxRetries + 1, LastErr: lastErr}
+	return &RetryError{Attempts: attempts, LastErr: lastErr}
+}
+
+// sameOrigin reports whether two absolute URLs share scheme and host.
+func sameOrigin(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return strings.EqualFold(ua.Scheme, ub.Scheme) && strings.EqualFold(ua.Host, ub.Host)
 }
 
-// calculateBackoff calculates the backoff duration for a retry attempt.
-func (c *Client) calculateBackoff(attempt int) time.Duration {
-	// Exponential backoff: min * 2^attempt
-	backoff := float64(c.retryWaitMin) * math.Pow(2, float64(attempt-1))
+// applyDefaults returns req with the client's default options filled in.
+// The caller's request is never modified.
+func (c *Client) applyDefaults(req *ScreenshotRequest) *ScreenshotRequest {
+	d := c.defaults
+	if d == (requestDefaults{}) {
+		return req
+	}
+
+	out := *req
+	out.Device, out.Viewport = d.deviceOrViewport(out.Device, out.Viewport)
+	if out.Format == "" {
+		out.Format = d.Format
+	}
+	if out.BlockLevel == "" {
+		out.BlockLevel = d.BlockLevel
+	}
+	return &out
+}
 
-	// Add jitter (up to 25% of backoff)
-	jitter := backoff * 0.25 * rand.Float64()
-	backoff += jitter
+// applyBulkDefaults returns req with the client's default options merged into
+// its Defaults. The caller's request is never modified.
+func (c *Client) applyBulkDefaults(req *BulkRequest) *BulkRequest {
+	d := c.defaults
+	if d == (requestDefaults{}) {
+		return req
+	}
 
-	// Cap at max
-	if backoff > float64(c.retryWaitMax) {
-		backoff = float64(c.retryWaitMax)
+	out := *req
+	defaults := BulkDefaults{}
+	if req.Defaults != nil {
+		defaults = *req.Defaults
+	}
+	defaults.Device, defaults.Viewport = d.deviceOrViewport(defaults.Device, defaults.Viewport)
+	if defaults.Format == "" {
+		defaults.Format = d.Format
 	}
+	if defaults.BlockLevel == "" {
+		defaults.BlockLevel = d.BlockLevel
+	}
+	out.Defaults = &defaults
+	return &out
+}
 
-	return time.Duration(backoff)
+// deviceOrViewport fills in the default device, or failing that the default
+// viewport, when a request sets neither.
+func (d requestDefaults) deviceOrViewport(device string, viewport *ViewportConfig) (string, *ViewportConfig) {
+	if device != "" || viewport != nil {
+		return device, viewport
+	}
+	if d.Device != "" {
+		return d.Device, nil
+	}
+	if d.Viewport != nil {
+		v := *d.Viewport
+		return "", &v
+	}
+	return "", nil
 }
 
 // parseErrorResponse parses an error response from the API.
@@ -247,6 +491,7 @@ func (c *Client) parseErrorResponse(resp *http.Response) *APIError {
 	apiErr := &APIError{
 		StatusCode: resp.StatusCode,
 		Message:    http.StatusText(resp.StatusCode),
+		SDKVersion: Version,
 	}
 
 	var errResp struct {
@@ -256,7 +501,9 @@ func (c *Client) parseErrorResponse(resp *http.Response) *APIError {
 		Details map[string]interface{} `json:"details"`
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&errResp); err == nil {
+	// Error bodies are capped so a proxy's HTML error page or a runaway
+	// response cannot be read into memory in full.
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxErrorBodySize)).Decode(&errResp); err == nil {
 		if errResp.Message != "" {
 			apiErr.Message = errResp.Message
 		} else if errResp.Error != "" {
@@ -303,11 +550,195 @@ func isRetryableStatus(status int) bool {
 //	}
 //	os.WriteFile("screenshot.png", imageData, 0644)
 func (c *Client) Screenshot(ctx context.Context, req *ScreenshotRequest) ([]byte, error) {
+	req = c.normalizeRequestURL(req)
+	if err := validateScreenshotRequest(req); err != nil {
+		return nil, err
+	}
+
+	return c.screenshot(ctx, c.applyDefaults(req))
+}
+
+// ScreenshotDetailed captures a screenshot synchronously like Screenshot, and
+// also returns the content type and cache status of the response.
+//
+// Example:
+//
+//	result, err := client.ScreenshotDetailed(ctx, &allscreenshots.ScreenshotRequest{
+//	    URL:   "https://github.com",
+//	    Cache: &allscreenshots.CacheConfig{Enabled: true, TTLSeconds: 3600},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("cache hit: %v\n", result.CacheHit())
+func (c *Client) ScreenshotDetailed(ctx context.Context, req *ScreenshotRequest) (*ScreenshotResult, error) {
+	req = c.normalizeRequestURL(req)
+	if err := validateScreenshotRequest(req); err != nil {
+		return nil, err
+	}
+
+	return c.screenshotResult(ctx, c.applyAutoTimeout(ctx, c.applyDefaults(req)))
+}
+
+// ScreenshotWithMeta captures a screenshot and extracts page metadata (title,
+// final URL, status, description, favicon, and Open Graph tags) in the same
+// round trip.
+//
+// Example:
+//
+//	result, err := client.ScreenshotWithMeta(ctx, &allscreenshots.ScreenshotRequest{
+//	    URL: "https://github.com",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result.Metadata.Title, result.Metadata.OpenGraph["image"])
+func (c *Client) ScreenshotWithMeta(ctx context.Context, req *ScreenshotRequest) (*ScreenshotResult, error) {
+	req = c.normalizeRequestURL(req)
+	if err := validateScreenshotRequest(req); err != nil {
+		return nil, err
+	}
+
+	withMeta := *c.applyAutoTimeout(ctx, c.applyDefaults(req))
+	withMeta.ReturnMetadata = true
+	result, err := c.screenshotResult(ctx, &withMeta)
+	if err != nil {
+		return nil, err
+	}
+	if result.Metadata == nil {
+		result.Metadata = &PageMetadata{}
+	}
+	return result, nil
+}
+
+// screenshotResult performs a synchronous capture and collects the response
+// details into a ScreenshotResult.
+func (c *Client) screenshotResult(ctx context.Context, req *ScreenshotRequest) (*ScreenshotResult, error) {
+	release, err := c.acquireRender(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var result ScreenshotResult
+	err = c.requestRaw(ctx, http.MethodPost, c.endpoint("/screenshots"), req, func(resp *http.Response) error {
+		data, err := readBody(resp.Body, resp.ContentLength)
+		if err != nil {
+			return err
+		}
+		result.Data = data
+		result.ContentType = resp.Header.Get("Content-Type")
+		result.Cache = resp.Header.Get("X-Cache")
+		result.RateLimit = parseRateLimit(resp.Header, time.Now())
+		if header := resp.Header.Get(pageMetadataHeader); header != "" {
+			meta, err := decodePageMetadata(header)
+			if err != nil {
+				return err
+			}
+			result.Metadata = meta
+		}
+		if header := resp.Header.Get(blockReportHeader); header != "" {
+			var report BlockReport
+			if err := decodeJSONHeader(header, "block report", &report); err != nil {
+				return err
+			}
+			result.BlockReport = &report
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// pageMetadataHeader carries base64-encoded JSON page metadata on binary
+// screenshot responses when ReturnMetadata is set.
+const pageMetadataHeader = "X-Page-Metadata"
+
+// blockReportHeader carries the base64-encoded JSON block report on binary
+// screenshot responses when ReturnBlockReport is set.
+const blockReportHeader = "X-Block-Report"
+
+// decodePageMetadata decodes the X-Page-Metadata response header.
+func decodePageMetadata(header string) (*PageMetadata, error) {
+	var meta PageMetadata
+	if err := decodeJSONHeader(header, "page metadata", &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// decodeJSONHeader decodes a response header holding base64-encoded JSON
+// into v; what names the header's contents in errors.
+func decodeJSONHeader(header, what string, v interface{}) error {
+	raw, err := base64.StdEncoding.DecodeString(header)
+	if err != nil {
+		if raw, err = base64.RawURLEncoding.DecodeString(header); err != nil {
+			return fmt.Errorf("allscreenshots: failed to decode %s: %w", what, err)
+		}
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("allscreenshots: failed to decode %s: %w", what, err)
+	}
+	return nil
+}
+
+// ScreenshotJSON captures a screenshot synchronously in JSON response mode.
+// Instead of the image bytes, it returns where the image is stored along with
+// its dimensions and render statistics.
+//
+// Example:
+//
+//	result, err := client.ScreenshotJSON(ctx, &allscreenshots.ScreenshotRequest{
+//	    URL: "https://github.com",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%dx%d image at %s\n", result.Width, result.Height, result.StorageURL)
+func (c *Client) ScreenshotJSON(ctx context.Context, req *ScreenshotRequest) (*ScreenshotJSONResponse, error) {
+	req = c.normalizeRequestURL(req)
 	if err := validateScreenshotRequest(req); err != nil {
 		return nil, err
 	}
 
-	return c.requestBinary(ctx, http.MethodPost, "/v1/screenshots", req)
+	jsonReq := *c.applyAutoTimeout(ctx, c.applyDefaults(req))
+	jsonReq.ResponseType = ResponseTypeJSON
+
+	release, err := c.acquireRender(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var result ScreenshotJSONResponse
+	err = c.request(ctx, http.MethodPost, c.endpoint("/screenshots"), &jsonReq, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RenderHTML renders an HTML document and returns the image bytes. It is
+// useful for invoices, email previews, and social images built from
+// templates, without hosting a temporary page.
+//
+// Example:
+//
+//	imageData, err := client.RenderHTML(ctx, &allscreenshots.RenderHTMLRequest{
+//	    HTML:     "<h1>Invoice #1042</h1>",
+//	    Viewport: &allscreenshots.ViewportConfig{Width: 1200, Height: 630},
+//	    Format:   "png",
+//	})
+func (c *Client) RenderHTML(ctx context.Context, req *RenderHTMLRequest) ([]byte, error) {
+	if req == nil {
+		return nil, &ValidationError{Field: "request", Message: "request cannot be nil"}
+	}
+	if req.HTML == "" {
+		return nil, &ValidationError{Field: "html", Message: "html is required"}
+	}
+	return c.Screenshot(ctx, req.screenshotRequest())
 }
 
 // ScreenshotAsync starts an asynchronous screenshot capture.
@@ -324,36 +755,116 @@ func (c *Client) Screenshot(ctx context.Context, req *ScreenshotRequest) ([]byte
 //	}
 //	fmt.Printf("Job created: %s\n", job.ID)
 func (c *Client) ScreenshotAsync(ctx context.Context, req *ScreenshotRequest) (*AsyncJobCreatedResponse, error) {
+	req = c.normalizeRequestURL(req)
 	if err := validateScreenshotRequest(req); err != nil {
 		return nil, err
 	}
 
 	var result AsyncJobCreatedResponse
-	err := c.request(ctx, http.MethodPost, "/v1/screenshots/async", req, &result)
+	err := c.request(ctx, http.MethodPost, c.endpoint("/screenshots/async"), c.applyDefaults(req), &result)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
-// ListJobs returns all screenshot jobs.
+// ListJobs returns screenshot jobs, optionally filtered by opts. Use
+// ListJobsPage to page through many jobs or to read the total count.
 //
 // Example:
 //
-//	jobs, err := client.ListJobs(ctx)
+//	jobs, err := client.ListJobs(ctx, allscreenshots.WithTag("release-42"))
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
 //	for _, job := range jobs {
 //	    fmt.Printf("Job %s: %s\n", job.ID, job.Status)
 //	}
-func (c *Client) ListJobs(ctx context.Context) ([]JobResponse, error) {
-	var result []JobResponse
-	err := c.request(ctx, http.MethodGet, "/v1/screenshots/jobs", nil, &result)
+func (c *Client) ListJobs(ctx context.Context, opts ...ListJobsOption) ([]JobResponse, error) {
+	page, err := c.ListJobsPage(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return result, nil
+	return page.Jobs, nil
+}
+
+// ListJobsPage returns one page of screenshot jobs along with the total
+// number of jobs matching opts. Pass the page's NextCursor to WithCursor to
+// fetch the next page; it is empty on the last page.
+//
+// Example:
+//
+//	page, err := client.ListJobsPage(ctx,
+//	    allscreenshots.WithStatus(allscreenshots.JobStatusFailed),
+//	    allscreenshots.WithLimit(20),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("showing %d of %d failed jobs\n", len(page.Jobs), page.Total)
+func (c *Client) ListJobsPage(ctx context.Context, opts ...ListJobsOption) (*JobListResponse, error) {
+	params := url.Values{}
+	for _, opt := range opts {
+		opt(params)
+	}
+	path := c.endpoint("/screenshots/jobs")
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var result JobListResponse
+	err := c.request(ctx, http.MethodGet, path, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListJobsOption filters the jobs returned by ListJobs and ListComposeJobs.
+type ListJobsOption func(params url.Values)
+
+// WithTag limits ListJobs to jobs carrying tag. When given several times,
+// jobs must carry every tag.
+func WithTag(tag string) ListJobsOption {
+	return func(params url.Values) {
+		params.Add("tag", tag)
+	}
+}
+
+// WithStatus limits the listed jobs to those in status. When given several
+// times, jobs in any of the statuses are listed.
+func WithStatus(status JobStatus) ListJobsOption {
+	return func(params url.Values) {
+		params.Add("status", string(status))
+	}
+}
+
+// WithCreatedAfter limits the listed jobs to those created after t.
+func WithCreatedAfter(t time.Time) ListJobsOption {
+	return func(params url.Values) {
+		params.Set("createdAfter", t.UTC().Format(time.RFC3339))
+	}
+}
+
+// WithCreatedBefore limits the listed jobs to those created before t.
+func WithCreatedBefore(t time.Time) ListJobsOption {
+	return func(params url.Values) {
+		params.Set("createdBefore", t.UTC().Format(time.RFC3339))
+	}
+}
+
+// WithLimit sets the maximum number of jobs returned per page.
+func WithLimit(n int) ListJobsOption {
+	return func(params url.Values) {
+		params.Set("limit", strconv.Itoa(n))
+	}
+}
+
+// WithCursor continues a listing from the NextCursor of a previous page.
+func WithCursor(cursor string) ListJobsOption {
+	return func(params url.Values) {
+		params.Set("cursor", cursor)
+	}
 }
 
 // GetJob returns the status of a specific job.
@@ -371,7 +882,10 @@ func (c *Client) GetJob(ctx context.Context, id string) (*JobResponse, error) {
 	}
 
 	var result JobResponse
-	err := c.request(ctx, http.MethodGet, "/v1/screenshots/jobs/"+url.PathEscape(id), nil, &result)
+	err := c.requestRaw(ctx, http.MethodGet, c.endpoint("/screenshots/jobs/"+url.PathEscape(id)), nil, func(resp *http.Response) error {
+		result.RateLimit = parseRateLimit(resp.Header, time.Now())
+		return json.NewDecoder(resp.Body).Decode(&result)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -392,7 +906,97 @@ func (c *Client) GetJobResult(ctx context.Context, id string) ([]byte, error) {
 		return nil, &ValidationError{Field: "id", Message: "job ID is required"}
 	}
 
-	return c.requestBinary(ctx, http.MethodGet, "/v1/screenshots/jobs/"+url.PathEscape(id)+"/result", nil)
+	return c.requestBinary(ctx, http.MethodGet, c.endpoint("/screenshots/jobs/"+url.PathEscape(id)+"/result"), nil)
+}
+
+// RefreshResultURL issues a new signed result URL for a completed job whose
+// ResultURL has expired or is about to.
+//
+// Example:
+//
+//	fresh, err := client.RefreshResultURL(ctx, "job-123")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%s (valid until %s)\n", fresh.ResultURL, fresh.ExpiresAt)
+func (c *Client) RefreshResultURL(ctx context.Context, id string) (*ResultURLResponse, error) {
+	if id == "" {
+		return nil, &ValidationError{Field: "id", Message: "job ID is required"}
+	}
+
+	var result ResultURLResponse
+	err := c.request(ctx, http.MethodPost, c.endpoint("/screenshots/jobs/"+url.PathEscape(id)+"/result-url"), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadResult streams the file at resultURL to w and returns the number of
+// bytes written. The download goes through the client, so it is retried on
+// transient failures and errors are returned as *APIError. The API key is only
+// sent when resultURL points at the API itself.
+//
+// Example:
+//
+//	f, _ := os.Create("screenshot.png")
+//	defer f.Close()
+//	_, err := client.DownloadResult(ctx, job.ResultURL, f)
+func (c *Client) DownloadResult(ctx context.Context, resultURL string, w io.Writer) (int64, error) {
+	if !strings.HasPrefix(resultURL, "http://") && !strings.HasPrefix(resultURL, "https://") {
+		return 0, &ValidationError{Field: "resultURL", Message: "result URL must start with http:// or https://"}
+	}
+	if w == nil {
+		return 0, &ValidationError{Field: "w", Message: "writer cannot be nil"}
+	}
+
+	var n int64
+	err := c.requestRaw(ctx, http.MethodGet, resultURL, nil, func(resp *http.Response) error {
+		var copyErr error
+		n, copyErr = copyBody(w, resp.Body)
+		return copyErr
+	})
+	return n, err
+}
+
+// DeleteJob deletes a job and its stored result.
+//
+// Example:
+//
+//	if err := client.DeleteJob(ctx, "job-123"); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) DeleteJob(ctx context.Context, id string) error {
+	if id == "" {
+		return &ValidationError{Field: "id", Message: "job ID is required"}
+	}
+
+	return c.request(ctx, http.MethodDelete, c.endpoint("/screenshots/jobs/"+url.PathEscape(id)), nil, nil)
+}
+
+// DeleteCompletedJobsBefore deletes finished jobs (completed, failed, or
+// cancelled) created before t, along with their stored results, and returns
+// the number of jobs deleted.
+//
+// Example:
+//
+//	deleted, err := client.DeleteCompletedJobsBefore(ctx, time.Now().AddDate(0, 0, -30))
+func (c *Client) DeleteCompletedJobsBefore(ctx context.Context, t time.Time) (int, error) {
+	if t.IsZero() {
+		return 0, &ValidationError{Field: "before", Message: "time is required"}
+	}
+
+	params := url.Values{}
+	params.Set("completedBefore", t.UTC().Format(time.RFC3339))
+
+	var result struct {
+		Deleted int `json:"deleted"`
+	}
+	err := c.request(ctx, http.MethodDelete, c.endpoint("/screenshots/jobs?"+params.Encode()), nil, &result)
+	if err != nil {
+		return 0, err
+	}
+	return result.Deleted, nil
 }
 
 // CancelJob cancels a pending or processing job.
@@ -410,7 +1014,7 @@ func (c *Client) CancelJob(ctx context.Context, id string) (*JobResponse, error)
 	}
 
 	var result JobResponse
-	err := c.request(ctx, http.MethodPost, "/v1/screenshots/jobs/"+url.PathEscape(id)+"/cancel", nil, &result)
+	err := c.request(ctx, http.MethodPost, c.endpoint("/screenshots/jobs/"+url.PathEscape(id)+"/cancel"), nil, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -431,12 +1035,18 @@ func (c *Client) CancelJob(ctx context.Context, id string) (*JobResponse, error)
 //	    },
 //	})
 func (c *Client) CreateBulkJob(ctx context.Context, req *BulkRequest) (*BulkResponse, error) {
+	req = c.normalizeBulkURLs(req)
 	if err := validateBulkRequest(req); err != nil {
 		return nil, err
 	}
+	release, err := c.reserveQuota(ctx, len(req.URLs))
+	if err != nil {
+		return nil, err
+	}
 
 	var result BulkResponse
-	err := c.request(ctx, http.MethodPost, "/v1/screenshots/bulk", req, &result)
+	err = c.request(ctx, http.MethodPost, c.endpoint("/screenshots/bulk"), c.applyBulkDefaults(req), &result)
+	release(err == nil)
 	if err != nil {
 		return nil, err
 	}
@@ -446,7 +1056,7 @@ func (c *Client) CreateBulkJob(ctx context.Context, req *BulkRequest) (*BulkResp
 // ListBulkJobs returns all bulk screenshot jobs.
 func (c *Client) ListBulkJobs(ctx context.Context) ([]BulkJobSummary, error) {
 	var result []BulkJobSummary
-	err := c.request(ctx, http.MethodGet, "/v1/screenshots/bulk", nil, &result)
+	err := c.request(ctx, http.MethodGet, c.endpoint("/screenshots/bulk"), nil, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -460,13 +1070,34 @@ func (c *Client) GetBulkJob(ctx context.Context, id string) (*BulkStatusResponse
 	}
 
 	var result BulkStatusResponse
-	err := c.request(ctx, http.MethodGet, "/v1/screenshots/bulk/"+url.PathEscape(id), nil, &result)
+	err := c.request(ctx, http.MethodGet, c.endpoint("/screenshots/bulk/"+url.PathEscape(id)), nil, &result)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// GetBulkJobResult returns the image data of one job of a bulk job. Use
+// IterateBulkResults to receive every result as its job finishes.
+//
+// Example:
+//
+//	status, err := client.GetBulkJob(ctx, "bulk-123")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	data, err := client.GetBulkJobResult(ctx, status.ID, status.Jobs[0].ID)
+func (c *Client) GetBulkJobResult(ctx context.Context, bulkID, jobID string) ([]byte, error) {
+	if bulkID == "" {
+		return nil, &ValidationError{Field: "bulkId", Message: "bulk job ID is required"}
+	}
+	if jobID == "" {
+		return nil, &ValidationError{Field: "jobId", Message: "job ID is required"}
+	}
+
+	return c.requestBinary(ctx, http.MethodGet, c.endpoint("/screenshots/bulk/"+url.PathEscape(bulkID)+"/jobs/"+url.PathEscape(jobID)+"/result"), nil)
+}
+
 // CancelBulkJob cancels a bulk job.
 func (c *Client) CancelBulkJob(ctx context.Context, id string) (*BulkJobSummary, error) {
 	if id == "" {
@@ -474,13 +1105,22 @@ func (c *Client) CancelBulkJob(ctx context.Context, id string) (*BulkJobSummary,
 	}
 
 	var result BulkJobSummary
-	err := c.request(ctx, http.MethodPost, "/v1/screenshots/bulk/"+url.PathEscape(id)+"/cancel", nil, &result)
+	err := c.request(ctx, http.MethodPost, c.endpoint("/screenshots/bulk/"+url.PathEscape(id)+"/cancel"), nil, &result)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// DeleteBulkJob deletes a bulk job, its jobs, and their stored results.
+func (c *Client) DeleteBulkJob(ctx context.Context, id string) error {
+	if id == "" {
+		return &ValidationError{Field: "id", Message: "bulk job ID is required"}
+	}
+
+	return c.request(ctx, http.MethodDelete, c.endpoint("/screenshots/bulk/"+url.PathEscape(id)), nil, nil)
+}
+
 // Compose creates a composed image from multiple screenshots.
 //
 // Example:
@@ -499,8 +1139,24 @@ func (c *Client) Compose(ctx context.Context, req *ComposeRequest) (*ComposeResp
 		return nil, err
 	}
 
+	body, err := composeBody(req)
+	if err != nil {
+		return nil, err
+	}
+	release, err := c.reserveQuota(ctx, composeCost(req))
+	if err != nil {
+		return nil, err
+	}
+	releaseRender, err := c.acquireRender(ctx)
+	if err != nil {
+		release(false)
+		return nil, err
+	}
+
 	var result ComposeResponse
-	err := c.request(ctx, http.MethodPost, "/v1/screenshots/compose", req, &result)
+	err = c.request(ctx, http.MethodPost, c.endpoint("/screenshots/compose"), body, &result)
+	releaseRender()
+	release(err == nil)
 	if err != nil {
 		return nil, err
 	}
@@ -513,9 +1169,18 @@ func (c *Client) ComposeAsync(ctx context.Context, req *ComposeRequest) (*Compos
 		return nil, err
 	}
 	req.Async = true
+	body, err := composeBody(req)
+	if err != nil {
+		return nil, err
+	}
+	release, err := c.reserveQuota(ctx, composeCost(req))
+	if err != nil {
+		return nil, err
+	}
 
 	var result ComposeJobStatusResponse
-	err := c.request(ctx, http.MethodPost, "/v1/screenshots/compose", req, &result)
+	err = c.request(ctx, http.MethodPost, c.endpoint("/screenshots/compose"), body, &result)
+	release(err == nil)
 	if err != nil {
 		return nil, err
 	}
@@ -533,7 +1198,7 @@ type ComposeLayoutPreviewParams struct {
 
 // GetComposeLayoutPreview returns a preview of a compose layout.
 func (c *Client) GetComposeLayoutPreview(ctx context.Context, params *ComposeLayoutPreviewParams) (*LayoutPreviewResponse, error) {
-	path := "/v1/screenshots/compose/preview"
+	path := c.endpoint("/screenshots/compose/preview")
 
 	query := url.Values{}
 	if params.Layout != "" {
@@ -564,14 +1229,92 @@ func (c *Client) GetComposeLayoutPreview(ctx context.Context, params *ComposeLay
 	return &result, nil
 }
 
-// ListComposeJobs returns all compose jobs.
-func (c *Client) ListComposeJobs(ctx context.Context) ([]ComposeJobSummaryResponse, error) {
-	var result []ComposeJobSummaryResponse
-	err := c.request(ctx, http.MethodGet, "/v1/screenshots/compose/jobs", nil, &result)
+// ListComposeJobs returns compose jobs, optionally filtered by opts. Use
+// ListComposeJobsPage to page through many jobs.
+//
+// Example:
+//
+//	jobs, err := client.ListComposeJobs(ctx,
+//	    allscreenshots.WithStatus(allscreenshots.JobStatusFailed),
+//	    allscreenshots.WithCreatedAfter(time.Now().Add(-24*time.Hour)),
+//	)
+func (c *Client) ListComposeJobs(ctx context.Context, opts ...ListJobsOption) ([]ComposeJobSummaryResponse, error) {
+	page, err := c.ListComposeJobsPage(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return result, nil
+	return page.Jobs, nil
+}
+
+// ListComposeJobsPage returns one page of compose jobs. Pass the page's
+// NextCursor to WithCursor to fetch the next page; it is empty on the last
+// page.
+//
+// Example:
+//
+//	opts := []allscreenshots.ListJobsOption{allscreenshots.WithLimit(50)}
+//	for {
+//	    page, err := client.ListComposeJobsPage(ctx, opts...)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    for _, job := range page.Jobs {
+//	        fmt.Println(job.JobID, job.Status)
+//	    }
+//	    if page.NextCursor == "" {
+//	        break
+//	    }
+//	    opts = []allscreenshots.ListJobsOption{allscreenshots.WithLimit(50), allscreenshots.WithCursor(page.NextCursor)}
+//	}
+func (c *Client) ListComposeJobsPage(ctx context.Context, opts ...ListJobsOption) (*ComposeJobListResponse, error) {
+	params := url.Values{}
+	for _, opt := range opts {
+		opt(params)
+	}
+	path := c.endpoint("/screenshots/compose/jobs")
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var result ComposeJobListResponse
+	err := c.request(ctx, http.MethodGet, path, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CancelComposeJob cancels a pending or processing compose job.
+//
+// Example:
+//
+//	job, err := client.CancelComposeJob(ctx, "compose-123")
+func (c *Client) CancelComposeJob(ctx context.Context, jobID string) (*ComposeJobStatusResponse, error) {
+	if jobID == "" {
+		return nil, &ValidationError{Field: "jobId", Message: "job ID is required"}
+	}
+
+	var result ComposeJobStatusResponse
+	err := c.request(ctx, http.MethodPost, c.endpoint("/screenshots/compose/jobs/"+url.PathEscape(jobID)+"/cancel"), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteComposeJob deletes a compose job and its stored result.
+//
+// Example:
+//
+//	if err := client.DeleteComposeJob(ctx, "compose-123"); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) DeleteComposeJob(ctx context.Context, jobID string) error {
+	if jobID == "" {
+		return &ValidationError{Field: "jobId", Message: "job ID is required"}
+	}
+
+	return c.request(ctx, http.MethodDelete, c.endpoint("/screenshots/compose/jobs/"+url.PathEscape(jobID)), nil, nil)
 }
 
 // GetComposeJob returns the status of a compose job.
@@ -581,7 +1324,7 @@ func (c *Client) GetComposeJob(ctx context.Context, jobID string) (*ComposeJobSt
 	}
 
 	var result ComposeJobStatusResponse
-	err := c.request(ctx, http.MethodGet, "/v1/screenshots/compose/jobs/"+url.PathEscape(jobID), nil, &result)
+	err := c.request(ctx, http.MethodGet, c.endpoint("/screenshots/compose/jobs/"+url.PathEscape(jobID)), nil, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -604,7 +1347,7 @@ func (c *Client) CreateSchedule(ctx context.Context, req *CreateScheduleRequest)
 	}
 
 	var result ScheduleResponse
-	err := c.request(ctx, http.MethodPost, "/v1/schedules", req, &result)
+	err := c.request(ctx, http.MethodPost, c.endpoint("/schedules"), req, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -614,7 +1357,7 @@ func (c *Client) CreateSchedule(ctx context.Context, req *CreateScheduleRequest)
 // ListSchedules returns all schedules.
 func (c *Client) ListSchedules(ctx context.Context) (*ScheduleListResponse, error) {
 	var result ScheduleListResponse
-	err := c.request(ctx, http.MethodGet, "/v1/schedules", nil, &result)
+	err := c.request(ctx, http.MethodGet, c.endpoint("/schedules"), nil, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -628,7 +1371,7 @@ func (c *Client) GetSchedule(ctx context.Context, id string) (*ScheduleResponse,
 	}
 
 	var result ScheduleResponse
-	err := c.request(ctx, http.MethodGet, "/v1/schedules/"+url.PathEscape(id), nil, &result)
+	err := c.request(ctx, http.MethodGet, c.endpoint("/schedules/"+url.PathEscape(id)), nil, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -640,9 +1383,19 @@ func (c *Client) UpdateSchedule(ctx context.Context, id string, req *UpdateSched
 	if id == "" {
 		return nil, &ValidationError{Field: "id", Message: "schedule ID is required"}
 	}
+	if req != nil {
+		if err := validateScheduleURLs(req.URL, req.URLs); err != nil {
+			return nil, err
+		}
+		if req.Options != nil {
+			if err := validateOrientation("options.orientation", req.Options.Orientation); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	var result ScheduleResponse
-	err := c.request(ctx, http.MethodPut, "/v1/schedules/"+url.PathEscape(id), req, &result)
+	err := c.request(ctx, http.MethodPut, c.endpoint("/schedules/"+url.PathEscape(id)), req, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -655,7 +1408,7 @@ func (c *Client) DeleteSchedule(ctx context.Context, id string) error {
 		return &ValidationError{Field: "id", Message: "schedule ID is required"}
 	}
 
-	return c.request(ctx, http.MethodDelete, "/v1/schedules/"+url.PathEscape(id), nil, nil)
+	return c.request(ctx, http.MethodDelete, c.endpoint("/schedules/"+url.PathEscape(id)), nil, nil)
 }
 
 // PauseSchedule pauses a schedule.
@@ -665,7 +1418,7 @@ func (c *Client) PauseSchedule(ctx context.Context, id string) (*ScheduleRespons
 	}
 
 	var result ScheduleResponse
-	err := c.request(ctx, http.MethodPost, "/v1/schedules/"+url.PathEscape(id)+"/pause", nil, &result)
+	err := c.request(ctx, http.MethodPost, c.endpoint("/schedules/"+url.PathEscape(id)+"/pause"), nil, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -679,7 +1432,7 @@ func (c *Client) ResumeSchedule(ctx context.Context, id string) (*ScheduleRespon
 	}
 
 	var result ScheduleResponse
-	err := c.request(ctx, http.MethodPost, "/v1/schedules/"+url.PathEscape(id)+"/resume", nil, &result)
+	err := c.request(ctx, http.MethodPost, c.endpoint("/schedules/"+url.PathEscape(id)+"/resume"), nil, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -693,7 +1446,7 @@ func (c *Client) TriggerSchedule(ctx context.Context, id string) (*ScheduleRespo
 	}
 
 	var result ScheduleResponse
-	err := c.request(ctx, http.MethodPost, "/v1/schedules/"+url.PathEscape(id)+"/trigger", nil, &result)
+	err := c.request(ctx, http.MethodPost, c.endpoint("/schedules/"+url.PathEscape(id)+"/trigger"), nil, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -706,7 +1459,7 @@ func (c *Client) GetScheduleHistory(ctx context.Context, id string, limit int) (
 		return nil, &ValidationError{Field: "id", Message: "schedule ID is required"}
 	}
 
-	path := "/v1/schedules/" + url.PathEscape(id) + "/history"
+	path := c.endpoint("/schedules/" + url.PathEscape(id) + "/history")
 	if limit > 0 {
 		path += "?limit=" + strconv.Itoa(limit)
 	}
@@ -719,6 +1472,53 @@ func (c *Client) GetScheduleHistory(ctx context.Context, id string, limit int) (
 	return &result, nil
 }
 
+// GetScheduleStats returns success and failure statistics for a schedule.
+//
+// Example:
+//
+//	stats, err := client.GetScheduleStats(ctx, "schedule-id")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%.1f%% successful, %d failures in a row\n", stats.SuccessRate, stats.ConsecutiveFailures)
+func (c *Client) GetScheduleStats(ctx context.Context, id string) (*ScheduleStatsResponse, error) {
+	if id == "" {
+		return nil, &ValidationError{Field: "id", Message: "schedule ID is required"}
+	}
+
+	var result ScheduleStatsResponse
+	err := c.request(ctx, http.MethodGet, c.endpoint("/schedules/"+url.PathEscape(id)+"/stats"), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListUnhealthySchedules returns the statistics of every schedule whose
+// latest threshold or more executions all failed.
+//
+// Example:
+//
+//	unhealthy, err := client.ListUnhealthySchedules(ctx, 3)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, s := range unhealthy {
+//	    alert(fmt.Sprintf("%s failing: %s", s.ScheduleName, s.LastError))
+//	}
+func (c *Client) ListUnhealthySchedules(ctx context.Context, threshold int) ([]ScheduleStatsResponse, error) {
+	if threshold < 1 {
+		return nil, &ValidationError{Field: "threshold", Message: "threshold must be at least 1"}
+	}
+
+	var result ScheduleStatsListResponse
+	err := c.request(ctx, http.MethodGet, c.endpoint("/schedules/unhealthy?consecutiveFailures="+strconv.Itoa(threshold)), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Schedules, nil
+}
+
 // GetUsage returns usage statistics.
 //
 // Example:
@@ -731,7 +1531,7 @@ func (c *Client) GetScheduleHistory(ctx context.Context, id string, limit int) (
 //	fmt.Printf("Screenshots this period: %d\n", usage.CurrentPeriod.ScreenshotsCount)
 func (c *Client) GetUsage(ctx context.Context) (*UsageResponse, error) {
 	var result UsageResponse
-	err := c.request(ctx, http.MethodGet, "/v1/usage", nil, &result)
+	err := c.request(ctx, http.MethodGet, c.endpoint("/usage"), nil, &result)
 	if err != nil {
 		return nil, err
 	}
@@ -749,22 +1549,50 @@ func (c *Client) GetUsage(ctx context.Context) (*UsageResponse, error) {
 //	fmt.Printf("Screenshots remaining: %d\n", quota.Screenshots.Remaining)
 func (c *Client) GetQuotaStatus(ctx context.Context) (*QuotaStatusResponse, error) {
 	var result QuotaStatusResponse
-	err := c.request(ctx, http.MethodGet, "/v1/usage/quota", nil, &result)
+	err := c.request(ctx, http.MethodGet, c.endpoint("/usage/quota"), nil, &result)
 	if err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// Ping checks that the API is reachable and healthy. It does not verify the
+// API key; use ValidateCredentials for that.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.request(ctx, http.MethodGet, "/health", nil, nil)
+}
+
+// ValidateCredentials verifies the API key and returns the account's quota
+// status, including its tier. Call it at startup to fail fast on a missing
+// or revoked key: the error is a ValidationError when no key is configured
+// and an APIError for which IsUnauthorized is true when the key is rejected.
+//
+// Example:
+//
+//	status, err := client.ValidateCredentials(ctx)
+//	if err != nil {
+//	    log.Fatalf("allscreenshots credentials: %v", err)
+//	}
+//	log.Printf("allscreenshots ready (%s tier)", status.Tier)
+func (c *Client) ValidateCredentials(ctx context.Context) (*QuotaStatusResponse, error) {
+	return c.GetQuotaStatus(ctx)
+}
+
 // validateScreenshotRequest validates a screenshot request.
 func validateScreenshotRequest(req *ScreenshotRequest) error {
 	if req == nil {
 		return &ValidationError{Field: "request", Message: "request cannot be nil"}
 	}
-	if req.URL == "" {
-		return &ValidationError{Field: "url", Message: "URL is required"}
-	}
-	if !strings.HasPrefix(req.URL, "http://") && !strings.HasPrefix(req.URL, "https://") {
+	if req.HTML != "" {
+		if req.URL != "" {
+			return &ValidationError{Field: "html", Message: "html cannot be combined with url"}
+		}
+		if len(req.HTML) > maxHTMLSize {
+			return &ValidationError{Field: "html", Message: fmt.Sprintf("html must be at most %d bytes", maxHTMLSize)}
+		}
+	} else if req.URL == "" {
+		return &ValidationError{Field: "url", Message: "URL is required unless html is set"}
+	} else if !strings.HasPrefix(req.URL, "http://") && !strings.HasPrefix(req.URL, "https://") {
 		return &ValidationError{Field: "url", Message: "URL must start with http:// or https://"}
 	}
 	if req.Quality != 0 && (req.Quality < 1 || req.Quality > 100) {
@@ -781,6 +1609,191 @@ func validateScreenshotRequest(req *ScreenshotRequest) error {
 			return err
 		}
 	}
+	if err := validateOrientation("orientation", req.Orientation); err != nil {
+		return err
+	}
+	if req.MediaType != "" && req.MediaType != MediaTypeScreen && req.MediaType != MediaTypePrint {
+		return &ValidationError{Field: "mediaType", Message: "mediaType must be screen or print"}
+	}
+	if req.DisableJavaScript && (req.CustomJS != "" || len(req.Actions) > 0) {
+		return &ValidationError{Field: "disableJavaScript", Message: "disableJavaScript cannot be combined with customJs or actions"}
+	}
+	if len(req.CustomCSS) > maxCustomCodeLength {
+		return &ValidationError{Field: "customCss", Message: fmt.Sprintf("customCss must be at most %d characters", maxCustomCodeLength)}
+	}
+	if len(req.CustomJS) > maxCustomCodeLength {
+		return &ValidationError{Field: "customJs", Message: fmt.Sprintf("customJs must be at most %d characters", maxCustomCodeLength)}
+	}
+	if req.Clip != nil {
+		if err := validateClip(req); err != nil {
+			return err
+		}
+	}
+	for i, status := range req.FailOnStatus {
+		if status < 100 || status > 599 {
+			return &ValidationError{Field: fmt.Sprintf("failOnStatus[%d]", i), Message: "status must be between 100 and 599"}
+		}
+	}
+	switch req.StickyElements {
+	case "", StickyElementsKeep, StickyElementsHide, StickyElementsFirstOnly:
+	default:
+		return &ValidationError{Field: "stickyElements", Message: "stickyElements must be keep, hide, or first-only"}
+	}
+	if req.Cache != nil {
+		if req.Cache.TTLSeconds != 0 && (req.Cache.TTLSeconds < 60 || req.Cache.TTLSeconds > 2592000) {
+			return &ValidationError{Field: "cache.ttlSeconds", Message: "ttlSeconds must be between 60 and 2592000"}
+		}
+		if len(req.Cache.Key) > 255 {
+			return &ValidationError{Field: "cache.key", Message: "key must be at most 255 characters"}
+		}
+	}
+	if req.Storage != nil {
+		if err := validateStorage(req.Storage); err != nil {
+			return err
+		}
+	}
+	if req.ScrollStrategy != nil {
+		if err := validateScrollStrategy(req.ScrollStrategy); err != nil {
+			return err
+		}
+	}
+	if err := validateTags(req.Tags); err != nil {
+		return err
+	}
+	if err := validateBlockRules(req.BlockRequests, req.BlockResourceTypes); err != nil {
+		return err
+	}
+	if err := validateActions(req.Actions); err != nil {
+		return err
+	}
+	if err := validateHeaders(req.Headers); err != nil {
+		return err
+	}
+	if err := validateCookies(req.Cookies); err != nil {
+		return err
+	}
+	if err := validateFonts(req.Fonts); err != nil {
+		return err
+	}
+	if err := validateSessionState(req); err != nil {
+		return err
+	}
+	if req.Auth != nil && req.Auth.Username == "" {
+		return &ValidationError{Field: "auth.username", Message: "username is required"}
+	}
+	if len(req.UserAgent) > 1024 {
+		return &ValidationError{Field: "userAgent", Message: "userAgent must be at most 1024 characters"}
+	}
+	if req.Locale != "" && !isLocale(req.Locale) {
+		return &ValidationError{Field: "locale", Message: "locale must be a BCP 47 language tag such as en-US"}
+	}
+	if err := validateTimezone("timezone", req.Timezone); err != nil {
+		return err
+	}
+	if req.Geolocation != nil {
+		if err := validateGeolocation(req.Geolocation); err != nil {
+			return err
+		}
+	}
+	if req.Network != nil {
+		if err := validateNetwork(req.Network); err != nil {
+			return err
+		}
+	}
+	if req.ProxyCountry != "" && !isCountryCode(req.ProxyCountry) {
+		return &ValidationError{Field: "proxyCountry", Message: "proxyCountry must be a two-letter ISO 3166-1 country code"}
+	}
+	if err := validateOverlay("overlay", req.Overlay); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateGeolocation validates an emulated geolocation.
+func validateGeolocation(g *GeoConfig) error {
+	if g.Latitude < -90 || g.Latitude > 90 {
+		return &ValidationError{Field: "geolocation.latitude", Message: "latitude must be between -90 and 90"}
+	}
+	if g.Longitude < -180 || g.Longitude > 180 {
+		return &ValidationError{Field: "geolocation.longitude", Message: "longitude must be between -180 and 180"}
+	}
+	if g.Accuracy < 0 {
+		return &ValidationError{Field: "geolocation.accuracy", Message: "accuracy must not be negative"}
+	}
+	return nil
+}
+
+// validateNetwork validates network throttling settings.
+func validateNetwork(n *NetworkConfig) error {
+	switch n.Profile {
+	case NetworkProfile3G, NetworkProfile4G:
+	case NetworkProfileOfflineCache:
+		if n.LatencyMs != 0 || n.DownloadKbps != 0 {
+			return &ValidationError{Field: "network.profile", Message: "offline-cache cannot be combined with latencyMs or downloadKbps"}
+		}
+	case "":
+		if n.LatencyMs == 0 && n.DownloadKbps == 0 {
+			return &ValidationError{Field: "network", Message: "profile, latencyMs, or downloadKbps is required"}
+		}
+	default:
+		return &ValidationError{Field: "network.profile", Message: "profile must be 3g, 4g, or offline-cache"}
+	}
+	if n.LatencyMs < 0 || n.LatencyMs > 10000 {
+		return &ValidationError{Field: "network.latencyMs", Message: "latencyMs must be between 0 and 10000"}
+	}
+	if n.DownloadKbps < 0 {
+		return &ValidationError{Field: "network.downloadKbps", Message: "downloadKbps must not be negative"}
+	}
+	return nil
+}
+
+// isCountryCode reports whether s is a two-letter country code.
+func isCountryCode(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// isLocale reports whether s looks like a BCP 47 language tag: a 2-3 letter
+// language followed by alphanumeric subtags of 1-8 characters.
+func isLocale(s string) bool {
+	parts := strings.Split(strings.ReplaceAll(s, "_", "-"), "-")
+	if len(parts[0]) < 2 || len(parts[0]) > 3 {
+		return false
+	}
+	for i, part := range parts {
+		if len(part) == 0 || len(part) > 8 {
+			return false
+		}
+		for _, r := range part {
+			isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+			if !isLetter && (i == 0 || r < '0' || r > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// validateTimezone checks that tz is an IANA time zone name known to the
+// local time zone database. An empty tz is valid. Programs running where no
+// database is installed should import time/tzdata.
+func validateTimezone(field, tz string) error {
+	if tz == "" {
+		return nil
+	}
+	if tz == "Local" {
+		return &ValidationError{Field: field, Message: "timezone must be an IANA time zone name such as Europe/Berlin"}
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return &ValidationError{Field: field, Message: fmt.Sprintf("unknown IANA time zone %q", tz)}
+	}
 	return nil
 }
 
@@ -798,6 +1811,202 @@ func validateViewport(v *ViewportConfig) error {
 	return nil
 }
 
+// validateClip validates the clip region of a screenshot request. When the
+// viewport size is known and FullPage is false, the region must fit inside it.
+func validateClip(req *ScreenshotRequest) error {
+	clip := req.Clip
+	if req.Selector != "" {
+		return &ValidationError{Field: "clip", Message: "clip cannot be combined with selector"}
+	}
+	if clip.X < 0 || clip.Y < 0 {
+		return &ValidationError{Field: "clip", Message: "clip x and y must not be negative"}
+	}
+	if clip.Width <= 0 || clip.Height <= 0 {
+		return &ValidationError{Field: "clip", Message: "clip width and height must be positive"}
+	}
+	if req.FullPage || req.Viewport == nil {
+		return nil
+	}
+	if req.Viewport.Width != 0 && clip.X+clip.Width > req.Viewport.Width {
+		return &ValidationError{Field: "clip", Message: fmt.Sprintf("clip exceeds viewport width of %d", req.Viewport.Width)}
+	}
+	if req.Viewport.Height != 0 && clip.Y+clip.Height > req.Viewport.Height {
+		return &ValidationError{Field: "clip", Message: fmt.Sprintf("clip exceeds viewport height of %d", req.Viewport.Height)}
+	}
+	return nil
+}
+
+// validateStorage validates a customer storage destination.
+func validateStorage(s *StorageConfig) error {
+	switch s.Provider {
+	case StorageProviderS3, StorageProviderGCS, StorageProviderAzure:
+	case "":
+		return &ValidationError{Field: "storage.provider", Message: "provider is required"}
+	default:
+		return &ValidationError{Field: "storage.provider", Message: "provider must be s3, gcs, or azure"}
+	}
+	if s.Bucket == "" {
+		return &ValidationError{Field: "storage.bucket", Message: "bucket is required"}
+	}
+	if strings.HasPrefix(s.Prefix, "/") {
+		return &ValidationError{Field: "storage.prefix", Message: "prefix must not start with /"}
+	}
+	if s.Endpoint != "" && !strings.HasPrefix(s.Endpoint, "https://") {
+		return &ValidationError{Field: "storage.endpoint", Message: "endpoint must start with https://"}
+	}
+	return nil
+}
+
+// validateScrollStrategy validates pre-scroll settings.
+func validateScrollStrategy(s *ScrollConfig) error {
+	if s.StepPx != 0 && (s.StepPx < 100 || s.StepPx > 5000) {
+		return &ValidationError{Field: "scrollStrategy.stepPx", Message: "stepPx must be between 100 and 5000"}
+	}
+	if s.DelayMs < 0 || s.DelayMs > 5000 {
+		return &ValidationError{Field: "scrollStrategy.delayMs", Message: "delayMs must be between 0 and 5000"}
+	}
+	if s.MaxScrolls != 0 && (s.MaxScrolls < 1 || s.MaxScrolls > 200) {
+		return &ValidationError{Field: "scrollStrategy.maxScrolls", Message: "maxScrolls must be between 1 and 200"}
+	}
+	return nil
+}
+
+// Limits on job tags.
+const (
+	maxTags      = 20
+	maxTagLength = 64
+)
+
+// Limits on the content injected into the page: blocked request patterns,
+// HTML to render, custom CSS and JavaScript, and web fonts.
+const (
+	maxBlockRequests      = 100
+	maxBlockPatternLength = 500
+	maxHTMLSize           = 2 << 20
+	maxCustomCodeLength   = 10000
+	maxFonts              = 10
+	maxFontSize           = 2 << 20
+)
+
+// Limits on the extra headers and cookies sent to the target page.
+const (
+	maxRequestHeaders     = 50
+	maxRequestHeadersSize = 8192
+	maxRequestCookies     = 50
+)
+
+// validateTags validates job tags.
+func validateTags(tags []string) error {
+	if len(tags) > maxTags {
+		return &ValidationError{Field: "tags", Message: fmt.Sprintf("maximum %d tags allowed", maxTags)}
+	}
+	for i, tag := range tags {
+		if tag == "" || len(tag) > maxTagLength {
+			return &ValidationError{Field: fmt.Sprintf("tags[%d]", i), Message: fmt.Sprintf("tag must be between 1 and %d characters", maxTagLength)}
+		}
+		for _, r := range tag {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.:/", r)) {
+				return &ValidationError{Field: fmt.Sprintf("tags[%d]", i), Message: "tag may only contain letters, digits, and - _ . : /"}
+			}
+		}
+	}
+	return nil
+}
+
+// validateBlockRules validates request blocking patterns and resource types.
+func validateBlockRules(patterns, resourceTypes []string) error {
+	if len(patterns) > maxBlockRequests {
+		return &ValidationError{Field: "blockRequests", Message: fmt.Sprintf("maximum %d patterns allowed", maxBlockRequests)}
+	}
+	for i, p := range patterns {
+		if p == "" || len(p) > maxBlockPatternLength {
+			return &ValidationError{Field: fmt.Sprintf("blockRequests[%d]", i), Message: fmt.Sprintf("pattern must be between 1 and %d characters", maxBlockPatternLength)}
+		}
+	}
+	for i, t := range resourceTypes {
+		switch t {
+		case ResourceTypeImage, ResourceTypeFont, ResourceTypeMedia, ResourceTypeScript, ResourceTypeStylesheet:
+		default:
+			return &ValidationError{Field: fmt.Sprintf("blockResourceTypes[%d]", i), Message: fmt.Sprintf("unknown resource type %q", t)}
+		}
+	}
+	return nil
+}
+
+// validateHeaders validates extra headers for the target page.
+func validateHeaders(headers map[string]string) error {
+	if len(headers) > maxRequestHeaders {
+		return &ValidationError{Field: "headers", Message: fmt.Sprintf("maximum %d headers allowed", maxRequestHeaders)}
+	}
+	size := 0
+	for name, value := range headers {
+		field := fmt.Sprintf("headers[%s]", name)
+		if name == "" {
+			return &ValidationError{Field: "headers", Message: "header name cannot be empty"}
+		}
+		if strings.ContainsAny(name, " \t\r\n:") {
+			return &ValidationError{Field: field, Message: "header name contains invalid characters"}
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return &ValidationError{Field: field, Message: "header value cannot contain line breaks"}
+		}
+		size += len(name) + len(value)
+	}
+	if size > maxRequestHeadersSize {
+		return &ValidationError{Field: "headers", Message: fmt.Sprintf("headers must be at most %d bytes in total", maxRequestHeadersSize)}
+	}
+	return nil
+}
+
+// validateFonts validates fonts loaded into the page.
+func validateFonts(fonts []FontConfig) error {
+	if len(fonts) > maxFonts {
+		return &ValidationError{Field: "fonts", Message: fmt.Sprintf("maximum %d fonts allowed", maxFonts)}
+	}
+	for i, f := range fonts {
+		field := fmt.Sprintf("fonts[%d]", i)
+		if f.Family == "" {
+			return &ValidationError{Field: field + ".family", Message: "family is required"}
+		}
+		switch {
+		case f.URL == "" && len(f.Data) == 0:
+			return &ValidationError{Field: field, Message: "url or data is required"}
+		case f.URL != "" && len(f.Data) > 0:
+			return &ValidationError{Field: field, Message: "url and data are mutually exclusive"}
+		case f.URL != "" && !strings.HasPrefix(f.URL, "http://") && !strings.HasPrefix(f.URL, "https://"):
+			return &ValidationError{Field: field + ".url", Message: "URL must start with http:// or https://"}
+		case len(f.Data) > maxFontSize:
+			return &ValidationError{Field: field + ".data", Message: fmt.Sprintf("data must be at most %d bytes", maxFontSize)}
+		}
+		if f.Weight != 0 && (f.Weight < 1 || f.Weight > 1000) {
+			return &ValidationError{Field: field + ".weight", Message: "weight must be between 1 and 1000"}
+		}
+		if f.Style != "" && f.Style != FontStyleNormal && f.Style != FontStyleItalic {
+			return &ValidationError{Field: field + ".style", Message: "style must be normal or italic"}
+		}
+	}
+	return nil
+}
+
+// validateCookies validates cookies for the target page.
+func validateCookies(cookies []Cookie) error {
+	if len(cookies) > maxRequestCookies {
+		return &ValidationError{Field: "cookies", Message: fmt.Sprintf("maximum %d cookies allowed", maxRequestCookies)}
+	}
+	for i, c := range cookies {
+		if c.Name == "" {
+			return &ValidationError{Field: fmt.Sprintf("cookies[%d].name", i), Message: "cookie name is required"}
+		}
+		if strings.ContainsAny(c.Name, " \t\r\n;=,") {
+			return &ValidationError{Field: fmt.Sprintf("cookies[%d].name", i), Message: "cookie name contains invalid characters"}
+		}
+		if strings.ContainsAny(c.Value, "\r\n;") {
+			return &ValidationError{Field: fmt.Sprintf("cookies[%d].value", i), Message: "cookie value contains invalid characters"}
+		}
+	}
+	return nil
+}
+
 // validateBulkRequest validates a bulk request.
 func validateBulkRequest(req *BulkRequest) error {
 	if req == nil {
@@ -816,6 +2025,54 @@ func validateBulkRequest(req *BulkRequest) error {
 		if !strings.HasPrefix(u.URL, "http://") && !strings.HasPrefix(u.URL, "https://") {
 			return &ValidationError{Field: fmt.Sprintf("urls[%d].url", i), Message: "URL must start with http:// or https://"}
 		}
+		if u.Options != nil {
+			if err := validateBulkURLWebhook(i, u.Options); err != nil {
+				return err
+			}
+			if err := validateOrientation(fmt.Sprintf("urls[%d].options.orientation", i), u.Options.Orientation); err != nil {
+				return err
+			}
+		}
+	}
+	if req.Defaults != nil {
+		if err := validateOrientation("defaults.orientation", req.Defaults.Orientation); err != nil {
+			return err
+		}
+	}
+	if req.Storage != nil {
+		if err := validateStorage(req.Storage); err != nil {
+			return err
+		}
+	}
+	return validateTags(req.Tags)
+}
+
+// validateBulkURLWebhook validates the webhook of the i-th URL of a bulk request.
+func validateBulkURLWebhook(i int, opts *BulkURLOptions) error {
+	if opts.WebhookURL != "" && !strings.HasPrefix(opts.WebhookURL, "http://") && !strings.HasPrefix(opts.WebhookURL, "https://") {
+		return &ValidationError{Field: fmt.Sprintf("urls[%d].options.webhookUrl", i), Message: "webhook URL must start with http:// or https://"}
+	}
+	if len(opts.WebhookSecret) > 255 {
+		return &ValidationError{Field: fmt.Sprintf("urls[%d].options.webhookSecret", i), Message: "webhook secret must be at most 255 characters"}
+	}
+	return nil
+}
+
+// validateScheduleURLs validates the URL or URLs of a schedule; at most one may be set.
+func validateScheduleURLs(u string, urls []string) error {
+	if u != "" && len(urls) > 0 {
+		return &ValidationError{Field: "urls", Message: "url and urls are mutually exclusive"}
+	}
+	if u != "" && !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+		return &ValidationError{Field: "url", Message: "URL must start with http:// or https://"}
+	}
+	if len(urls) > 100 {
+		return &ValidationError{Field: "urls", Message: "maximum 100 URLs allowed"}
+	}
+	for i, u := range urls {
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			return &ValidationError{Field: fmt.Sprintf("urls[%d]", i), Message: "URL must start with http:// or https://"}
+		}
 	}
 	return nil
 }
@@ -825,12 +2082,23 @@ func validateComposeRequest(req *ComposeRequest) error {
 	if req == nil {
 		return &ValidationError{Field: "request", Message: "request cannot be nil"}
 	}
-	if len(req.Captures) == 0 && req.URL == "" {
-		return &ValidationError{Field: "captures", Message: "either captures or url is required"}
+	if len(req.Captures) == 0 && req.URL == "" && len(req.Images) == 0 {
+		return &ValidationError{Field: "captures", Message: "either captures, url, or images is required"}
 	}
 	if len(req.Captures) > 20 {
 		return &ValidationError{Field: "captures", Message: "maximum 20 captures allowed"}
 	}
+	if len(req.Captures)+len(req.Images) > 20 {
+		return &ValidationError{Field: "images", Message: "maximum 20 captures and images allowed"}
+	}
+	for i, img := range req.Images {
+		if img.Name == "" {
+			return &ValidationError{Field: fmt.Sprintf("images[%d].name", i), Message: "name is required"}
+		}
+		if len(img.Data) == 0 {
+			return &ValidationError{Field: fmt.Sprintf("images[%d].data", i), Message: "image data is required"}
+		}
+	}
 	if len(req.Variants) > 20 {
 		return &ValidationError{Field: "variants", Message: "maximum 20 variants allowed"}
 	}
@@ -841,10 +2109,105 @@ func validateComposeRequest(req *ComposeRequest) error {
 		if !strings.HasPrefix(c.URL, "http://") && !strings.HasPrefix(c.URL, "https://") {
 			return &ValidationError{Field: fmt.Sprintf("captures[%d].url", i), Message: "URL must start with http:// or https://"}
 		}
+		if err := validateFrame(fmt.Sprintf("captures[%d].frame", i), c.Frame); err != nil {
+			return err
+		}
+		if err := validateOrientation(fmt.Sprintf("captures[%d].orientation", i), c.Orientation); err != nil {
+			return err
+		}
+	}
+	for i, v := range req.Variants {
+		if err := validateFrame(fmt.Sprintf("variants[%d].frame", i), v.Frame); err != nil {
+			return err
+		}
+		if err := validateOrientation(fmt.Sprintf("variants[%d].orientation", i), v.Orientation); err != nil {
+			return err
+		}
+	}
+	if req.Defaults != nil {
+		if err := validateOrientation("defaults.orientation", req.Defaults.Orientation); err != nil {
+			return err
+		}
+	}
+	if req.Output != nil {
+		if err := validateFrame("output.frame", req.Output.Frame); err != nil {
+			return err
+		}
+		if err := validateOverlay("output.overlay", req.Output.Overlay); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOrientation validates a device orientation.
+func validateOrientation(field, orientation string) error {
+	switch orientation {
+	case "", OrientationPortrait, OrientationLandscape:
+		return nil
+	}
+	return &ValidationError{Field: field, Message: "orientation must be portrait or landscape"}
+}
+
+// validateFrame validates a compose frame configuration.
+func validateFrame(field string, f *FrameConfig) error {
+	if f == nil {
+		return nil
+	}
+	switch f.Style {
+	case FrameStyleIPhone14, FrameStyleMacBook, FrameStyleBrowser:
+	case "":
+		return &ValidationError{Field: field + ".style", Message: "style is required"}
+	default:
+		return &ValidationError{Field: field + ".style", Message: "style must be iphone-14, macbook, or browser"}
+	}
+	if f.Color != "" && !isHexColor(f.Color) {
+		return &ValidationError{Field: field + ".color", Message: "color must be #RRGGBB"}
 	}
 	return nil
 }
 
+// validateOverlay validates an overlay configuration.
+func validateOverlay(field string, o *OverlayConfig) error {
+	if o == nil {
+		return nil
+	}
+	if o.Text == "" && o.ImageURL == "" {
+		return &ValidationError{Field: field, Message: "text or imageUrl is required"}
+	}
+	if len(o.Text) > 500 {
+		return &ValidationError{Field: field + ".text", Message: "text must be at most 500 characters"}
+	}
+	if o.ImageURL != "" && !strings.HasPrefix(o.ImageURL, "http://") && !strings.HasPrefix(o.ImageURL, "https://") {
+		return &ValidationError{Field: field + ".imageUrl", Message: "imageUrl must start with http:// or https://"}
+	}
+	switch o.Position {
+	case "", OverlayTopLeft, OverlayTopCenter, OverlayTopRight, OverlayCenter, OverlayBottomLeft, OverlayBottomCenter, OverlayBottomRight:
+	default:
+		return &ValidationError{Field: field + ".position", Message: "position must be top-left, top-center, top-right, center, bottom-left, bottom-center, or bottom-right"}
+	}
+	if o.Opacity < 0 || o.Opacity > 1 {
+		return &ValidationError{Field: field + ".opacity", Message: "opacity must be between 0 and 1"}
+	}
+	if o.FontSize != 0 && (o.FontSize < 8 || o.FontSize > 200) {
+		return &ValidationError{Field: field + ".fontSize", Message: "fontSize must be between 8 and 200"}
+	}
+	return nil
+}
+
+// isHexColor reports whether s is a #RRGGBB color.
+func isHexColor(s string) bool {
+	if len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+	return true
+}
+
 // validateCreateScheduleRequest validates a create schedule request.
 func validateCreateScheduleRequest(req *CreateScheduleRequest) error {
 	if req == nil {
@@ -856,15 +2219,28 @@ func validateCreateScheduleRequest(req *CreateScheduleRequest) error {
 	if len(req.Name) > 255 {
 		return &ValidationError{Field: "name", Message: "name must be at most 255 characters"}
 	}
-	if req.URL == "" {
-		return &ValidationError{Field: "url", Message: "URL is required"}
+	if err := validateScheduleURLs(req.URL, req.URLs); err != nil {
+		return err
 	}
-	if !strings.HasPrefix(req.URL, "http://") && !strings.HasPrefix(req.URL, "https://") {
-		return &ValidationError{Field: "url", Message: "URL must start with http:// or https://"}
+	if req.URL == "" && len(req.URLs) == 0 {
+		return &ValidationError{Field: "url", Message: "URL is required unless urls is set"}
 	}
 	if req.Schedule == "" {
 		return &ValidationError{Field: "schedule", Message: "schedule is required"}
 	}
+	if err := validateTimezone("timezone", req.Timezone); err != nil {
+		return err
+	}
+	if req.Options != nil && req.Options.Storage != nil {
+		if err := validateStorage(req.Options.Storage); err != nil {
+			return err
+		}
+	}
+	if req.Options != nil {
+		if err := validateOrientation("options.orientation", req.Options.Orientation); err != nil {
+			return err
+		}
+	}
 	if req.RetentionDays != 0 && (req.RetentionDays < 1 || req.RetentionDays > 365) {
 		return &ValidationError{Field: "retentionDays", Message: "retentionDays must be between 1 and 365"}
 	}
@@ -1,12 +1,21 @@
 package allscreenshots
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
 	"testing"
 	"time"
+	_ "time/tzdata" // timezone validation tests must not depend on the host database
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -42,6 +51,88 @@ func TestNewClient(t *testing.T) {
 	})
 }
 
+func TestClient_UserAgentSuffix(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"tier":"pro"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+		WithUserAgentSuffix("myapp/2.3"),
+		WithUserAgentSuffix(" "),
+		WithUserAgentSuffix("platform/1.0"),
+	)
+	_, err := client.GetQuotaStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "allscreenshots-sdk-go/"+Version+" myapp/2.3 platform/1.0", got)
+
+	// The suffix is kept when the base user agent is replaced.
+	client = NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithUserAgentSuffix("myapp/2.3"), WithUserAgent("custom/1.0"))
+	_, err = client.GetQuotaStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "custom/1.0 myapp/2.3", got)
+}
+
+func TestClient_DefaultOptions(t *testing.T) {
+	client := NewClient(
+		WithDefaultDevice("iPhone 14"),
+		WithDefaultFormat("webp"),
+		WithDefaultBlockLevel("pro"),
+		WithDefaultViewport(ViewportConfig{Width: 1280, Height: 720}),
+	)
+
+	t.Run("fills unset screenshot fields", func(t *testing.T) {
+		req := &ScreenshotRequest{URL: "https://example.com"}
+		applied := client.applyDefaults(req)
+
+		assert.Equal(t, "iPhone 14", applied.Device)
+		assert.Nil(t, applied.Viewport)
+		assert.Equal(t, "webp", applied.Format)
+		assert.Equal(t, "pro", applied.BlockLevel)
+		assert.Empty(t, req.Device, "caller's request must not be modified")
+	})
+
+	t.Run("per-request values win", func(t *testing.T) {
+		applied := client.applyDefaults(&ScreenshotRequest{
+			URL:        "https://example.com",
+			Viewport:   &ViewportConfig{Width: 800, Height: 600},
+			Format:     "png",
+			BlockLevel: "none",
+		})
+
+		assert.Empty(t, applied.Device)
+		assert.Equal(t, 800, applied.Viewport.Width)
+		assert.Equal(t, "png", applied.Format)
+		assert.Equal(t, "none", applied.BlockLevel)
+	})
+
+	t.Run("viewport default without device default", func(t *testing.T) {
+		client := NewClient(WithDefaultViewport(ViewportConfig{Width: 1280, Height: 720}))
+		applied := client.applyDefaults(&ScreenshotRequest{URL: "https://example.com"})
+
+		require.NotNil(t, applied.Viewport)
+		assert.Equal(t, 1280, applied.Viewport.Width)
+	})
+
+	t.Run("merges into bulk defaults", func(t *testing.T) {
+		req := &BulkRequest{
+			URLs:     []BulkURLRequest{{URL: "https://example.com"}},
+			Defaults: &BulkDefaults{Format: "jpeg"},
+		}
+		applied := client.applyBulkDefaults(req)
+
+		assert.Equal(t, "iPhone 14", applied.Defaults.Device)
+		assert.Equal(t, "jpeg", applied.Defaults.Format)
+		assert.Equal(t, "pro", applied.Defaults.BlockLevel)
+		assert.Empty(t, req.Defaults.Device, "caller's request must not be modified")
+	})
+}
+
 func TestScreenshotRequest_Validation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -58,6 +149,36 @@ func TestScreenshotRequest_Validation(t *testing.T) {
 			req:     &ScreenshotRequest{},
 			wantErr: "URL is required",
 		},
+		{
+			name:    "html instead of URL",
+			req:     &ScreenshotRequest{HTML: "<p>hello</p>"},
+			wantErr: "",
+		},
+		{
+			name:    "html and URL",
+			req:     &ScreenshotRequest{URL: "https://example.com", HTML: "<p>hello</p>"},
+			wantErr: "html cannot be combined with url",
+		},
+		{
+			name:    "text overlay",
+			req:     &ScreenshotRequest{URL: "https://example.com", Overlay: &OverlayConfig{Text: "CONFIDENTIAL", Position: OverlayTopRight, Opacity: 0.5}},
+			wantErr: "",
+		},
+		{
+			name:    "empty overlay",
+			req:     &ScreenshotRequest{URL: "https://example.com", Overlay: &OverlayConfig{Position: OverlayCenter}},
+			wantErr: "text or imageUrl is required",
+		},
+		{
+			name:    "overlay opacity out of range",
+			req:     &ScreenshotRequest{URL: "https://example.com", Overlay: &OverlayConfig{ImageURL: "https://example.com/logo.png", Opacity: 1.5}},
+			wantErr: "opacity must be between 0 and 1",
+		},
+		{
+			name:    "unknown overlay position",
+			req:     &ScreenshotRequest{URL: "https://example.com", Overlay: &OverlayConfig{Text: "draft", Position: "middle"}},
+			wantErr: "position must be top-left",
+		},
 		{
 			name:    "invalid URL scheme",
 			req:     &ScreenshotRequest{URL: "ftp://example.com"},
@@ -104,6 +225,344 @@ func TestScreenshotRequest_Validation(t *testing.T) {
 			},
 			wantErr: "width must be between 100 and 4096",
 		},
+		{
+			name: "valid headers and cookies",
+			req: &ScreenshotRequest{
+				URL:     "https://example.com",
+				Headers: map[string]string{"Authorization": "Bearer token", "X-Variant": "b"},
+				Cookies: []Cookie{{Name: "session", Value: "abc", Domain: "example.com", Secure: true}},
+			},
+			wantErr: "",
+		},
+		{
+			name: "header with line break",
+			req: &ScreenshotRequest{
+				URL:     "https://example.com",
+				Headers: map[string]string{"X-Test": "a\r\nInjected: yes"},
+			},
+			wantErr: "header value cannot contain line breaks",
+		},
+		{
+			name: "headers too large",
+			req: &ScreenshotRequest{
+				URL:     "https://example.com",
+				Headers: map[string]string{"X-Large": strings.Repeat("a", 9000)},
+			},
+			wantErr: "headers must be at most 8192 bytes in total",
+		},
+		{
+			name: "too many headers",
+			req: &ScreenshotRequest{
+				URL:     "https://example.com",
+				Headers: manyHeaders(51),
+			},
+			wantErr: "maximum 50 headers allowed",
+		},
+		{
+			name: "basic auth without username",
+			req: &ScreenshotRequest{
+				URL:  "https://staging.example.com",
+				Auth: &BasicAuth{Password: "secret"},
+			},
+			wantErr: "username is required",
+		},
+		{
+			name: "valid emulation fields",
+			req: &ScreenshotRequest{
+				URL:       "https://example.com",
+				UserAgent: "Mozilla/5.0 (X11; Linux x86_64)",
+				Locale:    "de-DE",
+				Timezone:  "Europe/Berlin",
+			},
+			wantErr: "",
+		},
+		{
+			name:    "invalid locale",
+			req:     &ScreenshotRequest{URL: "https://example.com", Locale: "german"},
+			wantErr: "locale must be a BCP 47 language tag",
+		},
+		{
+			name:    "unknown timezone",
+			req:     &ScreenshotRequest{URL: "https://example.com", Timezone: "Mars/Olympus_Mons"},
+			wantErr: "unknown IANA time zone",
+		},
+		{
+			name: "valid geolocation and proxy country",
+			req: &ScreenshotRequest{
+				URL:          "https://example.com/pricing",
+				Geolocation:  &GeoConfig{Latitude: 52.52, Longitude: 13.405, Accuracy: 100},
+				ProxyCountry: "DE",
+			},
+			wantErr: "",
+		},
+		{
+			name: "latitude out of range",
+			req: &ScreenshotRequest{
+				URL:         "https://example.com",
+				Geolocation: &GeoConfig{Latitude: 91},
+			},
+			wantErr: "latitude must be between -90 and 90",
+		},
+		{
+			name:    "invalid proxy country",
+			req:     &ScreenshotRequest{URL: "https://example.com", ProxyCountry: "DEU"},
+			wantErr: "proxyCountry must be a two-letter ISO 3166-1 country code",
+		},
+		{
+			name: "valid clip",
+			req: &ScreenshotRequest{
+				URL:      "https://example.com",
+				Viewport: &ViewportConfig{Width: 1280, Height: 720},
+				Clip:     &ClipConfig{X: 100, Y: 100, Width: 400, Height: 300},
+			},
+			wantErr: "",
+		},
+		{
+			name: "clip with selector",
+			req: &ScreenshotRequest{
+				URL:      "https://example.com",
+				Selector: "#hero",
+				Clip:     &ClipConfig{Width: 400, Height: 300},
+			},
+			wantErr: "clip cannot be combined with selector",
+		},
+		{
+			name: "clip outside viewport",
+			req: &ScreenshotRequest{
+				URL:      "https://example.com",
+				Viewport: &ViewportConfig{Width: 1280, Height: 720},
+				Clip:     &ClipConfig{Y: 600, Width: 400, Height: 300},
+			},
+			wantErr: "clip exceeds viewport height of 720",
+		},
+		{
+			name: "clip below the fold on full page",
+			req: &ScreenshotRequest{
+				URL:      "https://example.com",
+				FullPage: true,
+				Viewport: &ViewportConfig{Width: 1280, Height: 720},
+				Clip:     &ClipConfig{Y: 2000, Width: 400, Height: 300},
+			},
+			wantErr: "",
+		},
+		{
+			name: "valid media emulation",
+			req: &ScreenshotRequest{
+				URL:               "https://example.com",
+				DisableJavaScript: true,
+				MediaType:         MediaTypePrint,
+				ReducedMotion:     true,
+			},
+			wantErr: "",
+		},
+		{
+			name:    "landscape device",
+			req:     &ScreenshotRequest{URL: "https://example.com", Device: "iPad", Orientation: OrientationLandscape},
+			wantErr: "",
+		},
+		{
+			name:    "invalid orientation",
+			req:     &ScreenshotRequest{URL: "https://example.com", Device: "iPad", Orientation: "sideways"},
+			wantErr: "orientation must be portrait or landscape",
+		},
+		{
+			name: "fonts",
+			req: &ScreenshotRequest{URL: "https://example.com", Fonts: []FontConfig{
+				{Family: "Brand Sans", URL: "https://cdn.example.com/brand-sans.woff2"},
+				{Family: "Brand Sans", Data: []byte("wOF2"), Weight: 700, Style: FontStyleItalic},
+			}},
+			wantErr: "",
+		},
+		{
+			name:    "font without family",
+			req:     &ScreenshotRequest{URL: "https://example.com", Fonts: []FontConfig{{URL: "https://cdn.example.com/a.woff2"}}},
+			wantErr: "'fonts[0].family': family is required",
+		},
+		{
+			name:    "font without source",
+			req:     &ScreenshotRequest{URL: "https://example.com", Fonts: []FontConfig{{Family: "Brand Sans"}}},
+			wantErr: "url or data is required",
+		},
+		{
+			name:    "font with URL and data",
+			req:     &ScreenshotRequest{URL: "https://example.com", Fonts: []FontConfig{{Family: "Brand Sans", URL: "https://cdn.example.com/a.woff2", Data: []byte("wOF2")}}},
+			wantErr: "url and data are mutually exclusive",
+		},
+		{
+			name:    "font data too large",
+			req:     &ScreenshotRequest{URL: "https://example.com", Fonts: []FontConfig{{Family: "Brand Sans", Data: make([]byte, 2<<20+1)}}},
+			wantErr: "data must be at most 2097152 bytes",
+		},
+		{
+			name:    "invalid font style",
+			req:     &ScreenshotRequest{URL: "https://example.com", Fonts: []FontConfig{{Family: "Brand Sans", URL: "https://cdn.example.com/a.woff2", Style: "oblique"}}},
+			wantErr: "style must be normal or italic",
+		},
+		{
+			name:    "too many fonts",
+			req:     &ScreenshotRequest{URL: "https://example.com", Fonts: make([]FontConfig, 11)},
+			wantErr: "maximum 10 fonts allowed",
+		},
+		{
+			name:    "network profile",
+			req:     &ScreenshotRequest{URL: "https://example.com", Network: &NetworkConfig{Profile: NetworkProfile3G, LatencyMs: 600}},
+			wantErr: "",
+		},
+		{
+			name:    "custom network throughput",
+			req:     &ScreenshotRequest{URL: "https://example.com", Network: &NetworkConfig{DownloadKbps: 750}},
+			wantErr: "",
+		},
+		{
+			name:    "unknown network profile",
+			req:     &ScreenshotRequest{URL: "https://example.com", Network: &NetworkConfig{Profile: "5g"}},
+			wantErr: "profile must be 3g, 4g, or offline-cache",
+		},
+		{
+			name:    "empty network config",
+			req:     &ScreenshotRequest{URL: "https://example.com", Network: &NetworkConfig{}},
+			wantErr: "profile, latencyMs, or downloadKbps is required",
+		},
+		{
+			name:    "offline cache with throughput",
+			req:     &ScreenshotRequest{URL: "https://example.com", Network: &NetworkConfig{Profile: NetworkProfileOfflineCache, DownloadKbps: 100}},
+			wantErr: "offline-cache cannot be combined with latencyMs or downloadKbps",
+		},
+		{
+			name:    "network latency too high",
+			req:     &ScreenshotRequest{URL: "https://example.com", Network: &NetworkConfig{Profile: NetworkProfile4G, LatencyMs: 20000}},
+			wantErr: "latencyMs must be between 0 and 10000",
+		},
+		{
+			name:    "negative network throughput",
+			req:     &ScreenshotRequest{URL: "https://example.com", Network: &NetworkConfig{Profile: NetworkProfile4G, DownloadKbps: -1}},
+			wantErr: "downloadKbps must not be negative",
+		},
+		{
+			name:    "invalid media type",
+			req:     &ScreenshotRequest{URL: "https://example.com", MediaType: "tv"},
+			wantErr: "mediaType must be screen or print",
+		},
+		{
+			name:    "custom JS with JavaScript disabled",
+			req:     &ScreenshotRequest{URL: "https://example.com", DisableJavaScript: true, CustomJS: "1"},
+			wantErr: "disableJavaScript cannot be combined with customJs or actions",
+		},
+		{
+			name: "valid scroll strategy",
+			req: &ScreenshotRequest{
+				URL:            "https://example.com",
+				FullPage:       true,
+				ScrollStrategy: &ScrollConfig{Enabled: true, StepPx: 800, DelayMs: 250, MaxScrolls: 40},
+				StickyElements: StickyElementsFirstOnly,
+			},
+			wantErr: "",
+		},
+		{
+			name:    "invalid sticky elements mode",
+			req:     &ScreenshotRequest{URL: "https://example.com", FullPage: true, StickyElements: "remove"},
+			wantErr: "stickyElements must be keep, hide, or first-only",
+		},
+		{
+			name:    "invalid fail-on status",
+			req:     &ScreenshotRequest{URL: "https://example.com", FailOnStatus: []int{404, 42}},
+			wantErr: "status must be between 100 and 599",
+		},
+		{
+			name: "valid block rules",
+			req: &ScreenshotRequest{
+				URL:                "https://example.com",
+				BlockRequests:      []string{"*://*.intercom.io/*", "/google-analytics\\.com/"},
+				BlockResourceTypes: []string{ResourceTypeMedia, ResourceTypeFont},
+			},
+			wantErr: "",
+		},
+		{
+			name:    "empty block pattern",
+			req:     &ScreenshotRequest{URL: "https://example.com", BlockRequests: []string{""}},
+			wantErr: "pattern must be between 1 and 500 characters",
+		},
+		{
+			name:    "unknown resource type",
+			req:     &ScreenshotRequest{URL: "https://example.com", BlockResourceTypes: []string{"video"}},
+			wantErr: `unknown resource type "video"`,
+		},
+		{
+			name: "cache TTL too short",
+			req: &ScreenshotRequest{
+				URL:   "https://example.com",
+				Cache: &CacheConfig{Enabled: true, TTLSeconds: 5},
+			},
+			wantErr: "ttlSeconds must be between 60 and 2592000",
+		},
+		{
+			name: "valid storage",
+			req: &ScreenshotRequest{
+				URL:     "https://example.com",
+				Storage: &StorageConfig{Provider: StorageProviderS3, Bucket: "acme-shots", Prefix: "daily/"},
+			},
+			wantErr: "",
+		},
+		{
+			name: "unknown storage provider",
+			req: &ScreenshotRequest{
+				URL:     "https://example.com",
+				Storage: &StorageConfig{Provider: "dropbox", Bucket: "acme-shots"},
+			},
+			wantErr: "provider must be s3, gcs, or azure",
+		},
+		{
+			name: "storage without bucket",
+			req: &ScreenshotRequest{
+				URL:     "https://example.com",
+				Storage: &StorageConfig{Provider: StorageProviderGCS},
+			},
+			wantErr: "bucket is required",
+		},
+		{
+			name:    "valid tags",
+			req:     &ScreenshotRequest{URL: "https://example.com", Tags: []string{"release-42", "customer:acme"}},
+			wantErr: "",
+		},
+		{
+			name:    "tag with spaces",
+			req:     &ScreenshotRequest{URL: "https://example.com", Tags: []string{"release 42"}},
+			wantErr: "tag may only contain letters, digits",
+		},
+		{
+			name: "scroll step too small",
+			req: &ScreenshotRequest{
+				URL:            "https://example.com",
+				ScrollStrategy: &ScrollConfig{Enabled: true, StepPx: 10},
+			},
+			wantErr: "stepPx must be between 100 and 5000",
+		},
+		{
+			name: "too many scrolls",
+			req: &ScreenshotRequest{
+				URL:            "https://example.com",
+				ScrollStrategy: &ScrollConfig{Enabled: true, MaxScrolls: 500},
+			},
+			wantErr: "maxScrolls must be between 1 and 200",
+		},
+		{
+			name:    "custom JS too long",
+			req:     &ScreenshotRequest{URL: "https://example.com", CustomJS: strings.Repeat("x", 10001)},
+			wantErr: "customJs must be at most 10000 characters",
+		},
+		{
+			name:    "custom CSS too long",
+			req:     &ScreenshotRequest{URL: "https://example.com", CustomCSS: strings.Repeat("x", 10001)},
+			wantErr: "customCss must be at most 10000 characters",
+		},
+		{
+			name: "cookie without name",
+			req: &ScreenshotRequest{
+				URL:     "https://example.com",
+				Cookies: []Cookie{{Value: "abc"}},
+			},
+			wantErr: "cookie name is required",
+		},
 	}
 
 	for _, tt := range tests {
@@ -119,6 +578,28 @@ func TestScreenshotRequest_Validation(t *testing.T) {
 	}
 }
 
+func TestBasicAuth_RedactsPassword(t *testing.T) {
+	auth := &BasicAuth{Username: "staging", Password: "hunter2"}
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		assert.NotContains(t, fmt.Sprintf(format, auth), "hunter2", format)
+		assert.NotContains(t, fmt.Sprintf(format, *auth), "hunter2", format)
+	}
+	assert.Contains(t, auth.String(), "staging")
+
+	body, err := json.Marshal(&ScreenshotRequest{URL: "https://example.com", Auth: auth})
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"auth":{"username":"staging","password":"hunter2"}`)
+}
+
+func manyHeaders(n int) map[string]string {
+	headers := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		headers[fmt.Sprintf("X-Header-%d", i)] = "v"
+	}
+	return headers
+}
+
 func TestBulkRequest_Validation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -152,6 +633,54 @@ func TestBulkRequest_Validation(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "per-URL webhooks",
+			req: &BulkRequest{
+				URLs: []BulkURLRequest{
+					{URL: "https://tenant-a.example.com", Options: &BulkURLOptions{WebhookURL: "https://a.example.com/hook", WebhookSecret: "a-secret"}},
+					{URL: "https://tenant-b.example.com", Options: &BulkURLOptions{WebhookURL: "https://b.example.com/hook"}},
+				},
+				WebhookURL: "https://hub.example.com/hook",
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid per-URL webhook",
+			req: &BulkRequest{
+				URLs: []BulkURLRequest{
+					{URL: "https://example.com"},
+					{URL: "https://example.com", Options: &BulkURLOptions{WebhookURL: "ftp://hooks.example.com"}},
+				},
+			},
+			wantErr: "'urls[1].options.webhookUrl': webhook URL must start with http:// or https://",
+		},
+		{
+			name: "per-URL webhook secret too long",
+			req: &BulkRequest{
+				URLs: []BulkURLRequest{
+					{URL: "https://example.com", Options: &BulkURLOptions{WebhookURL: "https://a.example.com", WebhookSecret: strings.Repeat("s", 256)}},
+				},
+			},
+			wantErr: "webhook secret must be at most 255 characters",
+		},
+		{
+			name: "per-URL orientation",
+			req: &BulkRequest{
+				URLs: []BulkURLRequest{
+					{URL: "https://example.com", Options: &BulkURLOptions{Device: "iPad", Orientation: OrientationLandscape}},
+					{URL: "https://example.org", Options: &BulkURLOptions{Device: "iPad", Orientation: "upside-down"}},
+				},
+			},
+			wantErr: "'urls[1].options.orientation': orientation must be portrait or landscape",
+		},
+		{
+			name: "invalid default orientation",
+			req: &BulkRequest{
+				URLs:     []BulkURLRequest{{URL: "https://example.com"}},
+				Defaults: &BulkDefaults{Device: "iPhone 14", Orientation: "Landscape"},
+			},
+			wantErr: "'defaults.orientation'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -181,7 +710,7 @@ func TestComposeRequest_Validation(t *testing.T) {
 		{
 			name:    "no captures or URL",
 			req:     &ComposeRequest{},
-			wantErr: "either captures or url is required",
+			wantErr: "either captures, url, or images is required",
 		},
 		{
 			name: "valid with URL",
@@ -190,6 +719,38 @@ func TestComposeRequest_Validation(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "valid frames",
+			req: &ComposeRequest{
+				Captures: []CaptureItem{{URL: "https://example.com", Frame: &FrameConfig{Style: FrameStyleIPhone14}}},
+				Output:   &ComposeOutputConfig{Frame: &FrameConfig{Style: FrameStyleBrowser, Color: "#1F2937"}},
+			},
+			wantErr: "",
+		},
+		{
+			name: "unknown frame style",
+			req: &ComposeRequest{
+				URL:      "https://example.com",
+				Variants: []VariantConfig{{Frame: &FrameConfig{Style: "pixel-7"}}},
+			},
+			wantErr: "style must be iphone-14, macbook, or browser",
+		},
+		{
+			name: "invalid output overlay font size",
+			req: &ComposeRequest{
+				URL:    "https://example.com",
+				Output: &ComposeOutputConfig{Overlay: &OverlayConfig{Text: "draft", FontSize: 4}},
+			},
+			wantErr: "fontSize must be between 8 and 200",
+		},
+		{
+			name: "invalid frame color",
+			req: &ComposeRequest{
+				URL:    "https://example.com",
+				Output: &ComposeOutputConfig{Frame: &FrameConfig{Style: FrameStyleMacBook, Color: "silver"}},
+			},
+			wantErr: "color must be #RRGGBB",
+		},
 		{
 			name: "valid with captures",
 			req: &ComposeRequest{
@@ -204,6 +765,24 @@ func TestComposeRequest_Validation(t *testing.T) {
 			},
 			wantErr: "URL must start with http:// or https://",
 		},
+		{
+			name: "valid orientations",
+			req: &ComposeRequest{
+				URL: "https://example.com",
+				Variants: []VariantConfig{
+					{Device: "iPad", Orientation: OrientationPortrait},
+					{Device: "iPad", Orientation: OrientationLandscape},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid capture orientation",
+			req: &ComposeRequest{
+				Captures: []CaptureItem{{URL: "https://example.com", Device: "iPad", Orientation: "flat"}},
+			},
+			wantErr: "'captures[0].orientation'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -255,6 +834,31 @@ func TestCreateScheduleRequest_Validation(t *testing.T) {
 			req:     &CreateScheduleRequest{Name: "Test", URL: "https://example.com", Schedule: "0 9 * * *"},
 			wantErr: "",
 		},
+		{
+			name:    "valid multi-URL request",
+			req:     &CreateScheduleRequest{Name: "Test", URLs: []string{"https://example.com", "https://example.com/pricing"}, Schedule: "0 9 * * *"},
+			wantErr: "",
+		},
+		{
+			name:    "URL and URLs",
+			req:     &CreateScheduleRequest{Name: "Test", URL: "https://example.com", URLs: []string{"https://example.com/pricing"}, Schedule: "0 9 * * *"},
+			wantErr: "url and urls are mutually exclusive",
+		},
+		{
+			name:    "invalid URL in URLs",
+			req:     &CreateScheduleRequest{Name: "Test", URLs: []string{"https://example.com", "example.com/pricing"}, Schedule: "0 9 * * *"},
+			wantErr: "validation error for field 'urls[1]'",
+		},
+		{
+			name:    "too many URLs",
+			req:     &CreateScheduleRequest{Name: "Test", URLs: make([]string, 101), Schedule: "0 9 * * *"},
+			wantErr: "maximum 100 URLs allowed",
+		},
+		{
+			name:    "invalid orientation",
+			req:     &CreateScheduleRequest{Name: "Test", URL: "https://example.com", Schedule: "0 9 * * *", Options: &ScheduleScreenshotOptions{Device: "iPad", Orientation: "tilted"}},
+			wantErr: "'options.orientation': orientation must be portrait or landscape",
+		},
 	}
 
 	for _, tt := range tests {
@@ -304,6 +908,173 @@ func TestClient_Screenshot(t *testing.T) {
 	assert.Equal(t, imageData, result)
 }
 
+func TestClient_ScreenshotDetailed(t *testing.T) {
+	imageData := []byte{0x89, 0x50, 0x4E, 0x47}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.NotNil(t, req.Cache)
+		assert.True(t, req.Cache.Enabled)
+		assert.Equal(t, 3600, req.Cache.TTLSeconds)
+
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("X-Cache", "HIT")
+		w.Write(imageData)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	result, err := client.ScreenshotDetailed(context.Background(), &ScreenshotRequest{
+		URL:   "https://example.com",
+		Cache: &CacheConfig{Enabled: true, TTLSeconds: 3600},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, imageData, result.Data)
+	assert.Equal(t, "image/png", result.ContentType)
+	assert.True(t, result.CacheHit())
+}
+
+func TestClient_ScreenshotWithMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.True(t, req.ReturnMetadata)
+
+		meta, _ := json.Marshal(PageMetadata{
+			Title:      "Example Domain",
+			FinalURL:   "https://www.example.com/",
+			StatusCode: 200,
+			OpenGraph:  map[string]string{"image": "https://www.example.com/og.png"},
+		})
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("X-Page-Metadata", base64.StdEncoding.EncodeToString(meta))
+		w.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	req := &ScreenshotRequest{URL: "https://example.com"}
+	result, err := client.ScreenshotWithMeta(context.Background(), req)
+	require.NoError(t, err)
+	assert.False(t, req.ReturnMetadata, "caller's request must not be modified")
+	assert.Equal(t, "Example Domain", result.Metadata.Title)
+	assert.Equal(t, "https://www.example.com/", result.Metadata.FinalURL)
+	assert.Equal(t, 200, result.Metadata.StatusCode)
+	assert.Equal(t, "https://www.example.com/og.png", result.Metadata.OpenGraph["image"])
+	assert.Len(t, result.Data, 4)
+}
+
+func TestClient_ScreenshotBlockReport(t *testing.T) {
+	report := BlockReport{
+		SelectorsRemoved: []string{"#onetrust-banner-sdk"},
+		RulesMatched:     []string{"onetrust"},
+		AdsBlocked:       7,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.True(t, req.ReturnBlockReport)
+		assert.True(t, req.BlockCookieBanners)
+
+		if req.ResponseType == ResponseTypeJSON {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(ScreenshotJSONResponse{URL: req.URL, BlockReport: &report})
+			return
+		}
+		data, _ := json.Marshal(report)
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("X-Block-Report", base64.StdEncoding.EncodeToString(data))
+		w.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	req := &ScreenshotRequest{URL: "https://example.com", BlockCookieBanners: true, ReturnBlockReport: true}
+
+	result, err := client.ScreenshotDetailed(context.Background(), req)
+	require.NoError(t, err)
+	require.NotNil(t, result.BlockReport)
+	assert.Equal(t, report, *result.BlockReport)
+	assert.False(t, result.BlockReport.Empty())
+
+	jsonResult, err := client.ScreenshotJSON(context.Background(), req)
+	require.NoError(t, err)
+	require.NotNil(t, jsonResult.BlockReport)
+	assert.Equal(t, 7, jsonResult.BlockReport.AdsBlocked)
+
+	assert.True(t, (&BlockReport{}).Empty())
+}
+
+func TestClient_ScreenshotJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots", r.URL.Path)
+
+		var req ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, ResponseTypeJSON, req.ResponseType)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"url": "https://example.com",
+			"storageUrl": "https://cdn.allscreenshots.com/abc.png",
+			"width": 1920,
+			"height": 1080,
+			"fileSize": 245120,
+			"renderTimeMs": 1830,
+			"expiresAt": "2030-01-01T00:00:00Z"
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	req := &ScreenshotRequest{URL: "https://example.com"}
+	result, err := client.ScreenshotJSON(context.Background(), req)
+	require.NoError(t, err)
+	assert.Empty(t, req.ResponseType, "caller's request must not be modified")
+	assert.Equal(t, "https://cdn.allscreenshots.com/abc.png", result.StorageURL)
+	assert.Equal(t, 1920, result.Width)
+	assert.Equal(t, 1080, result.Height)
+	assert.Equal(t, int64(245120), result.FileSize)
+	assert.Equal(t, int64(1830), result.RenderTimeMs)
+	require.NotNil(t, result.ExpiresAt)
+}
+
+func TestClient_RenderHTML(t *testing.T) {
+	imageData := []byte{0x89, 0x50, 0x4E, 0x47}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "<h1>Invoice</h1>", body["html"])
+		assert.NotContains(t, body, "url")
+		assert.Equal(t, "png", body["format"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"family": "Brand Sans", "data": "d09GMg=="}}, body["fonts"])
+
+		w.Write(imageData)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	result, err := client.RenderHTML(context.Background(), &RenderHTMLRequest{
+		HTML:     "<h1>Invoice</h1>",
+		Viewport: &ViewportConfig{Width: 1200, Height: 630},
+		Format:   "png",
+		Fonts:    []FontConfig{{Family: "Brand Sans", Data: []byte("wOF2")}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, imageData, result)
+
+	_, err = client.RenderHTML(context.Background(), &RenderHTMLRequest{})
+	assert.True(t, IsValidationError(err))
+}
+
 func TestClient_ScreenshotAsync(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/v1/screenshots/async", r.URL.Path)
@@ -330,36 +1101,150 @@ func TestClient_ScreenshotAsync(t *testing.T) {
 	})
 
 	require.NoError(t, err)
-	assert.Equal(t, "job-123", result.ID)
-	assert.Equal(t, JobStatusQueued, result.Status)
+	assert.Equal(t, "job-123", result.ID)
+	assert.Equal(t, JobStatusQueued, result.Status)
+}
+
+func TestClient_GetJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots/jobs/job-123", r.URL.Path)
+		assert.Equal(t, "GET", r.Method)
+
+		resp := JobResponse{
+			ID:     "job-123",
+			Status: JobStatusCompleted,
+			URL:    "https://example.com",
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+	)
+
+	result, err := client.GetJob(context.Background(), "job-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "job-123", result.ID)
+	assert.Equal(t, JobStatusCompleted, result.Status)
+}
+
+func TestClient_GetJob_Diagnostics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "job-123",
+			"status": "COMPLETED",
+			"consoleMessages": [
+				{"type": "log", "text": "app booted"},
+				{"type": "error", "text": "Uncaught TypeError", "url": "https://example.com/app.js", "line": 42}
+			],
+			"failedRequests": [
+				{"url": "https://cdn.example.com/font.woff2", "method": "GET", "resourceType": "font", "status": 404}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	job, err := client.GetJob(context.Background(), "job-123")
+	require.NoError(t, err)
+	require.Len(t, job.ConsoleMessages, 2)
+	errs := job.ConsoleErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Uncaught TypeError", errs[0].Text)
+	assert.Equal(t, 42, errs[0].Line)
+	require.Len(t, job.FailedRequests, 1)
+	assert.Equal(t, 404, job.FailedRequests[0].Status)
+}
+
+func TestJobResponse_Storage(t *testing.T) {
+	var job JobResponse
+	err := json.Unmarshal([]byte(`{"id":"job-1","status":"COMPLETED","storage":{"provider":"s3","bucket":"acme-shots","key":"daily/job-1.png","url":"https://acme-shots.s3.amazonaws.com/daily/job-1.png"}}`), &job)
+	require.NoError(t, err)
+	require.NotNil(t, job.Storage)
+	assert.Equal(t, "daily/job-1.png", job.Storage.Key)
+	assert.Equal(t, "https://acme-shots.s3.amazonaws.com/daily/job-1.png", job.Storage.URL)
+}
+
+func TestClient_RefreshResultURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots/jobs/job-123/result-url", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"resultUrl":"https://cdn.example.com/job-123.png?sig=new","expiresAt":"2030-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	result, err := client.RefreshResultURL(context.Background(), "job-123")
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/job-123.png?sig=new", result.ResultURL)
+	require.NotNil(t, result.ExpiresAt)
+	assert.Equal(t, 2030, result.ExpiresAt.Year())
+
+	_, err = client.RefreshResultURL(context.Background(), "")
+	assert.True(t, IsValidationError(err))
 }
 
-func TestClient_GetJob(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		assert.Equal(t, "/v1/screenshots/jobs/job-123", r.URL.Path)
-		assert.Equal(t, "GET", r.Method)
+func TestClient_DownloadResult(t *testing.T) {
+	imageData := []byte{0x89, 0x50, 0x4E, 0x47}
 
-		resp := JobResponse{
-			ID:     "job-123",
-			Status: JobStatusCompleted,
-			URL:    "https://example.com",
-		}
+	t.Run("does not send API key to other hosts", func(t *testing.T) {
+		attempts := 0
+		cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			assert.Empty(t, r.Header.Get("X-API-Key"))
+			if attempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write(imageData)
+		}))
+		defer cdn.Close()
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(resp)
-	}))
-	defer server.Close()
+		client := NewClient(
+			WithAPIKey("test-api-key"),
+			WithBaseURL("https://api.example.com"),
+			WithRetryWait(time.Millisecond, 10*time.Millisecond),
+		)
 
-	client := NewClient(
-		WithAPIKey("test-api-key"),
-		WithBaseURL(server.URL),
-	)
+		var buf bytes.Buffer
+		n, err := client.DownloadResult(context.Background(), cdn.URL+"/job-123.png?sig=abc", &buf)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), n)
+		assert.Equal(t, imageData, buf.Bytes())
+		assert.Equal(t, 2, attempts)
+	})
 
-	result, err := client.GetJob(context.Background(), "job-123")
+	t.Run("authenticates API URLs and maps errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "test-api-key", r.Header.Get("X-API-Key"))
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer server.Close()
 
-	require.NoError(t, err)
-	assert.Equal(t, "job-123", result.ID)
-	assert.Equal(t, JobStatusCompleted, result.Status)
+		client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+		_, err := client.DownloadResult(context.Background(), server.URL+"/v1/screenshots/jobs/job-123/result", io.Discard)
+		require.Error(t, err)
+		apiErr, ok := AsAPIError(err)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusGone, apiErr.StatusCode)
+	})
+
+	t.Run("rejects relative URLs", func(t *testing.T) {
+		client := NewClient(WithAPIKey("test-api-key"))
+		_, err := client.DownloadResult(context.Background(), "/job-123.png", io.Discard)
+		assert.True(t, IsValidationError(err))
+	})
 }
 
 func TestClient_ErrorHandling(t *testing.T) {
@@ -392,6 +1277,41 @@ func TestClient_ErrorHandling(t *testing.T) {
 		assert.True(t, IsBadRequest(err))
 	})
 
+	t.Run("handles target status error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req ScreenshotRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, []int{404, 410}, req.FailOnStatus)
+			assert.True(t, req.FailOn5xx)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"code":    "TARGET_STATUS",
+				"message": "Target page responded with 404",
+				"details": map[string]interface{}{"targetStatus": 404},
+			})
+		}))
+		defer server.Close()
+
+		client := NewClient(
+			WithAPIKey("test-api-key"),
+			WithBaseURL(server.URL),
+		)
+
+		_, err := client.Screenshot(context.Background(), &ScreenshotRequest{
+			URL:          "https://example.com/missing",
+			FailOnStatus: []int{404, 410},
+			FailOn5xx:    true,
+		})
+
+		require.Error(t, err)
+		assert.True(t, IsTargetStatusError(err))
+		apiErr, ok := AsAPIError(err)
+		require.True(t, ok)
+		assert.Equal(t, 404, apiErr.TargetStatus())
+	})
+
 	t.Run("handles 401 error", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusUnauthorized)
@@ -475,6 +1395,39 @@ func TestClient_ErrorHandling(t *testing.T) {
 
 		require.Error(t, err)
 		assert.True(t, IsRetryError(err))
+
+		retryErr := err.(*RetryError)
+		require.Len(t, retryErr.Attempts, 3)
+		for i, attempt := range retryErr.Attempts {
+			assert.Equal(t, i+1, attempt.Attempt)
+			assert.Equal(t, http.StatusServiceUnavailable, attempt.StatusCode)
+			assert.True(t, IsAPIError(attempt.Err))
+		}
+		assert.Zero(t, retryErr.Attempts[0].Wait)
+		assert.Positive(t, retryErr.Attempts[1].Wait)
+	})
+
+	t.Run("records network failures", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		baseURL := server.URL
+		server.Close()
+
+		client := NewClient(
+			WithAPIKey("test-api-key"),
+			WithBaseURL(baseURL),
+			WithMaxRetries(1),
+			WithRetryWait(1*time.Millisecond, 10*time.Millisecond),
+		)
+
+		_, err := client.Screenshot(context.Background(), &ScreenshotRequest{
+			URL: "https://example.com",
+		})
+
+		var retryErr *RetryError
+		require.ErrorAs(t, err, &retryErr)
+		require.Len(t, retryErr.Attempts, 2)
+		assert.Zero(t, retryErr.Attempts[1].StatusCode)
+		assert.True(t, IsNetworkError(retryErr.Attempts[1].Err))
 	})
 
 	t.Run("requires API key", func(t *testing.T) {
@@ -520,6 +1473,59 @@ func TestClient_ListJobs(t *testing.T) {
 	assert.Equal(t, "job-1", result[0].ID)
 }
 
+func TestClient_ListJobs_WithTag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"release-42", "customer:acme"}, r.URL.Query()["tag"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]JobResponse{
+			{ID: "job-1", Status: JobStatusCompleted, Tags: []string{"release-42", "customer:acme"}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	result, err := client.ListJobs(context.Background(), WithTag("release-42"), WithTag("customer:acme"))
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, []string{"release-42", "customer:acme"}, result[0].Tags)
+}
+
+func TestClient_ListJobsPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots/jobs", r.URL.Path)
+		assert.Equal(t, "20", r.URL.Query().Get("limit"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"jobs":[{"id":"job-1","status":"FAILED"}],"total":57,"nextCursor":"c2"}`))
+		} else {
+			w.Write([]byte(`[{"id":"job-2","status":"FAILED"},{"id":"job-3","status":"FAILED"}]`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	page, err := client.ListJobsPage(context.Background(), WithLimit(20))
+	require.NoError(t, err)
+	require.Len(t, page.Jobs, 1)
+	assert.Equal(t, 57, page.Total)
+	assert.Equal(t, "c2", page.NextCursor)
+
+	// A plain array is a complete, unpaginated listing.
+	page, err = client.ListJobsPage(context.Background(), WithLimit(20), WithCursor(page.NextCursor))
+	require.NoError(t, err)
+	assert.Len(t, page.Jobs, 2)
+	assert.Equal(t, 2, page.Total)
+	assert.Empty(t, page.NextCursor)
+
+	jobs, err := client.ListJobs(context.Background(), WithLimit(20))
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", jobs[0].ID)
+}
+
 func TestClient_CancelJob(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, "/v1/screenshots/jobs/job-123/cancel", r.URL.Path)
@@ -546,6 +1552,43 @@ func TestClient_CancelJob(t *testing.T) {
 	assert.Equal(t, JobStatusCancelled, result.Status)
 }
 
+func TestClient_DeleteJobs(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		calls = append(calls, r.URL.RequestURI())
+
+		if r.URL.Path == "/v1/screenshots/jobs" {
+			assert.Equal(t, "2024-01-31T00:00:00Z", r.URL.Query().Get("completedBefore"))
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"deleted":42}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	require.NoError(t, client.DeleteJob(ctx, "job-123"))
+	require.NoError(t, client.DeleteBulkJob(ctx, "bulk-456"))
+	deleted, err := client.DeleteCompletedJobsBefore(ctx, time.Date(2024, 1, 31, 1, 0, 0, 0, time.FixedZone("CET", 3600)))
+	require.NoError(t, err)
+	assert.Equal(t, 42, deleted)
+
+	assert.Equal(t, []string{
+		"/v1/screenshots/jobs/job-123",
+		"/v1/screenshots/bulk/bulk-456",
+		"/v1/screenshots/jobs?completedBefore=2024-01-31T00%3A00%3A00Z",
+	}, calls)
+
+	assert.True(t, IsValidationError(client.DeleteJob(ctx, "")))
+	assert.True(t, IsValidationError(client.DeleteBulkJob(ctx, "")))
+	_, err = client.DeleteCompletedJobsBefore(ctx, time.Time{})
+	assert.True(t, IsValidationError(err))
+}
+
 func TestClient_BulkOperations(t *testing.T) {
 	t.Run("CreateBulkJob", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -632,6 +1675,66 @@ func TestClient_BulkOperations(t *testing.T) {
 	})
 }
 
+func TestClient_GetScheduleHistory_MultiURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/schedules/sched-1/history", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"scheduleId":"sched-1","totalExecutions":1,"executions":[{"id":"exec-1","status":"PARTIAL","results":[` +
+			`{"url":"https://example.com","status":"COMPLETED","resultUrl":"https://cdn.example.com/1.png"},` +
+			`{"url":"https://example.com/pricing","status":"FAILED","errorCode":"TIMEOUT"}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	history, err := client.GetScheduleHistory(context.Background(), "sched-1", 0)
+	require.NoError(t, err)
+	require.Len(t, history.Executions, 1)
+	results := history.Executions[0].Results
+	require.Len(t, results, 2)
+	assert.Equal(t, "https://cdn.example.com/1.png", results[0].ResultURL)
+	assert.Equal(t, "TIMEOUT", results[1].ErrorCode)
+}
+
+func TestClient_ScheduleStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/schedules/sched-1/stats":
+			w.Write([]byte(`{"scheduleId":"sched-1","totalExecutions":40,"successCount":30,"failureCount":10,"successRate":75,"averageRenderTimeMs":2100,"consecutiveFailures":4,"lastErrorCode":"TIMEOUT","lastError":"Navigation timed out","lastFailureAt":"2024-03-01T09:00:00Z"}`))
+		case "/v1/schedules/unhealthy":
+			assert.Equal(t, "3", r.URL.Query().Get("consecutiveFailures"))
+			w.Write([]byte(`{"schedules":[{"scheduleId":"sched-1","scheduleName":"Homepage","consecutiveFailures":4,"lastError":"Navigation timed out"}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	stats, err := client.GetScheduleStats(ctx, "sched-1")
+	require.NoError(t, err)
+	assert.Equal(t, 75.0, stats.SuccessRate)
+	assert.Equal(t, int64(2100), stats.AverageRenderTimeMs)
+	assert.Equal(t, 4, stats.ConsecutiveFailures)
+	assert.Equal(t, "Navigation timed out", stats.LastError)
+	require.NotNil(t, stats.LastFailureAt)
+	assert.Nil(t, stats.LastSuccessAt)
+
+	unhealthy, err := client.ListUnhealthySchedules(ctx, 3)
+	require.NoError(t, err)
+	require.Len(t, unhealthy, 1)
+	assert.Equal(t, "Homepage", unhealthy[0].ScheduleName)
+
+	_, err = client.GetScheduleStats(ctx, "")
+	assert.True(t, IsValidationError(err))
+	_, err = client.ListUnhealthySchedules(ctx, 0)
+	assert.True(t, IsValidationError(err))
+}
+
 func TestClient_Usage(t *testing.T) {
 	t.Run("GetUsage", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -691,24 +1794,36 @@ func TestClient_Usage(t *testing.T) {
 	})
 }
 
-func TestCalculateBackoff(t *testing.T) {
-	client := NewClient(
-		WithRetryWait(1*time.Second, 30*time.Second),
-	)
-
-	// Test that backoff increases with attempts
-	backoff1 := client.calculateBackoff(1)
-	backoff2 := client.calculateBackoff(2)
-	backoff3 := client.calculateBackoff(3)
+func TestClient_PingAndValidateCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.WriteHeader(http.StatusOK)
+		case "/v1/usage/quota":
+			if r.Header.Get("X-API-Key") != "good-key" {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"code":"UNAUTHORIZED","message":"Invalid API key"}`))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"tier":"pro"}`))
+		}
+	}))
+	defer server.Close()
 
-	// Backoff should generally increase (allowing for jitter)
-	assert.Less(t, backoff1, 2*time.Second)
-	assert.LessOrEqual(t, backoff1, backoff2+500*time.Millisecond) // Account for jitter
-	assert.LessOrEqual(t, backoff2, backoff3+1*time.Second)
+	ctx := context.Background()
+	good := NewClient(WithAPIKey("good-key"), WithBaseURL(server.URL))
+	require.NoError(t, good.Ping(ctx))
+	status, err := good.ValidateCredentials(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "pro", status.Tier)
 
-	// Backoff should not exceed max
-	backoff10 := client.calculateBackoff(10)
-	assert.LessOrEqual(t, backoff10, 30*time.Second)
+	bad := NewClient(WithAPIKey("revoked-key"), WithBaseURL(server.URL))
+	require.NoError(t, bad.Ping(ctx))
+	_, err = bad.ValidateCredentials(ctx)
+	assert.True(t, IsUnauthorized(err))
+	assert.Contains(t, err.Error(), "Invalid API key")
 }
 
 func TestErrorTypes(t *testing.T) {
@@ -741,14 +1856,266 @@ func TestErrorTypes(t *testing.T) {
 		assert.True(t, IsNetworkError(err))
 	})
 
+	t.Run("JobFailedError without job", func(t *testing.T) {
+		err := &JobFailedError{}
+		assert.Equal(t, "allscreenshots: job failed", err.Error())
+		assert.True(t, IsJobFailedError(err))
+		assert.False(t, IsTimeoutError(err))
+		assert.False(t, IsTargetStatusError(err))
+		assert.Equal(t, "", ErrorCode(err))
+	})
+
+	t.Run("wrapped JobFailedError", func(t *testing.T) {
+		timedOut := fmt.Errorf("nightly run: %w", &JobFailedError{Job: &JobResponse{ID: "job-1", Status: JobStatusFailed, ErrorCode: ErrCodeTimeout}})
+		assert.True(t, IsTimeoutError(timedOut))
+		assert.False(t, IsTargetStatusError(timedOut))
+
+		targetStatus := fmt.Errorf("nightly run: %w", &JobFailedError{Job: &JobResponse{ID: "job-2", Status: JobStatusFailed, ErrorCode: ErrCodeTargetStatus}})
+		assert.True(t, IsTargetStatusError(targetStatus))
+		assert.False(t, IsTimeoutError(targetStatus))
+	})
+
 	t.Run("RetryError", func(t *testing.T) {
 		innerErr := &NetworkError{Message: "timeout"}
 		err := &RetryError{
-			Attempts: 3,
-			LastErr:  innerErr,
+			Attempts: []AttemptInfo{
+				{Attempt: 1, StatusCode: 503, Err: &APIError{StatusCode: 503}},
+				{Attempt: 2, StatusCode: 429, Err: &APIError{StatusCode: 429}, Wait: time.Second},
+				{Attempt: 3, Err: innerErr, Wait: 2 * time.Second},
+			},
+			LastErr: innerErr,
 		}
 		assert.Contains(t, err.Error(), "3 attempts")
 		assert.True(t, IsRetryError(err))
 		assert.Equal(t, innerErr, err.Unwrap())
 	})
 }
+
+func TestClient_TimeoutErrors(t *testing.T) {
+	t.Run("client timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer server.Close()
+
+		client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithTimeout(20*time.Millisecond))
+		_, err := client.Screenshot(context.Background(), &ScreenshotRequest{URL: "https://example.com"})
+		require.Error(t, err)
+		assert.True(t, IsTimeoutError(err))
+		assert.False(t, IsNetworkError(err))
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer server.Close()
+
+		client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := client.Screenshot(ctx, &ScreenshotRequest{URL: "https://example.com"})
+		require.Error(t, err)
+		assert.True(t, IsTimeoutError(err))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("cancelled context is not a timeout", func(t *testing.T) {
+		client := NewClient(WithAPIKey("test-api-key"), WithBaseURL("http://127.0.0.1:1"))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := client.Screenshot(ctx, &ScreenshotRequest{URL: "https://example.com"})
+		require.Error(t, err)
+		assert.False(t, IsTimeoutError(err))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("API TIMEOUT code", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"code": "TIMEOUT", "message": "Page did not load within 30000ms"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+		_, err := client.Screenshot(context.Background(), &ScreenshotRequest{URL: "https://example.com"})
+		require.Error(t, err)
+		assert.True(t, IsTimeoutError(err))
+		assert.Contains(t, err.Error(), "Page did not load within 30000ms")
+
+		apiErr, ok := AsAPIError(err)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeTimeout, apiErr.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+		assert.True(t, IsAPIError(err))
+	})
+
+	t.Run("retried timeouts", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusGatewayTimeout)
+			w.Write([]byte(`{"code": "TIMEOUT", "message": "Upstream timed out"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(
+			WithAPIKey("test-api-key"),
+			WithBaseURL(server.URL),
+			WithMaxRetries(1),
+			WithRetryWait(time.Millisecond, 10*time.Millisecond),
+		)
+		_, err := client.GetJob(context.Background(), "job-123")
+		require.Error(t, err)
+		assert.True(t, IsRetryError(err))
+		assert.True(t, IsTimeoutError(err))
+	})
+
+	t.Run("timed out job", func(t *testing.T) {
+		err := &JobFailedError{Job: &JobResponse{ID: "job-1", Status: JobStatusFailed, ErrorCode: ErrCodeTimeout}}
+		assert.True(t, IsTimeoutError(err))
+		assert.False(t, IsTimeoutError(&JobFailedError{Job: &JobResponse{ID: "job-2", Status: JobStatusFailed}}))
+	})
+}
+
+func TestIsRetryableAndErrorCode(t *testing.T) {
+	rateLimited := &APIError{StatusCode: 429, Code: ErrCodeRateLimitExceeded}
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+		code      string
+	}{
+		{"nil", nil, false, ""},
+		{"rate limited", rateLimited, true, ErrCodeRateLimitExceeded},
+		{"service unavailable", &APIError{StatusCode: 503, Code: ErrCodeServiceUnavailable}, true, ErrCodeServiceUnavailable},
+		{"internal error", &APIError{StatusCode: 500, Code: ErrCodeInternalError}, false, ErrCodeInternalError},
+		{"bad request", &APIError{StatusCode: 400, Code: ErrCodeInvalidURL}, false, ErrCodeInvalidURL},
+		{"wrapped", fmt.Errorf("capture homepage: %w", rateLimited), true, ErrCodeRateLimitExceeded},
+		{"retries exhausted", &RetryError{LastErr: rateLimited}, true, ErrCodeRateLimitExceeded},
+		{"API timeout", timeoutError(&APIError{StatusCode: 422, Code: ErrCodeTimeout}), false, ErrCodeTimeout},
+		{"wrapped API timeout", fmt.Errorf("capture homepage: %w", &RetryError{LastErr: timeoutError(&APIError{StatusCode: 408, Code: ErrCodeTimeout})}), false, ErrCodeTimeout},
+		{"gateway timeout", timeoutError(&APIError{StatusCode: 504, Code: ErrCodeTimeout}), true, ErrCodeTimeout},
+		{"transport timeout", &TimeoutError{Message: "request timed out", Cause: &url.Error{Op: "Post", URL: "https://api.example", Err: &net.OpError{Op: "dial", Net: "tcp", Err: os.ErrDeadlineExceeded}}}, true, ""},
+		{"context deadline", &TimeoutError{Message: "request timed out", Cause: &url.Error{Op: "Post", URL: "https://api.example", Err: context.DeadlineExceeded}}, false, ""},
+		{"request timeout", &TimeoutError{Message: "request timed out", Cause: context.DeadlineExceeded}, false, ""},
+		{"poll timeout", &TimeoutError{Message: "timed out waiting for job job-1"}, false, ""},
+		{"connection refused", &NetworkError{Message: "request failed", Cause: fmt.Errorf("dial tcp: connection refused")}, true, ""},
+		{"TLS failure", &NetworkError{Message: "request failed", Cause: fmt.Errorf("x509: certificate signed by unknown authority")}, false, ""},
+		{"validation", &ValidationError{Field: "url", Message: "URL is required"}, false, ""},
+		{"cancelled", context.Canceled, false, ""},
+		{"client closed", ErrClientClosed, false, ""},
+		{"failed job", &JobFailedError{Job: &JobResponse{ID: "job-1", Status: JobStatusFailed, ErrorCode: ErrCodeURLUnreachable}}, false, ErrCodeURLUnreachable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.retryable, IsRetryable(tt.err))
+			assert.Equal(t, tt.code, ErrorCode(tt.err))
+		})
+	}
+}
+
+func TestIsRetryable_RequestTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+	req := &ScreenshotRequest{URL: "https://example.com"}
+
+	// The HTTP client's own timeout is retried by the client.
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithTimeout(20*time.Millisecond), WithMaxRetries(0))
+	_, err := client.Screenshot(context.Background(), req)
+	require.True(t, IsTimeoutError(err))
+	assert.True(t, IsRetryable(err))
+
+	// The caller's context deadline is not.
+	client = NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithMaxRetries(0))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.Screenshot(ctx, req)
+	require.True(t, IsTimeoutError(err))
+	assert.False(t, IsRetryable(err))
+}
+
+func TestClient_ListComposeJobsPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots/compose/jobs", r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, []string{"FAILED", "CANCELLED"}, query["status"])
+		assert.Equal(t, "2026-01-02T03:04:05Z", query.Get("createdAfter"))
+		assert.Equal(t, "25", query.Get("limit"))
+
+		w.Header().Set("Content-Type", "application/json")
+		if query.Get("cursor") == "" {
+			json.NewEncoder(w).Encode(ComposeJobListResponse{
+				Jobs:       []ComposeJobSummaryResponse{{JobID: "compose-1", Status: "FAILED"}},
+				NextCursor: "page-2",
+			})
+			return
+		}
+		assert.Equal(t, "page-2", query.Get("cursor"))
+		json.NewEncoder(w).Encode(ComposeJobListResponse{
+			Jobs: []ComposeJobSummaryResponse{{JobID: "compose-2", Status: "CANCELLED"}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	opts := []ListJobsOption{
+		WithStatus(JobStatusFailed),
+		WithStatus(JobStatusCancelled),
+		WithCreatedAfter(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+		WithLimit(25),
+	}
+
+	page, err := client.ListComposeJobsPage(context.Background(), opts...)
+	require.NoError(t, err)
+	require.Len(t, page.Jobs, 1)
+	assert.Equal(t, "page-2", page.NextCursor)
+
+	page, err = client.ListComposeJobsPage(context.Background(), append(opts, WithCursor(page.NextCursor))...)
+	require.NoError(t, err)
+	require.Len(t, page.Jobs, 1)
+	assert.Equal(t, "compose-2", page.Jobs[0].JobID)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestClient_ListComposeJobs_PlainArray(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]ComposeJobSummaryResponse{{JobID: "compose-1"}, {JobID: "compose-2"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	jobs, err := client.ListComposeJobs(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, jobs, 2)
+}
+
+func TestClient_CancelAndDeleteComposeJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/screenshots/compose/jobs/compose-1/cancel":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(ComposeJobStatusResponse{JobID: "compose-1", Status: "CANCELLED"})
+		case "DELETE /v1/screenshots/compose/jobs/compose-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	job, err := client.CancelComposeJob(context.Background(), "compose-1")
+	require.NoError(t, err)
+	assert.Equal(t, "CANCELLED", job.Status)
+	require.NoError(t, client.DeleteComposeJob(context.Background(), "compose-1"))
+
+	_, err = client.CancelComposeJob(context.Background(), "")
+	assert.True(t, IsValidationError(err))
+	assert.True(t, IsValidationError(client.DeleteComposeJob(context.Background(), "")))
+}
@@ -0,0 +1,130 @@
+package allscreenshots
+
+import "reflect"
+
+// Clone returns a deep copy of r that shares no pointers, slices, or maps
+// with it, so the copy can be changed or handed to another goroutine
+// without affecting r. It returns nil for a nil request.
+//
+// Example:
+//
+//	req := base.Clone()
+//	req.URL = "https://example.com/pricing"
+func (r *ScreenshotRequest) Clone() *ScreenshotRequest {
+	if r == nil {
+		return nil
+	}
+	return deepCopy(r)
+}
+
+// Clone returns a deep copy of d, or nil for nil defaults.
+func (d *BulkDefaults) Clone() *BulkDefaults {
+	if d == nil {
+		return nil
+	}
+	return deepCopy(d)
+}
+
+// Clone returns a deep copy of d, or nil for nil defaults.
+func (d *CaptureDefaults) Clone() *CaptureDefaults {
+	if d == nil {
+		return nil
+	}
+	return deepCopy(d)
+}
+
+// Mergeable is the set of option types MergeOptions accepts.
+type Mergeable interface {
+	ScreenshotRequest | BulkDefaults | CaptureDefaults
+}
+
+// MergeOptions returns a new value holding base with every field override
+// sets laid over it. A field counts as set when it is not the zero value,
+// so override cannot turn a boolean off or clear a string; pointer fields
+// such as Viewport are replaced as a whole rather than merged. Neither
+// base nor override is modified, and the result shares no memory with
+// them. Either may be nil.
+//
+// Example:
+//
+//	req := allscreenshots.MergeOptions(teamDefaults, &allscreenshots.ScreenshotRequest{
+//	    URL:      "https://example.com",
+//	    FullPage: true,
+//	})
+func MergeOptions[T Mergeable](base, override *T) *T {
+	out := new(T)
+	if base != nil {
+		out = deepCopy(base)
+	}
+	if override == nil {
+		return out
+	}
+
+	dst := reflect.ValueOf(out).Elem()
+	src := reflect.ValueOf(override).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		if f := src.Field(i); !f.IsZero() && dst.Field(i).CanSet() {
+			copyValue(dst.Field(i), f)
+		}
+	}
+	return out
+}
+
+// deepCopy returns a copy of *v that shares no pointers, slices, or maps
+// with it.
+func deepCopy[T any](v *T) *T {
+	out := new(T)
+	copyValue(reflect.ValueOf(out).Elem(), reflect.ValueOf(v).Elem())
+	return out
+}
+
+// copyValue sets dst to a deep copy of src. Unexported struct fields, such
+// as those of time.Time, are copied shallowly.
+func copyValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		p := reflect.New(src.Type().Elem())
+		copyValue(p.Elem(), src.Elem())
+		dst.Set(p)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			copyValue(s.Index(i), src.Index(i))
+		}
+		dst.Set(s)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			v := reflect.New(src.Type().Elem()).Elem()
+			copyValue(v, iter.Value())
+			m.SetMapIndex(iter.Key(), v)
+		}
+		dst.Set(m)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		v := reflect.New(src.Elem().Type()).Elem()
+		copyValue(v, src.Elem())
+		dst.Set(v)
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				copyValue(dst.Field(i), src.Field(i))
+			}
+		}
+	default:
+		dst.Set(src)
+	}
+}
@@ -0,0 +1,82 @@
+package allscreenshots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScreenshotRequest_Clone(t *testing.T) {
+	req := &ScreenshotRequest{
+		URL:           "https://example.com",
+		Viewport:      &ViewportConfig{Width: 1280, Height: 720},
+		HideSelectors: []string{".banner"},
+		Headers:       map[string]string{"X-Env": "prod"},
+		Cookies:       []Cookie{{Name: "session", Value: "abc"}},
+		Fonts:         []FontConfig{{Family: "Brand Sans", Data: []byte("wOF2")}},
+	}
+
+	clone := req.Clone()
+	require.Equal(t, req, clone)
+
+	clone.Viewport.Width = 390
+	clone.HideSelectors[0] = ".modal"
+	clone.Headers["X-Env"] = "staging"
+	clone.Cookies[0].Value = "xyz"
+	clone.Fonts[0].Data[0] = 'x'
+
+	assert.Equal(t, 1280, req.Viewport.Width)
+	assert.Equal(t, ".banner", req.HideSelectors[0])
+	assert.Equal(t, "prod", req.Headers["X-Env"])
+	assert.Equal(t, "abc", req.Cookies[0].Value)
+	assert.Equal(t, []byte("wOF2"), req.Fonts[0].Data)
+
+	assert.Nil(t, (*ScreenshotRequest)(nil).Clone())
+}
+
+func TestDefaults_Clone(t *testing.T) {
+	bulk := &BulkDefaults{Device: "iPhone 14", Auth: &BasicAuth{Username: "u", Password: "p"}}
+	bulkClone := bulk.Clone()
+	bulkClone.Auth.Password = "changed"
+	assert.Equal(t, "p", bulk.Auth.Password)
+
+	capture := &CaptureDefaults{HideSelectors: []string{".ad"}}
+	captureClone := capture.Clone()
+	captureClone.HideSelectors[0] = ".popup"
+	assert.Equal(t, ".ad", capture.HideSelectors[0])
+}
+
+func TestMergeOptions(t *testing.T) {
+	base := &ScreenshotRequest{
+		Viewport:   &ViewportConfig{Width: 1200, Height: 630},
+		Format:     "png",
+		BlockLevel: "pro",
+		Headers:    map[string]string{"X-Team": "web"},
+	}
+	override := &ScreenshotRequest{
+		URL:      "https://example.com",
+		Format:   "webp",
+		FullPage: true,
+	}
+
+	merged := MergeOptions(base, override)
+	assert.Equal(t, &ScreenshotRequest{
+		URL:        "https://example.com",
+		Viewport:   &ViewportConfig{Width: 1200, Height: 630},
+		Format:     "webp",
+		FullPage:   true,
+		BlockLevel: "pro",
+		Headers:    map[string]string{"X-Team": "web"},
+	}, merged)
+
+	merged.Viewport.Width = 1
+	merged.Headers["X-Team"] = "api"
+	assert.Equal(t, 1200, base.Viewport.Width)
+	assert.Equal(t, "web", base.Headers["X-Team"])
+	assert.Empty(t, base.URL)
+
+	assert.Equal(t, &BulkDefaults{Device: "iPad", Format: "png"}, MergeOptions(&BulkDefaults{Device: "iPad"}, &BulkDefaults{Format: "png"}))
+	assert.Equal(t, &CaptureDefaults{DarkMode: true}, MergeOptions(nil, &CaptureDefaults{DarkMode: true}))
+	assert.Equal(t, &CaptureDefaults{}, MergeOptions[CaptureDefaults](nil, nil))
+}
@@ -0,0 +1,87 @@
+package allscreenshots
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClientClosed is returned by calls made after Close.
+var ErrClientClosed = errors.New("allscreenshots: client is closed")
+
+// lifecycle tracks the work in flight on a client so Close can drain it.
+type lifecycle struct {
+	mu     sync.Mutex
+	closed bool
+	// closing is closed when Close is called
+	closing chan struct{}
+	active  sync.WaitGroup
+}
+
+// Close shuts the client down gracefully, e.g. when a Kubernetes pod
+// receives SIGTERM. New calls fail with ErrClientClosed at once. Job event
+// subscriptions and progress watchers are stopped, and Close waits for
+// requests already in flight, including the captures of a Pool, until they
+// finish or ctx is done. Idle connections are then closed. Pollers such as
+// WaitForJob return ErrClientClosed at their next status check.
+//
+// Close returns ctx's error if requests were still in flight when ctx was
+// done. Calling Close again waits for the same requests.
+//
+// Example:
+//
+//	<-shutdown
+//	ctx, cancel := context.WithTimeout(context.Background(), 25*time.Second)
+//	defer cancel()
+//	if err := client.Close(ctx); err != nil {
+//	    log.Printf("abandoned in-flight captures: %v", err)
+//	}
+func (c *Client) Close(ctx context.Context) error {
+	l := &c.lifecycle
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.closing)
+	}
+	l.mu.Unlock()
+	defer c.httpClient.CloseIdleConnections()
+
+	drained := make(chan struct{})
+	go func() {
+		l.active.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// track registers work that Close waits for. The returned done must be
+// called once the work has finished.
+func (c *Client) track() (done func(), err error) {
+	l := &c.lifecycle
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, ErrClientClosed
+	}
+	l.active.Add(1)
+	return l.active.Done, nil
+}
+
+// closable returns a copy of ctx that is also cancelled when Close is
+// called, for background work that would otherwise run indefinitely.
+func (c *Client) closable(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-c.lifecycle.closing:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
@@ -0,0 +1,90 @@
+package allscreenshots
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Close(t *testing.T) {
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := client.Screenshot(context.Background(), &ScreenshotRequest{URL: "https://example.com"})
+		result <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, client.Close(ctx))
+
+	// The in-flight capture was drained, not cut off.
+	select {
+	case err := <-result:
+		assert.NoError(t, err)
+	default:
+		t.Fatal("Close returned before the in-flight request finished")
+	}
+
+	_, err := client.Screenshot(context.Background(), &ScreenshotRequest{URL: "https://example.com"})
+	assert.True(t, errors.Is(err, ErrClientClosed))
+	assert.NoError(t, client.Close(ctx))
+}
+
+func TestClient_CloseDeadline(t *testing.T) {
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(300 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	go client.Screenshot(context.Background(), &ScreenshotRequest{URL: "https://example.com"})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, client.Close(ctx), context.DeadlineExceeded)
+}
+
+func TestClient_CloseStopsSubscriptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": heartbeat\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	events, err := client.SubscribeJobEvents(context.Background(), SubscribeOptions{})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, client.Close(ctx))
+
+	_, open := <-events
+	assert.False(t, open)
+
+	_, err = client.SubscribeJobEvents(context.Background(), SubscribeOptions{})
+	assert.ErrorIs(t, err, ErrClientClosed)
+}
@@ -0,0 +1,79 @@
+package allscreenshots
+
+import (
+	"context"
+	"sync"
+)
+
+// WithRequestCoalescing makes concurrent Screenshot calls for identical
+// requests, as determined by RequestKey, share a single API call. Requests
+// that differ in their storage target, webhook, or tags are not merged, so
+// each one's upload and notification still happen. This
+// suits on-demand endpoints such as OG image generators, where a burst of
+// traffic for one page would otherwise trigger a capture per visitor.
+//
+// The shared call is not cancelled when one caller's context is; each
+// caller stops waiting when its own context is done.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(allscreenshots.WithRequestCoalescing())
+func WithRequestCoalescing() ClientOption {
+	return func(c *Client) {
+		c.flights = &flightGroup{}
+	}
+}
+
+// flightGroup runs at most one call per key at a time and shares its result
+// with every caller that asks for the same key meanwhile.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is a call in progress.
+type flight struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// do runs fn for key, or waits for the call already running for key.
+// Callers other than the one that ran fn receive a copy of the data, so
+// none can modify another's result.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flight)
+	}
+	if f, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-f.done:
+			if f.err != nil {
+				return nil, f.err
+			}
+			return append([]byte(nil), f.data...), nil
+		case <-ctx.Done():
+			return nil, timeoutError(ctx.Err())
+		}
+	}
+	f := &flight{done: make(chan struct{})}
+	g.calls[key] = f
+	g.mu.Unlock()
+
+	go func() {
+		f.data, f.err = fn(context.WithoutCancel(ctx))
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(f.done)
+	}()
+
+	select {
+	case <-f.done:
+		return f.data, f.err
+	case <-ctx.Done():
+		return nil, timeoutError(ctx.Err())
+	}
+}
@@ -0,0 +1,126 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithRequestCoalescing(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("image"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRequestCoalescing())
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([][]byte, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = client.Screenshot(context.Background(), &ScreenshotRequest{URL: "https://example.com/og"})
+		}(i)
+	}
+
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond) // let the other callers join the flight
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for i := 0; i < callers; i++ {
+		require.NoError(t, errs[i])
+		assert.Equal(t, []byte("image"), results[i])
+	}
+
+	// Once the flight has landed, the next call goes to the API again.
+	_, err := client.Screenshot(context.Background(), &ScreenshotRequest{URL: "https://example.com/og"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_WithRequestCoalescing_DeliveryOptions(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("image"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRequestCoalescing())
+
+	reqs := []*ScreenshotRequest{
+		{URL: "https://example.com/og"},
+		{URL: "https://example.com/og", Storage: &StorageConfig{Provider: "s3", Bucket: "customer-a"}},
+		{URL: "https://example.com/og", Storage: &StorageConfig{Provider: "s3", Bucket: "customer-b"}},
+		{URL: "https://example.com/og", WebhookURL: "https://hooks.example.com/a"},
+		{URL: "https://example.com/og", Tags: []string{"campaign"}},
+	}
+	var wg sync.WaitGroup
+	errs := make([]error, len(reqs))
+	for i, req := range reqs {
+		wg.Add(1)
+		go func(i int, req *ScreenshotRequest) {
+			defer wg.Done()
+			_, errs[i] = client.Screenshot(context.Background(), req)
+		}(i, req)
+	}
+
+	require.Eventually(t, func() bool { return calls.Load() == int32(len(reqs)) }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+func TestFlightGroup_CallerCancellation(t *testing.T) {
+	var g flightGroup
+	release := make(chan struct{})
+	fn := func(ctx context.Context) ([]byte, error) {
+		<-release
+		return []byte("data"), ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error, 1)
+	go func() {
+		_, err := g.do(ctx, "k", fn)
+		leader <- err
+	}()
+	require.Eventually(t, func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return len(g.calls) == 1
+	}, time.Second, time.Millisecond)
+
+	follower := make(chan []byte, 1)
+	go func() {
+		data, _ := g.do(context.Background(), "k", fn)
+		follower <- data
+	}()
+
+	cancel()
+	assert.ErrorIs(t, <-leader, context.Canceled)
+	close(release)
+	assert.Equal(t, []byte("data"), <-follower, "the shared call outlives the cancelled caller")
+}
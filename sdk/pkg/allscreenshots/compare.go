@@ -0,0 +1,59 @@
+package allscreenshots
+
+import (
+	"context"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots/visdiff"
+)
+
+// CompareResult is the outcome of CaptureAndCompare.
+type CompareResult struct {
+	// Screenshot is the newly captured image
+	Screenshot []byte
+	// Diff compares the baseline against Screenshot
+	Diff *visdiff.DiffResult
+}
+
+// CaptureAndCompare captures a screenshot and compares it against a baseline
+// image with visdiff. The request's format, after client defaults are
+// applied, must be one visdiff can decode (png or jpeg).
+//
+// Example:
+//
+//	baseline, _ := os.ReadFile("testdata/home.png")
+//	result, err := client.CaptureAndCompare(ctx, &allscreenshots.ScreenshotRequest{
+//	    URL:    "https://example.com",
+//	    Device: "Desktop HD",
+//	}, baseline, visdiff.Options{Threshold: 0.1})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if !result.Diff.Identical() {
+//	    os.WriteFile("home-diff.png", result.Diff.Image, 0644)
+//	}
+func (c *Client) CaptureAndCompare(ctx context.Context, req *ScreenshotRequest, baseline []byte, opts visdiff.Options) (*CompareResult, error) {
+	req = c.normalizeRequestURL(req)
+	if err := validateScreenshotRequest(req); err != nil {
+		return nil, err
+	}
+	req = c.applyDefaults(req)
+	switch req.Format {
+	case "", "png", "jpeg", "jpg":
+	default:
+		return nil, &ValidationError{Field: "format", Message: "format must be png or jpeg for comparison"}
+	}
+	if len(baseline) == 0 {
+		return nil, &ValidationError{Field: "baseline", Message: "baseline image is required"}
+	}
+
+	data, err := c.Screenshot(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := visdiff.Compare(baseline, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &CompareResult{Screenshot: data, Diff: diff}, nil
+}
@@ -0,0 +1,52 @@
+package allscreenshots
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots/visdiff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CaptureAndCompare(t *testing.T) {
+	encode := func(c color.Color) []byte {
+		img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+		for y := 0; y < 4; y++ {
+			for x := 0; x < 4; x++ {
+				img.Set(x, y, c)
+			}
+		}
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, img))
+		return buf.Bytes()
+	}
+	current := encode(color.Black)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(current)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	req := &ScreenshotRequest{URL: "https://example.com"}
+
+	result, err := client.CaptureAndCompare(context.Background(), req, encode(color.White), visdiff.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, current, result.Screenshot)
+	assert.Equal(t, 16, result.Diff.ChangedPixels)
+
+	result, err = client.CaptureAndCompare(context.Background(), req, current, visdiff.Options{})
+	require.NoError(t, err)
+	assert.True(t, result.Diff.Identical())
+
+	_, err = client.CaptureAndCompare(context.Background(), &ScreenshotRequest{URL: "https://example.com", Format: "webp"}, current, visdiff.Options{})
+	assert.True(t, IsValidationError(err))
+}
@@ -0,0 +1,189 @@
+// Package compose renders compose layout previews locally.
+//
+// RenderPreview draws the placements returned by
+// Client.GetComposeLayoutPreview, so a UI can show the chosen layout
+// immediately without capturing anything. The package has no dependencies
+// beyond the standard library; labels use a built-in bitmap font.
+package compose
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+// DefaultPreviewWidth is the width previews are scaled down to by default.
+const DefaultPreviewWidth = 800
+
+// Default preview colors.
+var (
+	DefaultBackground = color.RGBA{R: 0xf3, G: 0xf4, B: 0xf6, A: 0xff}
+	DefaultFill       = color.RGBA{R: 0xdb, G: 0xea, B: 0xfe, A: 0xff}
+	DefaultBorder     = color.RGBA{R: 0x3b, G: 0x82, B: 0xf6, A: 0xff}
+	DefaultText       = color.RGBA{R: 0x1e, G: 0x3a, B: 0x8a, A: 0xff}
+)
+
+// PreviewOptions configures RenderPreview. Zero fields use the defaults.
+type PreviewOptions struct {
+	// MaxWidth scales the canvas down to at most this many pixels wide
+	// (default 800); previews are never scaled up
+	MaxWidth int
+	// Background fills the canvas
+	Background color.Color
+	// Fill fills each placement
+	Fill color.Color
+	// Border outlines each placement
+	Border color.Color
+	// Text colors the labels
+	Text color.Color
+	// HideLabels leaves placements unlabeled
+	HideLabels bool
+}
+
+// RenderPreview draws the placements of a layout preview as labeled
+// rectangles and returns the image as PNG. Placements without a label are
+// labeled with their position, starting at 1.
+//
+// Example:
+//
+//	preview, err := client.GetComposeLayoutPreview(ctx, &allscreenshots.ComposeLayoutPreviewParams{
+//	    Layout:     "GRID",
+//	    ImageCount: 4,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	img, err := compose.RenderPreview(preview, compose.PreviewOptions{MaxWidth: 400})
+func RenderPreview(preview *allscreenshots.LayoutPreviewResponse, opts PreviewOptions) ([]byte, error) {
+	img, err := DrawPreview(preview, opts)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("compose: failed to encode preview: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DrawPreview is RenderPreview without the PNG encoding, for callers that
+// draw the preview into their own image.
+func DrawPreview(preview *allscreenshots.LayoutPreviewResponse, opts PreviewOptions) (*image.RGBA, error) {
+	if preview == nil {
+		return nil, errors.New("compose: preview cannot be nil")
+	}
+	if preview.CanvasWidth <= 0 || preview.CanvasHeight <= 0 {
+		return nil, fmt.Errorf("compose: invalid canvas size %dx%d", preview.CanvasWidth, preview.CanvasHeight)
+	}
+	opts = opts.withDefaults()
+
+	scale := 1.0
+	if preview.CanvasWidth > opts.MaxWidth {
+		scale = float64(opts.MaxWidth) / float64(preview.CanvasWidth)
+	}
+	scaled := func(v int) int { return int(float64(v)*scale + 0.5) }
+
+	img := image.NewRGBA(image.Rect(0, 0, max(1, scaled(preview.CanvasWidth)), max(1, scaled(preview.CanvasHeight))))
+	draw.Draw(img, img.Bounds(), image.NewUniform(opts.Background), image.Point{}, draw.Src)
+
+	for i, p := range preview.Placements {
+		r := image.Rect(scaled(p.X), scaled(p.Y), scaled(p.X+p.Width), scaled(p.Y+p.Height)).Intersect(img.Bounds())
+		if r.Empty() {
+			continue
+		}
+		draw.Draw(img, r, image.NewUniform(opts.Fill), image.Point{}, draw.Src)
+		strokeRect(img, r, opts.Border, 2)
+
+		if !opts.HideLabels {
+			label := p.Label
+			if label == "" {
+				label = fmt.Sprintf("%d", i+1)
+			}
+			drawLabel(img, r, label, opts.Text)
+		}
+	}
+	return img, nil
+}
+
+// withDefaults fills in unset options.
+func (o PreviewOptions) withDefaults() PreviewOptions {
+	if o.MaxWidth <= 0 {
+		o.MaxWidth = DefaultPreviewWidth
+	}
+	if o.Background == nil {
+		o.Background = DefaultBackground
+	}
+	if o.Fill == nil {
+		o.Fill = DefaultFill
+	}
+	if o.Border == nil {
+		o.Border = DefaultBorder
+	}
+	if o.Text == nil {
+		o.Text = DefaultText
+	}
+	return o
+}
+
+// strokeRect outlines r with lines width pixels wide, drawn inside r.
+func strokeRect(img draw.Image, r image.Rectangle, c color.Color, width int) {
+	width = min(width, r.Dx()/2, r.Dy()/2)
+	if width < 1 {
+		width = 1
+	}
+	src := image.NewUniform(c)
+	for _, edge := range []image.Rectangle{
+		image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+width),
+		image.Rect(r.Min.X, r.Max.Y-width, r.Max.X, r.Max.Y),
+		image.Rect(r.Min.X, r.Min.Y, r.Min.X+width, r.Max.Y),
+		image.Rect(r.Max.X-width, r.Min.Y, r.Max.X, r.Max.Y),
+	} {
+		draw.Draw(img, edge.Intersect(r), src, image.Point{}, draw.Src)
+	}
+}
+
+// drawLabel draws text centered in r, as large as fits (up to 4x the
+// glyph size), truncating it if it does not fit at all.
+func drawLabel(img draw.Image, r image.Rectangle, text string, c color.Color) {
+	const padding = 4
+	avail := r.Dx() - 2*padding
+	if avail < glyphAdvance || r.Dy() < glyphHeight+2*padding {
+		return
+	}
+
+	runes := []rune(strings.ToUpper(text))
+	if maxRunes := avail / glyphAdvance; len(runes) > maxRunes {
+		runes = runes[:maxRunes]
+	}
+	scale := min(4, avail/(len(runes)*glyphAdvance), (r.Dy()-2*padding)/glyphHeight)
+	scale = max(scale, 1)
+
+	width := (len(runes)*glyphAdvance - 1) * scale
+	x := r.Min.X + (r.Dx()-width)/2
+	y := r.Min.Y + (r.Dy()-glyphHeight*scale)/2
+	src := image.NewUniform(c)
+	for _, ch := range runes {
+		drawGlyph(img, x, y, scale, glyph(ch), src)
+		x += glyphAdvance * scale
+	}
+}
+
+// drawGlyph draws one glyph with its top-left corner at x, y.
+func drawGlyph(img draw.Image, x, y, scale int, rows [glyphHeight]uint8, src image.Image) {
+	for row, bits := range rows {
+		for col := 0; col < glyphWidth; col++ {
+			if bits&(1<<(glyphWidth-1-col)) == 0 {
+				continue
+			}
+			px := image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale)
+			draw.Draw(img, px, src, image.Point{}, draw.Src)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package compose
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gridPreview() *allscreenshots.LayoutPreviewResponse {
+	return &allscreenshots.LayoutPreviewResponse{
+		Layout:       "GRID",
+		CanvasWidth:  1600,
+		CanvasHeight: 800,
+		Placements: []allscreenshots.PlacementPreview{
+			{Index: 0, X: 0, Y: 0, Width: 800, Height: 800, Label: "Desktop"},
+			{Index: 1, X: 800, Y: 0, Width: 800, Height: 800},
+		},
+	}
+}
+
+func TestRenderPreview(t *testing.T) {
+	data, err := RenderPreview(gridPreview(), PreviewOptions{})
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 800, 400), img.Bounds())
+
+	// Placement borders and fills are drawn at scaled coordinates.
+	assert.Equal(t, color.RGBAModel.Convert(DefaultBorder), color.RGBAModel.Convert(img.At(0, 200)))
+	assert.Equal(t, color.RGBAModel.Convert(DefaultFill), color.RGBAModel.Convert(img.At(20, 20)))
+	assert.Equal(t, color.RGBAModel.Convert(DefaultBorder), color.RGBAModel.Convert(img.At(401, 200)))
+}
+
+func TestDrawPreview_Labels(t *testing.T) {
+	countText := func(img *image.RGBA, r image.Rectangle) int {
+		n := 0
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				if img.RGBAAt(x, y) == DefaultText {
+					n++
+				}
+			}
+		}
+		return n
+	}
+
+	img, err := DrawPreview(gridPreview(), PreviewOptions{})
+	require.NoError(t, err)
+	assert.Positive(t, countText(img, image.Rect(0, 0, 400, 400)))
+	assert.Positive(t, countText(img, image.Rect(400, 0, 800, 400)), "unlabeled placements are numbered")
+
+	img, err = DrawPreview(gridPreview(), PreviewOptions{HideLabels: true})
+	require.NoError(t, err)
+	assert.Zero(t, countText(img, img.Bounds()))
+}
+
+func TestDrawPreview_NoUpscaling(t *testing.T) {
+	img, err := DrawPreview(gridPreview(), PreviewOptions{MaxWidth: 4000})
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 1600, 800), img.Bounds())
+}
+
+func TestDrawPreview_Errors(t *testing.T) {
+	_, err := DrawPreview(nil, PreviewOptions{})
+	assert.ErrorContains(t, err, "preview cannot be nil")
+
+	_, err = DrawPreview(&allscreenshots.LayoutPreviewResponse{}, PreviewOptions{})
+	assert.ErrorContains(t, err, "invalid canvas size 0x0")
+}
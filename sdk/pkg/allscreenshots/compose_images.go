@@ -0,0 +1,86 @@
+package allscreenshots
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// encodedBody is a request body that is sent as is rather than as JSON.
+type encodedBody struct {
+	contentType string
+	data        []byte
+}
+
+// ComposeImages composes local images, such as logos, annotations, or
+// previously stored captures, into one image. To mix local images with
+// URL captures, set ComposeRequest.Images and call Compose instead.
+//
+// Example:
+//
+//	logo, _ := os.ReadFile("logo.png")
+//	shot, _ := os.ReadFile("homepage.png")
+//	result, err := client.ComposeImages(ctx, []allscreenshots.ImageInput{
+//	    {Name: "logo.png", Data: logo},
+//	    {Name: "homepage.png", Data: shot},
+//	}, &allscreenshots.ComposeOutputConfig{Layout: "HORIZONTAL"})
+func (c *Client) ComposeImages(ctx context.Context, images []ImageInput, output *ComposeOutputConfig) (*ComposeResponse, error) {
+	if len(images) == 0 {
+		return nil, &ValidationError{Field: "images", Message: "at least one image is required"}
+	}
+	return c.Compose(ctx, &ComposeRequest{Images: images, Output: output})
+}
+
+// composeBody returns the body of a compose request: the request itself, or
+// a multipart form with the request as its "request" part and one "images"
+// part per local image.
+func composeBody(req *ComposeRequest) (interface{}, error) {
+	if len(req.Images) == 0 {
+		return req, nil
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="request"`)
+	header.Set("Content-Type", "application/json")
+	part, err := mw.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to encode compose request: %w", err)
+	}
+	if err := json.NewEncoder(part).Encode(req); err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to marshal request body: %w", err)
+	}
+
+	for _, img := range req.Images {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", multipartFileDisposition("images", img.Name))
+		header.Set("Content-Type", http.DetectContentType(img.Data))
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("allscreenshots: failed to encode image %s: %w", img.Name, err)
+		}
+		if _, err := part.Write(img.Data); err != nil {
+			return nil, fmt.Errorf("allscreenshots: failed to encode image %s: %w", img.Name, err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to encode compose request: %w", err)
+	}
+
+	return &encodedBody{contentType: mw.FormDataContentType(), data: buf.Bytes()}, nil
+}
+
+// quoteEscaper escapes quoted form field values as mime/multipart does.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// multipartFileDisposition returns the Content-Disposition of a file part.
+func multipartFileDisposition(field, filename string) string {
+	return fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(field), quoteEscaper.Replace(filename))
+}
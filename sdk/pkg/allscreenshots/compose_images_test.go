@@ -0,0 +1,84 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ComposeImages(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	jpeg := []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots/compose", r.URL.Path)
+		reader, err := r.MultipartReader()
+		require.NoError(t, err)
+
+		part, err := reader.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "request", part.FormName())
+		var req ComposeRequest
+		require.NoError(t, json.NewDecoder(part).Decode(&req))
+		assert.Equal(t, "HORIZONTAL", req.Output.Layout)
+		require.Len(t, req.Captures, 1)
+
+		var names, types []string
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "images", part.FormName())
+			names = append(names, part.FileName())
+			types = append(types, part.Header.Get("Content-Type"))
+		}
+		assert.Equal(t, []string{"logo.png", "shot.jpg"}, names)
+		assert.Equal(t, []string{"image/png", "image/jpeg"}, types)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ComposeResponse{URL: "https://cdn.example.com/compose.png"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	result, err := client.Compose(context.Background(), &ComposeRequest{
+		Captures: []CaptureItem{{URL: "https://example.com"}},
+		Images:   []ImageInput{{Name: "logo.png", Data: png}, {Name: "shot.jpg", Data: jpeg}},
+		Output:   &ComposeOutputConfig{Layout: "HORIZONTAL"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/compose.png", result.URL)
+}
+
+func TestClient_ComposeImages_Validation(t *testing.T) {
+	client := NewClient(WithAPIKey("test-api-key"))
+
+	tests := []struct {
+		name    string
+		images  []ImageInput
+		wantErr string
+	}{
+		{name: "no images", images: nil, wantErr: "at least one image is required"},
+		{name: "missing name", images: []ImageInput{{Data: []byte{1}}}, wantErr: "name is required"},
+		{name: "missing data", images: []ImageInput{{Name: "a.png"}}, wantErr: "image data is required"},
+		{name: "too many", images: make([]ImageInput, 21), wantErr: "maximum 20 captures and images allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.ComposeImages(context.Background(), tt.images, nil)
+			require.Error(t, err)
+			assert.True(t, IsValidationError(err))
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
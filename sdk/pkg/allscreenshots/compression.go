@@ -0,0 +1,50 @@
+package allscreenshots
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"sync/atomic"
+)
+
+// minCompressSize is the smallest request body WithRequestCompression
+// compresses; below it gzip's overhead outweighs the savings.
+const minCompressSize = 1024
+
+// WithRequestCompression gzips JSON request bodies of 1 KB or more sent to
+// the API (Content-Encoding: gzip), which shrinks bulk and compose requests
+// with many URLs or long CustomCSS considerably. If the API answers a
+// compressed request with 415 Unsupported Media Type, the request is resent
+// uncompressed and compression stays off for the client from then on.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(allscreenshots.WithRequestCompression())
+func WithRequestCompression() ClientOption {
+	return func(c *Client) {
+		c.compression = &requestCompression{}
+	}
+}
+
+// requestCompression holds the request compression state of a client.
+type requestCompression struct {
+	// rejected is set once the API has refused a compressed body
+	rejected atomic.Bool
+}
+
+// compressBody returns data gzipped, or nil if it should be sent as is.
+func (c *Client) compressBody(data []byte) ([]byte, error) {
+	if c.compression == nil || c.compression.rejected.Load() || len(data) < minCompressSize {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to compress request body: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to compress request body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,71 @@
+package allscreenshots
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithRequestCompression(t *testing.T) {
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body = zr
+		}
+		var req ScreenshotRequest
+		require.NoError(t, json.NewDecoder(body).Decode(&req))
+		assert.Equal(t, "https://example.com", req.URL)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AsyncJobCreatedResponse{ID: "job-1"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRequestCompression())
+
+	_, err := client.ScreenshotAsync(context.Background(), &ScreenshotRequest{URL: "https://example.com"})
+	require.NoError(t, err)
+	_, err = client.ScreenshotAsync(context.Background(), &ScreenshotRequest{
+		URL:       "https://example.com",
+		CustomCSS: strings.Repeat("body { margin: 0 } ", 100),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"", "gzip"}, encodings)
+}
+
+func TestClient_WithRequestCompression_Rejected(t *testing.T) {
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		if r.Header.Get("Content-Encoding") != "" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(AsyncJobCreatedResponse{ID: "job-1"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithRequestCompression(), WithMaxRetries(0))
+	req := &ScreenshotRequest{URL: "https://example.com", CustomCSS: strings.Repeat("body { margin: 0 } ", 100)}
+
+	_, err := client.ScreenshotAsync(context.Background(), req)
+	require.NoError(t, err)
+	_, err = client.ScreenshotAsync(context.Background(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"gzip", "", ""}, encodings)
+}
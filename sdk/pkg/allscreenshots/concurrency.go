@@ -0,0 +1,156 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ConcurrencyLimitAuto makes WithConcurrencyLimit use the concurrent render
+// limit of the account's plan, as reported by GetAccount.
+const ConcurrencyLimitAuto = 0
+
+// concurrencyRetryInterval is how long the client waits before asking for
+// the account's limit again after GetAccount failed.
+const concurrencyRetryInterval = time.Minute
+
+// AccountResponse represents the account an API key belongs to.
+type AccountResponse struct {
+	ID   string `json:"id"`
+	Tier string `json:"tier"`
+	// ConcurrencyLimit is how many renders the plan runs at once; requests
+	// beyond it are refused with 429. 0 means the plan has no such limit.
+	ConcurrencyLimit int `json:"concurrencyLimit"`
+}
+
+// GetAccount returns the account the API key belongs to and its plan limits.
+//
+// Example:
+//
+//	account, err := client.GetAccount(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%s plan, %d concurrent renders\n", account.Tier, account.ConcurrencyLimit)
+func (c *Client) GetAccount(ctx context.Context) (*AccountResponse, error) {
+	var result AccountResponse
+	err := c.request(ctx, http.MethodGet, c.endpoint("/account"), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WithConcurrencyLimit limits how many synchronous renders (Screenshot and
+// its variants, Compose, and CaptureHTML) the client has in flight at once;
+// further calls wait for a slot or until their context is done. This keeps
+// bursts under the plan's concurrent render limit, which the API otherwise
+// enforces with 429 responses.
+//
+// With ConcurrencyLimitAuto (or any n <= 0), the limit is fetched with
+// GetAccount before the first render. If that fails, renders proceed
+// unlimited and the limit is fetched again a minute later.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(
+//	    allscreenshots.WithConcurrencyLimit(allscreenshots.ConcurrencyLimitAuto),
+//	)
+func WithConcurrencyLimit(n int) ClientOption {
+	return func(c *Client) {
+		l := &concurrencyLimiter{}
+		if n > 0 {
+			l.sem = make(chan struct{}, n)
+			l.resolved = true
+		}
+		c.concurrency = l
+	}
+}
+
+// concurrencyLimiter holds the render slots of WithConcurrencyLimit.
+type concurrencyLimiter struct {
+	mu sync.Mutex
+	// sem has a buffer slot per concurrent render; nil means unlimited
+	sem      chan struct{}
+	resolved bool
+	retryAt  time.Time
+	// fetching is closed when the GetAccount call in progress returns
+	fetching chan struct{}
+}
+
+// acquireRender waits for a render slot. The returned release must be
+// called once the render's response has been read. Without a limit it does
+// nothing.
+func (c *Client) acquireRender(ctx context.Context) (release func(), err error) {
+	l := c.concurrency
+	if l == nil {
+		return func() {}, nil
+	}
+	sem, err := l.semaphore(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if sem == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, timeoutError(ctx.Err())
+	}
+}
+
+// semaphore returns the render slots, fetching the account's limit first if
+// it is not known yet. Only one call fetches the limit at a time; others
+// wait for it, or until their context is done.
+func (l *concurrencyLimiter) semaphore(ctx context.Context, c *Client) (chan struct{}, error) {
+	for {
+		l.mu.Lock()
+		if l.resolved || time.Now().Before(l.retryAt) {
+			sem := l.sem
+			l.mu.Unlock()
+			return sem, nil
+		}
+		fetching := l.fetching
+		if fetching == nil {
+			fetching = make(chan struct{})
+			l.fetching = fetching
+			l.mu.Unlock()
+			return l.fetchLimit(ctx, c, fetching)
+		}
+		l.mu.Unlock()
+
+		select {
+		case <-fetching:
+		case <-ctx.Done():
+			return nil, timeoutError(ctx.Err())
+		}
+	}
+}
+
+// fetchLimit asks for the account's limit without holding l.mu, installs
+// the render slots, and closes done to wake the calls waiting for them. A
+// fetch cut short by ctx is not recorded, so a waiting call tries again.
+func (l *concurrencyLimiter) fetchLimit(ctx context.Context, c *Client, done chan struct{}) (chan struct{}, error) {
+	account, err := c.GetAccount(ctx)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fetching = nil
+	close(done)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, timeoutError(ctx.Err())
+		}
+		l.retryAt = time.Now().Add(concurrencyRetryInterval)
+		return nil, nil
+	}
+	l.resolved = true
+	if account.ConcurrencyLimit > 0 {
+		l.sem = make(chan struct{}, account.ConcurrencyLimit)
+	}
+	return l.sem, nil
+}
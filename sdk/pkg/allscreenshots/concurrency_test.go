@@ -0,0 +1,143 @@
+package allscreenshots
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// renderServer serves /v1/account with limit and counts concurrent renders.
+type renderServer struct {
+	*httptest.Server
+	accountCalls atomic.Int32
+	inFlight     atomic.Int32
+	maxInFlight  atomic.Int32
+}
+
+func newRenderServer(t *testing.T, limit int, hold time.Duration) *renderServer {
+	s := &renderServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/account" {
+			s.accountCalls.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"acct-1","tier":"pro","concurrencyLimit":` + strconv.Itoa(limit) + `}`))
+			return
+		}
+		n := s.inFlight.Add(1)
+		for {
+			m := s.maxInFlight.Load()
+			if n <= m || s.maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(hold)
+		s.inFlight.Add(-1)
+		w.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func captureConcurrently(t *testing.T, client *Client, n int) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Screenshot(context.Background(), &ScreenshotRequest{URL: "https://example.com"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClient_WithConcurrencyLimit(t *testing.T) {
+	server := newRenderServer(t, 0, 20*time.Millisecond)
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithConcurrencyLimit(2))
+
+	captureConcurrently(t, client, 6)
+	assert.Equal(t, int32(2), server.maxInFlight.Load())
+	assert.Zero(t, server.accountCalls.Load())
+}
+
+func TestClient_WithConcurrencyLimitAuto(t *testing.T) {
+	server := newRenderServer(t, 1, 10*time.Millisecond)
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithConcurrencyLimit(ConcurrencyLimitAuto))
+
+	captureConcurrently(t, client, 4)
+	assert.Equal(t, int32(1), server.maxInFlight.Load())
+	assert.Equal(t, int32(1), server.accountCalls.Load())
+
+	account, err := client.GetAccount(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &AccountResponse{ID: "acct-1", Tier: "pro", ConcurrencyLimit: 1}, account)
+}
+
+func TestClient_ConcurrencyLimitContext(t *testing.T) {
+	server := newRenderServer(t, 0, 200*time.Millisecond)
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithConcurrencyLimit(1))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.Screenshot(context.Background(), &ScreenshotRequest{URL: "https://example.com"})
+	}()
+	for server.inFlight.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.Screenshot(ctx, &ScreenshotRequest{URL: "https://example.com"})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	<-done
+}
+
+func TestClient_ConcurrencyLimitAutoContext(t *testing.T) {
+	release := make(chan struct{})
+	var accountCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/account" {
+			accountCalls.Add(1)
+			<-release
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"acct-1","tier":"pro","concurrencyLimit":1}`))
+			return
+		}
+		w.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+	}))
+	defer server.Close()
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithConcurrencyLimit(ConcurrencyLimitAuto))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Screenshot(context.Background(), &ScreenshotRequest{URL: "https://example.com"})
+		done <- err
+	}()
+	for accountCalls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// A call waiting for the limit gives up when its context is done,
+	// without waiting for the account lookup to return.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.Screenshot(ctx, &ScreenshotRequest{URL: "https://example.com"})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, IsTimeoutError(err))
+
+	close(release)
+	require.NoError(t, <-done)
+	_, err = client.Screenshot(context.Background(), &ScreenshotRequest{URL: "https://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), accountCalls.Load())
+}
@@ -0,0 +1,255 @@
+package allscreenshots
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EnvConfigFile is the environment variable overriding the configuration file path.
+	EnvConfigFile = "ALLSCREENSHOTS_CONFIG"
+	// EnvProfile is the environment variable selecting the configuration profile.
+	EnvProfile = "ALLSCREENSHOTS_PROFILE"
+	// DefaultProfile is the profile used when none is specified.
+	DefaultProfile = "default"
+)
+
+// Config represents the contents of a configuration file.
+//
+// Example config.yaml:
+//
+//	default_profile: production
+//	profiles:
+//	  production:
+//	    api_key_env: ALLSCREENSHOTS_PROD_KEY
+//	    timeout: 60s
+//	    default_device: Desktop HD
+//	  staging:
+//	    api_key_file: ~/.secrets/allscreenshots-staging
+//	    base_url: https://staging.api.allscreenshots.com
+type Config struct {
+	// DefaultProfile is used when no profile is requested explicitly
+	DefaultProfile string `yaml:"default_profile"`
+	// Profiles by name
+	Profiles map[string]*Profile `yaml:"profiles"`
+}
+
+// Profile represents a named set of client settings.
+type Profile struct {
+	// APIKey is the API key itself; prefer APIKeyEnv or APIKeyFile
+	APIKey string `yaml:"api_key"`
+	// APIKeyEnv names an environment variable holding the API key
+	APIKeyEnv string `yaml:"api_key_env"`
+	// APIKeyFile is a path to a file containing the API key
+	APIKeyFile string `yaml:"api_key_file"`
+	// BaseURL overrides the API base URL
+	BaseURL string `yaml:"base_url"`
+	// Timeout is the HTTP client timeout, e.g. "60s"
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxRetries is the maximum number of retry attempts
+	MaxRetries *int `yaml:"max_retries"`
+	// RetryWaitMin is the minimum wait between retries, e.g. "1s"
+	RetryWaitMin time.Duration `yaml:"retry_wait_min"`
+	// RetryWaitMax is the maximum wait between retries, e.g. "30s"
+	RetryWaitMax time.Duration `yaml:"retry_wait_max"`
+	// DefaultDevice is applied to requests that set neither Device nor Viewport
+	DefaultDevice string `yaml:"default_device"`
+	// DefaultFormat is applied to requests that do not set Format
+	DefaultFormat string `yaml:"default_format"`
+	// Project scopes every request to a project
+	Project string `yaml:"project"`
+}
+
+// DefaultConfigPath returns the configuration file path used when none is
+// given: $ALLSCREENSHOTS_CONFIG if set, otherwise ~/.config/allscreenshots/config.yaml.
+func DefaultConfigPath() (string, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("allscreenshots: cannot determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "allscreenshots", "config.yaml"), nil
+}
+
+// LoadConfig reads and parses a configuration file. An empty path means
+// DefaultConfigPath.
+func LoadConfig(path string) (*Config, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultConfigPath(); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(expandHome(path))
+	if err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to read config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to parse config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Profile returns the named profile. An empty name selects $ALLSCREENSHOTS_PROFILE,
+// then the file's default_profile, then "default".
+func (c *Config) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = os.Getenv(EnvProfile)
+	}
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	p, ok := c.Profiles[name]
+	if !ok || p == nil {
+		names := make([]string, 0, len(c.Profiles))
+		for n := range c.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, &ValidationError{Field: "profile", Message: fmt.Sprintf("profile %q not found (available: %s)", name, strings.Join(names, ", "))}
+	}
+	return p, nil
+}
+
+// ResolveAPIKey returns the profile's API key from APIKey, APIKeyEnv, or
+// APIKeyFile, in that order. It returns an empty string when none is set.
+func (p *Profile) ResolveAPIKey() (string, error) {
+	switch {
+	case p.APIKey != "":
+		return p.APIKey, nil
+	case p.APIKeyEnv != "":
+		key := os.Getenv(p.APIKeyEnv)
+		if key == "" {
+			return "", &ValidationError{Field: "api_key_env", Message: fmt.Sprintf("environment variable %s is not set", p.APIKeyEnv)}
+		}
+		return key, nil
+	case p.APIKeyFile != "":
+		data, err := os.ReadFile(expandHome(p.APIKeyFile))
+		if err != nil {
+			return "", fmt.Errorf("allscreenshots: failed to read api_key_file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return "", nil
+}
+
+// ClientOptions converts the profile into client options.
+func (p *Profile) ClientOptions() ([]ClientOption, error) {
+	var opts []ClientOption
+
+	key, err := p.ResolveAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		opts = append(opts, WithAPIKey(key))
+	}
+	if p.BaseURL != "" {
+		opts = append(opts, WithBaseURL(p.BaseURL))
+	}
+	if p.Timeout < 0 {
+		return nil, &ValidationError{Field: "timeout", Message: "timeout must not be negative"}
+	}
+	if p.Timeout > 0 {
+		opts = append(opts, WithTimeout(p.Timeout))
+	}
+	if p.MaxRetries != nil {
+		if *p.MaxRetries < 0 {
+			return nil, &ValidationError{Field: "max_retries", Message: "max_retries must not be negative"}
+		}
+		opts = append(opts, WithMaxRetries(*p.MaxRetries))
+	}
+	if p.RetryWaitMin > 0 || p.RetryWaitMax > 0 {
+		min, max := p.RetryWaitMin, p.RetryWaitMax
+		if min == 0 {
+			min = DefaultRetryWaitMin
+		}
+		if max == 0 {
+			max = DefaultRetryWaitMax
+		}
+		if min > max {
+			return nil, &ValidationError{Field: "retry_wait_min", Message: "retry_wait_min must not exceed retry_wait_max"}
+		}
+		opts = append(opts, WithRetryWait(min, max))
+	}
+	if p.DefaultDevice != "" {
+		opts = append(opts, WithDefaultDevice(p.DefaultDevice))
+	}
+	if p.DefaultFormat != "" {
+		opts = append(opts, WithDefaultFormat(p.DefaultFormat))
+	}
+	if p.Project != "" {
+		opts = append(opts, WithProject(p.Project))
+	}
+	return opts, nil
+}
+
+// NewClientFromConfig creates a client from a profile in a configuration file.
+//
+// An empty path uses DefaultConfigPath and an empty profile follows the
+// selection rules of Config.Profile. Any opts are applied after the profile,
+// so they override file settings. As with NewClient, the API key falls back
+// to ALLSCREENSHOTS_API_KEY when the profile does not provide one.
+//
+// Example:
+//
+//	client, err := allscreenshots.NewClientFromConfig("", "staging")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func NewClientFromConfig(path, profile string, opts ...ClientOption) (*Client, error) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := cfg.Profile(profile)
+	if err != nil {
+		return nil, err
+	}
+	profileOpts, err := p.ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(append(profileOpts, opts...)...), nil
+}
+
+// ConfigExists reports whether a configuration file exists at path (or the
+// default path when path is empty).
+func ConfigExists(path string) bool {
+	if path == "" {
+		var err error
+		if path, err = DefaultConfigPath(); err != nil {
+			return false
+		}
+	}
+	_, err := os.Stat(expandHome(path))
+	return !errors.Is(err, os.ErrNotExist)
+}
+
+// expandHome replaces a leading "~/" with the user's home directory.
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
@@ -0,0 +1,88 @@
+package allscreenshots
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func TestNewClientFromConfig(t *testing.T) {
+	t.Setenv(EnvProfile, "")
+	t.Setenv("TEST_ALLSCREENSHOTS_KEY", "key-from-env")
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("key-from-file\n"), 0o600))
+
+	path := writeConfig(t, `
+default_profile: production
+profiles:
+  production:
+    api_key_env: TEST_ALLSCREENSHOTS_KEY
+    timeout: 45s
+    max_retries: 1
+    default_device: iPhone 14
+    default_format: webp
+    project: prj-acme
+  staging:
+    api_key_file: `+keyFile+`
+    base_url: https://staging.example.com/
+    retry_wait_min: 10ms
+    retry_wait_max: 100ms
+`)
+
+	t.Run("uses default profile", func(t *testing.T) {
+		client, err := NewClientFromConfig(path, "")
+		require.NoError(t, err)
+		assert.Equal(t, "key-from-env", client.apiKey)
+		assert.Equal(t, 45*time.Second, client.httpClient.Timeout)
+		assert.Equal(t, 1, client.maxRetries)
+		assert.Equal(t, "iPhone 14", client.defaults.Device)
+		assert.Equal(t, "webp", client.defaults.Format)
+		assert.Equal(t, "prj-acme", client.project)
+	})
+
+	t.Run("selects named profile", func(t *testing.T) {
+		client, err := NewClientFromConfig(path, "staging")
+		require.NoError(t, err)
+		assert.Equal(t, "key-from-file", client.apiKey)
+		assert.Equal(t, "https://staging.example.com", client.baseURL)
+		assert.Equal(t, 10*time.Millisecond, client.retryWaitMin)
+		assert.Equal(t, 100*time.Millisecond, client.retryWaitMax)
+	})
+
+	t.Run("options override the file", func(t *testing.T) {
+		client, err := NewClientFromConfig(path, "production", WithAPIKey("explicit"))
+		require.NoError(t, err)
+		assert.Equal(t, "explicit", client.apiKey)
+	})
+
+	t.Run("selects profile from environment", func(t *testing.T) {
+		t.Setenv(EnvProfile, "staging")
+		client, err := NewClientFromConfig(path, "")
+		require.NoError(t, err)
+		assert.Equal(t, "key-from-file", client.apiKey)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := NewClientFromConfig(path, "missing")
+		require.Error(t, err)
+		assert.True(t, IsValidationError(err))
+		assert.Contains(t, err.Error(), "production, staging")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewClientFromConfig(filepath.Join(t.TempDir(), "nope.yaml"), "")
+		require.Error(t, err)
+	})
+}
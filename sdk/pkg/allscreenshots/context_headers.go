@@ -0,0 +1,44 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+)
+
+// ContextHeaderMapper returns the headers to send with an API request made
+// with ctx.
+type ContextHeaderMapper func(ctx context.Context) map[string]string
+
+// WithContextHeaderMapper forwards request-scoped values, such as trace or
+// tenant IDs, as headers on every API call. fn is called with the context
+// of each request attempt; empty names and values are skipped. Headers the
+// SDK sets itself, such as X-API-Key and Content-Type, take precedence, and
+// nothing is sent to hosts other than the API, such as signed result URLs.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(
+//	    allscreenshots.WithContextHeaderMapper(func(ctx context.Context) map[string]string {
+//	        return map[string]string{
+//	            "X-Request-ID": requestid.FromContext(ctx),
+//	            "X-Tenant-ID":  tenant.FromContext(ctx),
+//	        }
+//	    }),
+//	)
+func WithContextHeaderMapper(fn ContextHeaderMapper) ClientOption {
+	return func(c *Client) {
+		c.contextHeaders = fn
+	}
+}
+
+// setContextHeaders sets the headers mapped from ctx on h.
+func (c *Client) setContextHeaders(ctx context.Context, h http.Header) {
+	if c.contextHeaders == nil {
+		return
+	}
+	for name, value := range c.contextHeaders(ctx) {
+		if name != "" && value != "" {
+			h.Set(name, value)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package allscreenshots
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type traceIDKey struct{}
+
+func TestClient_WithContextHeaderMapper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/usage/quota":
+			assert.Equal(t, "trace-123", r.Header.Get("X-Trace-ID"))
+			assert.Equal(t, "test-api-key", r.Header.Get("X-API-Key"))
+			assert.NotContains(t, r.Header, "X-Empty")
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"tier":"pro"}`))
+		case "/results/shot.png":
+			assert.Empty(t, r.Header.Get("X-Trace-ID"))
+			w.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+		WithContextHeaderMapper(func(ctx context.Context) map[string]string {
+			id, _ := ctx.Value(traceIDKey{}).(string)
+			return map[string]string{
+				"X-Trace-ID": id,
+				"X-API-Key":  "overridden",
+				"X-Empty":    "",
+			}
+		}),
+	)
+
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "trace-123")
+	_, err := client.GetQuotaStatus(ctx)
+	require.NoError(t, err)
+
+	other := httptest.NewServer(server.Config.Handler)
+	defer other.Close()
+	_, err = client.DownloadResult(ctx, other.URL+"/results/shot.png", io.Discard)
+	require.NoError(t, err)
+}
@@ -0,0 +1,432 @@
+// Package crawler archives a website: it discovers the pages linked from a
+// root URL, captures them with bulk jobs, and writes the screenshots and an
+// index.json manifest to a ResultStore.
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+const (
+	// DefaultMaxDepth is how many links away from the root URL pages are
+	// discovered by default.
+	DefaultMaxDepth = 2
+	// DefaultMaxPages is the number of pages captured by default.
+	DefaultMaxPages = 100
+	// ManifestName is the name of the manifest written next to the results.
+	ManifestName = "index.json"
+
+	// chunkSize is the maximum number of URLs in one bulk job.
+	chunkSize = 100
+	// maxPageSize bounds how much of a page is read when looking for links.
+	maxPageSize = 5 << 20
+)
+
+// Page statuses recorded in the manifest.
+const (
+	// StatusCaptured means the page's screenshot was stored
+	StatusCaptured = "captured"
+	// StatusFailed means the page could not be captured or stored
+	StatusFailed = "failed"
+)
+
+// CrawlOptions configures CaptureSite.
+type CrawlOptions struct {
+	// MaxDepth is how many links away from the root URL pages are
+	// discovered (default 2); the root is at depth 0
+	MaxDepth int
+	// MaxPages caps the number of pages captured (default 100)
+	MaxPages int
+	// SameHostOnly restricts the crawl to pages on the root URL's host.
+	// Otherwise pages on other hosts are captured too, but their links are
+	// not followed.
+	SameHostOnly bool
+	// Device preset name used for every capture (e.g., "Desktop HD")
+	Device string
+	// Defaults are applied to every capture; Device overrides Defaults.Device
+	Defaults *allscreenshots.BulkDefaults
+	// Store receives the screenshots and the manifest (required)
+	Store allscreenshots.ResultStore
+	// Prefix is the key prefix results are stored under (default the root
+	// URL's host)
+	Prefix string
+	// HTTPClient fetches pages during discovery (default a client with a
+	// 30 second timeout)
+	HTTPClient *http.Client
+	// PollOptions configure how bulk jobs are polled
+	PollOptions []allscreenshots.PollOption
+}
+
+// Manifest describes an archived site. It is stored as index.json under
+// the crawl's prefix.
+type Manifest struct {
+	// RootURL the crawl started from
+	RootURL string `json:"rootUrl"`
+	// Device used for the captures, if any
+	Device string `json:"device,omitempty"`
+	// Pages in the order they were discovered
+	Pages []Page `json:"pages"`
+	// BulkJobIDs are the bulk jobs that captured the pages
+	BulkJobIDs []string `json:"bulkJobIds,omitempty"`
+	// StartedAt is when the crawl started
+	StartedAt time.Time `json:"startedAt"`
+	// CompletedAt is when the manifest was written
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+// Page is one captured page in a Manifest.
+type Page struct {
+	// URL of the page
+	URL string `json:"url"`
+	// Depth is the number of links between the root URL and the page
+	Depth int `json:"depth"`
+	// Status is StatusCaptured or StatusFailed
+	Status string `json:"status"`
+	// Key the screenshot is stored under, when captured
+	Key string `json:"key,omitempty"`
+	// JobID of the job that captured the page
+	JobID string `json:"jobId,omitempty"`
+	// Error describes why the page failed
+	Error string `json:"error,omitempty"`
+}
+
+// Captured returns the number of pages whose screenshot was stored.
+func (m *Manifest) Captured() int {
+	n := 0
+	for _, p := range m.Pages {
+		if p.Status == StatusCaptured {
+			n++
+		}
+	}
+	return n
+}
+
+// CaptureSite archives the site at rootURL: it follows links breadth-first
+// up to opts.MaxDepth, deduplicates the URLs it finds, captures up to
+// opts.MaxPages of them in bulk jobs of at most 100 URLs, and stores each
+// screenshot under "<prefix>/<n>-<path><ext>" with the manifest at
+// "<prefix>/index.json". Pages that fail to capture are recorded in the
+// manifest rather than failing the crawl; the returned error reports
+// problems that stopped the crawl, such as a bulk job that could not be
+// created.
+//
+// Example:
+//
+//	store, _ := resultstore.NewFS("./archive")
+//	manifest, err := crawler.CaptureSite(ctx, client, "https://example.com", crawler.CrawlOptions{
+//	    MaxDepth:     2,
+//	    MaxPages:     50,
+//	    SameHostOnly: true,
+//	    Device:       "Desktop HD",
+//	    Store:        store,
+//	})
+//	fmt.Printf("archived %d of %d pages\n", manifest.Captured(), len(manifest.Pages))
+func CaptureSite(ctx context.Context, client *allscreenshots.Client, rootURL string, opts CrawlOptions) (*Manifest, error) {
+	if client == nil {
+		return nil, errors.New("crawler: client is required")
+	}
+	if opts.Store == nil {
+		return nil, errors.New("crawler: store is required")
+	}
+	root, err := normalizeURL(rootURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("crawler: invalid root URL: %w", err)
+	}
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = DefaultMaxDepth
+	}
+	if opts.MaxPages <= 0 {
+		opts.MaxPages = DefaultMaxPages
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	prefix := strings.Trim(opts.Prefix, "/")
+	if prefix == "" {
+		prefix = root.Host
+	}
+
+	manifest := &Manifest{RootURL: root.String(), Device: opts.Device, StartedAt: time.Now()}
+	pages, err := discover(ctx, opts, root)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Pages = pages
+
+	for start := 0; start < len(pages); start += chunkSize {
+		end := min(start+chunkSize, len(pages))
+		bulkID, err := capture(ctx, client, opts, prefix, pages[start:end], start)
+		if bulkID != "" {
+			manifest.BulkJobIDs = append(manifest.BulkJobIDs, bulkID)
+		}
+		if err != nil {
+			return manifest, err
+		}
+	}
+
+	manifest.CompletedAt = time.Now()
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, err
+	}
+	meta := allscreenshots.ResultMeta{ContentType: "application/json", SourceURL: manifest.RootURL, CreatedAt: manifest.CompletedAt}
+	if err := opts.Store.Put(ctx, prefix+"/"+ManifestName, data, meta); err != nil {
+		return manifest, fmt.Errorf("crawler: writing manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// discover walks the site breadth-first from root and returns the pages to
+// capture. Pages that cannot be fetched are still captured; only their
+// links are lost.
+func discover(ctx context.Context, opts CrawlOptions, root *url.URL) ([]Page, error) {
+	seen := map[string]bool{root.String(): true}
+	pages := []Page{{URL: root.String()}}
+	for i := 0; i < len(pages) && len(pages) < opts.MaxPages; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		page := pages[i]
+		if page.Depth >= opts.MaxDepth {
+			break
+		}
+		base, _ := url.Parse(page.URL)
+		if base.Host != root.Host {
+			continue
+		}
+		links, err := fetchLinks(ctx, opts.HTTPClient, base)
+		if err != nil {
+			continue
+		}
+		for _, link := range links {
+			if seen[link.String()] || (opts.SameHostOnly && link.Host != root.Host) {
+				continue
+			}
+			seen[link.String()] = true
+			pages = append(pages, Page{URL: link.String(), Depth: page.Depth + 1})
+			if len(pages) == opts.MaxPages {
+				break
+			}
+		}
+	}
+	return pages, nil
+}
+
+// capture takes the screenshots of pages in one bulk job and stores them,
+// recording the outcome on each page. offset numbers the keys.
+func capture(ctx context.Context, client *allscreenshots.Client, opts CrawlOptions, prefix string, pages []Page, offset int) (string, error) {
+	var defaults allscreenshots.BulkDefaults
+	if opts.Defaults != nil {
+		defaults = *opts.Defaults
+	}
+	if opts.Device != "" {
+		defaults.Device = opts.Device
+	}
+
+	req := &allscreenshots.BulkRequest{Defaults: &defaults}
+	byURL := make(map[string]int, len(pages))
+	for i, p := range pages {
+		req.URLs = append(req.URLs, allscreenshots.BulkURLRequest{URL: p.URL})
+		byURL[p.URL] = i
+	}
+	bulk, err := client.CreateBulkJob(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("crawler: creating bulk job: %w", err)
+	}
+
+	it := client.IterateBulkResults(ctx, bulk.ID, opts.PollOptions...)
+	for it.Next() {
+		job := it.Job()
+		i, ok := byURL[job.URL]
+		if !ok {
+			continue
+		}
+		page := &pages[i]
+		page.JobID = job.ID
+		if job.Status != string(allscreenshots.JobStatusCompleted) {
+			page.Status = StatusFailed
+			page.Error = job.ErrorMessage
+			if page.Error == "" {
+				page.Error = "job " + job.Status
+			}
+			continue
+		}
+
+		data := it.Data()
+		contentType := http.DetectContentType(data)
+		key := fmt.Sprintf("%s/%04d-%s%s", prefix, offset+i, slug(page.URL), allscreenshots.ResultExtension(contentType))
+		meta := allscreenshots.ResultMeta{ContentType: contentType, SourceURL: page.URL, JobID: job.ID, CreatedAt: time.Now()}
+		if err := opts.Store.Put(ctx, key, data, meta); err != nil {
+			page.Status = StatusFailed
+			page.Error = err.Error()
+			continue
+		}
+		page.Status = StatusCaptured
+		page.Key = key
+	}
+	if err := it.Err(); err != nil {
+		return bulk.ID, fmt.Errorf("crawler: bulk job %s: %w", bulk.ID, err)
+	}
+	for i := range pages {
+		if pages[i].Status == "" {
+			pages[i].Status = StatusFailed
+			pages[i].Error = "no result returned"
+		}
+	}
+	return bulk.ID, nil
+}
+
+// hrefPattern matches the href attribute of anchor and area tags.
+var hrefPattern = regexp.MustCompile(`(?is)<(?:a|area)\s[^>]*?\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+
+// basePattern matches the href attribute of a base tag.
+var basePattern = regexp.MustCompile(`(?is)<base\s[^>]*?\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+
+// fetchLinks downloads an HTML page and returns the distinct page links it
+// contains, resolved and normalized. Responses that are not HTML have no
+// links.
+func fetchLinks(ctx context.Context, hc *http.Client, page *url.URL) ([]*url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, page.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("crawler: %s returned status %d", page, resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return nil, err
+	}
+	return extractLinks(string(body), resp.Request.URL), nil
+}
+
+// extractLinks returns the distinct page links in an HTML document, resolved
+// against its base URL.
+func extractLinks(doc string, base *url.URL) []*url.URL {
+	if m := basePattern.FindStringSubmatch(doc); m != nil {
+		if u, err := base.Parse(attrValue(m)); err == nil {
+			base = u
+		}
+	}
+
+	var links []*url.URL
+	seen := make(map[string]bool)
+	for _, m := range hrefPattern.FindAllStringSubmatch(doc, -1) {
+		u, err := normalizeURL(attrValue(m), base)
+		if err != nil || !isPage(u) || seen[u.String()] {
+			continue
+		}
+		seen[u.String()] = true
+		links = append(links, u)
+	}
+	return links
+}
+
+// attrValue returns the attribute value captured by hrefPattern or basePattern.
+func attrValue(m []string) string {
+	for _, v := range m[1:] {
+		if v != "" {
+			return unescape(strings.TrimSpace(v))
+		}
+	}
+	return ""
+}
+
+// unescape decodes the HTML entities that commonly appear in URLs.
+var unescape = strings.NewReplacer("&amp;", "&", "&#38;", "&", "&quot;", `"`, "&#39;", "'").Replace
+
+// normalizeURL resolves raw against base, if given, and normalizes it so
+// spellings of the same page compare equal: scheme and host lowercased,
+// default ports and fragments dropped, and an empty path made "/". Only
+// http and https URLs are accepted.
+func normalizeURL(raw string, base *url.URL) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.New("missing host")
+	}
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" {
+		host += ":" + port
+	}
+	u.Host = host
+	u.User = nil
+	u.Fragment, u.RawFragment = "", ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u, nil
+}
+
+// assetExtensions are file types linked from pages that are not pages
+// themselves.
+var assetExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true, ".svg": true, ".ico": true,
+	".pdf": true, ".zip": true, ".gz": true, ".tar": true, ".mp3": true, ".mp4": true, ".webm": true,
+	".css": true, ".js": true, ".json": true, ".xml": true, ".txt": true, ".csv": true,
+	".doc": true, ".docx": true, ".xls": true, ".xlsx": true, ".ppt": true, ".pptx": true,
+}
+
+// isPage reports whether u looks like a web page rather than a download.
+func isPage(u *url.URL) bool {
+	return !assetExtensions[strings.ToLower(path.Ext(u.Path))]
+}
+
+// slugPattern matches runs of characters not allowed in a key segment.
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slug returns a readable key segment for a page URL, e.g. "docs-getting-started"
+// for "https://example.com/docs/getting-started".
+func slug(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "page"
+	}
+	s := strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(u.Path+" "+u.RawQuery), "-"), "-")
+	if s == "" {
+		return "index"
+	}
+	if len(s) > 60 {
+		s = strings.TrimRight(s[:60], "-")
+	}
+	return s
+}
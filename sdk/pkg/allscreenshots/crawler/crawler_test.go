@@ -0,0 +1,217 @@
+package crawler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots/resultstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pngData = []byte("\x89PNG\r\n\x1a\nimage")
+
+// newSite serves a small site whose pages link to each other.
+func newSite(t *testing.T) *httptest.Server {
+	pages := map[string]string{
+		"/": `<html><body>
+			<a href="/about">About</a> <a href='/about#team'>Team</a>
+			<a href=blog>Blog</a> <a href="/logo.png">Logo</a>
+			<a href="mailto:hi@example.com">Mail</a> <a href="https://other.example/x">Elsewhere</a>
+		</body></html>`,
+		"/about":       `<a href="/about/team">Team</a><a href="/">Home</a>`,
+		"/blog":        `<a href="/blog/post-1?ref=index&amp;page=1">Post</a>`,
+		"/blog/post-1": `<a href="/deep">Deep</a>`,
+		"/about/team":  `<p>team</p>`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, body)
+	}))
+}
+
+// newAPI fakes the bulk endpoints, failing jobs for URLs containing "team".
+func newAPI(t *testing.T) (*httptest.Server, *[]allscreenshots.BulkRequest) {
+	var (
+		mu       sync.Mutex
+		requests []allscreenshots.BulkRequest
+		jobs     = make(map[string][]allscreenshots.BulkJobDetailInfo)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/screenshots/bulk":
+			var req allscreenshots.BulkRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			requests = append(requests, req)
+			id := fmt.Sprintf("bulk-%d", len(requests))
+			for i, u := range req.URLs {
+				job := allscreenshots.BulkJobDetailInfo{ID: fmt.Sprintf("%s-job-%d", id, i), URL: u.URL, Status: "COMPLETED"}
+				if strings.Contains(u.URL, "team") {
+					job.Status, job.ErrorMessage = "FAILED", "navigation timeout"
+				}
+				jobs[id] = append(jobs[id], job)
+			}
+			json.NewEncoder(w).Encode(allscreenshots.BulkResponse{ID: id, Status: "PROCESSING", TotalJobs: len(req.URLs)})
+		case strings.HasSuffix(r.URL.Path, "/result"):
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(pngData)
+		case strings.HasPrefix(r.URL.Path, "/v1/screenshots/bulk/"):
+			id := strings.TrimPrefix(r.URL.Path, "/v1/screenshots/bulk/")
+			json.NewEncoder(w).Encode(allscreenshots.BulkStatusResponse{ID: id, Status: "COMPLETED", TotalJobs: len(jobs[id]), Jobs: jobs[id]})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	return server, &requests
+}
+
+func TestCaptureSite(t *testing.T) {
+	site := newSite(t)
+	defer site.Close()
+	api, requests := newAPI(t)
+	defer api.Close()
+
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(api.URL))
+	store := resultstore.NewMemory()
+
+	manifest, err := CaptureSite(context.Background(), client, site.URL, CrawlOptions{
+		SameHostOnly: true,
+		Device:       "Desktop HD",
+		Store:        store,
+		Prefix:       "archive",
+		PollOptions:  []allscreenshots.PollOption{allscreenshots.WithPollInterval(time.Millisecond)},
+	})
+	require.NoError(t, err)
+
+	var urls []string
+	for _, p := range manifest.Pages {
+		urls = append(urls, strings.TrimPrefix(p.URL, site.URL))
+	}
+	assert.Equal(t, []string{"/", "/about", "/blog", "/about/team", "/blog/post-1?ref=index&page=1"}, urls)
+	assert.Equal(t, []int{0, 1, 1, 2, 2}, []int{manifest.Pages[0].Depth, manifest.Pages[1].Depth, manifest.Pages[2].Depth, manifest.Pages[3].Depth, manifest.Pages[4].Depth})
+	assert.Equal(t, 4, manifest.Captured())
+	assert.Equal(t, []string{"bulk-1"}, manifest.BulkJobIDs)
+
+	require.Len(t, *requests, 1)
+	assert.Equal(t, "Desktop HD", (*requests)[0].Defaults.Device)
+
+	assert.Equal(t, "archive/0000-index.png", manifest.Pages[0].Key)
+	assert.Equal(t, "archive/0004-blog-post-1-ref-index-page-1.png", manifest.Pages[4].Key)
+	team := manifest.Pages[3]
+	assert.Equal(t, StatusFailed, team.Status)
+	assert.Equal(t, "navigation timeout", team.Error)
+	assert.Empty(t, team.Key)
+
+	data, meta, err := store.Get(context.Background(), "archive/0001-about.png")
+	require.NoError(t, err)
+	assert.Equal(t, pngData, data)
+	assert.Equal(t, site.URL+"/about", meta.SourceURL)
+	assert.Equal(t, "bulk-1-job-1", meta.JobID)
+
+	data, meta, err = store.Get(context.Background(), "archive/index.json")
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", meta.ContentType)
+	var stored Manifest
+	require.NoError(t, json.Unmarshal(data, &stored))
+	assert.Equal(t, manifest.Pages, stored.Pages)
+}
+
+func TestCaptureSite_Limits(t *testing.T) {
+	site := newSite(t)
+	defer site.Close()
+	api, requests := newAPI(t)
+	defer api.Close()
+
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(api.URL))
+	poll := []allscreenshots.PollOption{allscreenshots.WithPollInterval(time.Millisecond)}
+
+	t.Run("max pages", func(t *testing.T) {
+		manifest, err := CaptureSite(context.Background(), client, site.URL, CrawlOptions{MaxPages: 2, SameHostOnly: true, Store: resultstore.NewMemory(), PollOptions: poll})
+		require.NoError(t, err)
+		require.Len(t, manifest.Pages, 2)
+		assert.Equal(t, site.URL+"/about", manifest.Pages[1].URL)
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		manifest, err := CaptureSite(context.Background(), client, site.URL, CrawlOptions{MaxDepth: 1, SameHostOnly: true, Store: resultstore.NewMemory(), PollOptions: poll})
+		require.NoError(t, err)
+		assert.Len(t, manifest.Pages, 3)
+	})
+
+	t.Run("other hosts are captured but not followed", func(t *testing.T) {
+		manifest, err := CaptureSite(context.Background(), client, site.URL, CrawlOptions{Store: resultstore.NewMemory(), PollOptions: poll})
+		require.NoError(t, err)
+		var urls []string
+		for _, p := range manifest.Pages {
+			urls = append(urls, p.URL)
+		}
+		assert.Contains(t, urls, "https://other.example/x")
+		assert.Len(t, urls, 6)
+	})
+
+	t.Run("store is required", func(t *testing.T) {
+		_, err := CaptureSite(context.Background(), client, site.URL, CrawlOptions{})
+		assert.EqualError(t, err, "crawler: store is required")
+	})
+
+	t.Run("invalid root URL", func(t *testing.T) {
+		_, err := CaptureSite(context.Background(), client, "ftp://example.com", CrawlOptions{Store: resultstore.NewMemory()})
+		assert.ErrorContains(t, err, "crawler: invalid root URL")
+	})
+
+	assert.Len(t, *requests, 3)
+}
+
+func TestExtractLinks(t *testing.T) {
+	base, _ := url.Parse("https://Example.com:443/docs/intro")
+	doc := `<base href="/docs/v2/">
+		<a class="x" href="guide">Guide</a>
+		<A HREF="https://EXAMPLE.com/docs/v2/guide#top">Same</A>
+		<a href="../pricing?b=2&amp;a=1">Pricing</a>
+		<area href="http://example.com:80">
+		<a href="javascript:void(0)">JS</a>
+		<a href="/files/report.PDF">Report</a>
+		<link href="/style.css">`
+
+	var got []string
+	for _, u := range extractLinks(doc, base) {
+		got = append(got, u.String())
+	}
+	assert.Equal(t, []string{
+		"https://example.com/docs/v2/guide",
+		"https://example.com/docs/pricing?b=2&a=1",
+		"http://example.com/",
+	}, got)
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://example.com/", "index"},
+		{"https://example.com/docs/Getting_Started", "docs-getting-started"},
+		{"https://example.com/search?q=go", "search-q-go"},
+		{"https://example.com/" + strings.Repeat("a", 100), strings.Repeat("a", 60)},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, slug(tt.url), tt.url)
+	}
+}
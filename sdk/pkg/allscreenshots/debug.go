@@ -0,0 +1,176 @@
+package allscreenshots
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// debugBodyLimit is how many bytes of a body WithDebug writes.
+const debugBodyLimit = 2048
+
+// debugPeekLimit is how many bytes of a JSON response WithDebug reads to
+// log it; larger bodies are logged by size.
+const debugPeekLimit = 64 << 10
+
+// debugRedacted replaces sensitive values in debug output.
+const debugRedacted = "[REDACTED]"
+
+// WithDebug writes a line for every request attempt and response to w:
+// method, URL, attempt number, status, duration, and JSON bodies truncated
+// to 2 KB. Credentials are redacted: headers (including the API key) are
+// never written, fields such as passwords, webhook secrets, tokens, and
+// cookie values are masked, and the query strings of signed result URLs
+// are removed. Writes to w are serialized.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(allscreenshots.WithDebug(os.Stderr))
+func WithDebug(w io.Writer) ClientOption {
+	return func(c *Client) {
+		if w == nil {
+			c.debug = nil
+			return
+		}
+		c.debug = &debugLogger{w: w}
+	}
+}
+
+// debugLogger serializes debug output of a client.
+type debugLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (d *debugLogger) printf(format string, args ...interface{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fmt.Fprintf(d.w, "allscreenshots: "+format+"\n", args...)
+}
+
+// request logs an outgoing request.
+func (d *debugLogger) request(attempt int, method, reqURL string, body []byte) {
+	line := fmt.Sprintf("--> %s %s attempt=%d", method, debugURL(reqURL), attempt)
+	if len(body) > 0 {
+		line += " body=" + debugJSON(body)
+	}
+	d.printf("%s", line)
+}
+
+// response logs a response. The start of JSON bodies is read for logging
+// and resp.Body is replaced so the caller still sees the whole body.
+func (d *debugLogger) response(attempt int, method, reqURL string, resp *http.Response, elapsed time.Duration) {
+	line := fmt.Sprintf("<-- %d %s %s attempt=%d (%s)", resp.StatusCode, method, debugURL(reqURL), attempt, elapsed.Round(time.Millisecond))
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		data, err := io.ReadAll(io.LimitReader(resp.Body, debugPeekLimit))
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+		if err == nil && len(data) > 0 {
+			line += " body=" + debugJSON(data)
+		}
+	} else if mediaType != "" {
+		line += " body=<" + mediaType
+		if resp.ContentLength >= 0 {
+			line += fmt.Sprintf(", %d bytes", resp.ContentLength)
+		}
+		line += ">"
+	}
+	d.printf("%s", line)
+}
+
+// failure logs a request that got no response.
+func (d *debugLogger) failure(attempt int, method, reqURL string, err error, elapsed time.Duration) {
+	d.printf("<-- ERROR %s %s attempt=%d (%s) error=%q", method, debugURL(reqURL), attempt, elapsed.Round(time.Millisecond), err.Error())
+}
+
+// debugURL removes credentials from a URL: user info and, since signed URLs
+// carry their signature there, any query string of a URL outside the API.
+// API query strings, which only hold filters, are kept.
+func debugURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	if u.User != nil {
+		u.User = url.User(debugRedacted)
+	}
+	if u.RawQuery != "" && isSignedURL(u.Query()) {
+		u.RawQuery = debugRedacted
+	}
+	return u.String()
+}
+
+// isSignedURL reports whether query carries a signature or credential.
+func isSignedURL(query url.Values) bool {
+	for key := range query {
+		if isSensitiveKey(key) || strings.EqualFold(key, "sig") || strings.EqualFold(key, "expires") {
+			return true
+		}
+	}
+	return false
+}
+
+// debugJSON returns body with sensitive fields debugRedacted, truncated to
+// debugBodyLimit. Bodies that are not JSON are shown as their size.
+func debugJSON(body []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	out, err := json.Marshal(redactJSON(v, ""))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	if len(out) > debugBodyLimit {
+		return string(out[:debugBodyLimit]) + fmt.Sprintf("...(%d bytes)", len(out))
+	}
+	return string(out)
+}
+
+// redactJSON masks sensitive values in a decoded JSON value. parent is the
+// key v was found under.
+func redactJSON(v interface{}, parent string) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			switch {
+			case isSensitiveKey(k):
+				t[k] = debugRedacted
+			case strings.EqualFold(parent, "cookies") && strings.EqualFold(k, "value"):
+				t[k] = debugRedacted
+			default:
+				t[k] = redactJSON(val, k)
+			}
+		}
+	case []interface{}:
+		for i, val := range t {
+			t[i] = redactJSON(val, parent)
+		}
+	}
+	return v
+}
+
+// isSensitiveKey reports whether a field or parameter named key holds a
+// credential.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if key == "cookie" || key == "set-cookie" {
+		return true
+	}
+	for _, s := range []string{"password", "secret", "token", "apikey", "api-key", "authorization", "credential", "accountkey", "signature"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
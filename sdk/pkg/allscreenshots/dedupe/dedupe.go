@@ -0,0 +1,124 @@
+// Package dedupe helps schedulers skip captures that would produce the same
+// result as one taken recently.
+//
+// Key reduces a request to a stable hash of the parameters that affect the
+// rendered image, and a Cache remembers which keys were captured within a
+// time window.
+package dedupe
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+// Key returns a stable hash of the normalized parameters of req; it is
+// allscreenshots.RequestKey. Requests that render the same image have the
+// same key, even when they differ in URL spelling, in the order of
+// unordered lists, or in delivery-only fields such as webhooks and tags.
+//
+// Example:
+//
+//	key := dedupe.Key(&allscreenshots.ScreenshotRequest{URL: "https://Example.com:443/?b=2&a=1"})
+//	// same as dedupe.Key(&allscreenshots.ScreenshotRequest{URL: "https://example.com/?a=1&b=2"})
+func Key(req *allscreenshots.ScreenshotRequest) string {
+	return allscreenshots.RequestKey(req)
+}
+
+// Cache remembers recently captured keys. Implementations must be safe for
+// concurrent use; a shared implementation, e.g. on Redis with SET NX EX,
+// lets several schedulers deduplicate against each other.
+type Cache interface {
+	// Add records key for ttl and reports whether it was added. It returns
+	// false if key was already recorded and has not expired, meaning the
+	// capture is a duplicate.
+	Add(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Remove forgets key, e.g. after the capture it was recorded for failed.
+	Remove(ctx context.Context, key string) error
+}
+
+// sweepEvery is how many additions a MemoryCache accepts between removals of
+// expired keys.
+const sweepEvery = 1024
+
+// MemoryCache is an in-process Cache.
+type MemoryCache struct {
+	mu      sync.Mutex
+	expires map[string]time.Time
+	adds    int
+	now     func() time.Time
+}
+
+// NewMemoryCache creates an empty in-process cache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{expires: make(map[string]time.Time), now: time.Now}
+}
+
+// Add implements Cache.
+func (c *MemoryCache) Add(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if exp, ok := c.expires[key]; ok && now.Before(exp) {
+		return false, nil
+	}
+	c.expires[key] = now.Add(ttl)
+
+	c.adds++
+	if c.adds >= sweepEvery {
+		c.adds = 0
+		for k, exp := range c.expires {
+			if !now.Before(exp) {
+				delete(c.expires, k)
+			}
+		}
+	}
+	return true, nil
+}
+
+// Remove implements Cache.
+func (c *MemoryCache) Remove(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.expires, key)
+	return nil
+}
+
+// Filter returns the requests in reqs that have not been captured within
+// window, recording them in cache. Duplicates within reqs itself are also
+// dropped, keeping the first.
+//
+// Example:
+//
+//	cache := dedupe.NewMemoryCache()
+//	for range time.Tick(time.Minute) {
+//	    due, err := dedupe.Filter(ctx, cache, nextRequests(), time.Hour)
+//	    if err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    results, _ := pool.CaptureAll(ctx, due)
+//	    for _, r := range results {
+//	        if r.Err != nil {
+//	            cache.Remove(ctx, dedupe.Key(r.Request)) // retry next time
+//	        }
+//	    }
+//	}
+func Filter(ctx context.Context, cache Cache, reqs []*allscreenshots.ScreenshotRequest, window time.Duration) ([]*allscreenshots.ScreenshotRequest, error) {
+	var due []*allscreenshots.ScreenshotRequest
+	for _, req := range reqs {
+		if req == nil {
+			continue
+		}
+		added, err := cache.Add(ctx, Key(req), window)
+		if err != nil {
+			return due, err
+		}
+		if added {
+			due = append(due, req)
+		}
+	}
+	return due, nil
+}
@@ -0,0 +1,91 @@
+package dedupe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey_Normalization(t *testing.T) {
+	base := &allscreenshots.ScreenshotRequest{
+		URL:           "https://example.com/?a=1&b=2",
+		Format:        "jpeg",
+		HideSelectors: []string{".a", ".b"},
+		Headers:       map[string]string{"X-Test": "1"},
+	}
+
+	same := []*allscreenshots.ScreenshotRequest{
+		{URL: "HTTPS://Example.COM:443?b=2&a=1#top", Format: "JPG", HideSelectors: []string{".b", ".a"}, Headers: map[string]string{"x-test": "1"}},
+		{URL: base.URL, Format: "jpeg", HideSelectors: []string{".a", ".b"}, Headers: map[string]string{"X-Test": "1"}, WebhookURL: "https://hooks.example.com", Tags: []string{"nightly"}, Fresh: true},
+	}
+	for _, req := range same {
+		assert.Equal(t, Key(base), Key(req), req.URL)
+	}
+
+	different := []*allscreenshots.ScreenshotRequest{
+		{URL: "https://example.com/?a=1&b=3", Format: "jpeg", HideSelectors: []string{".a", ".b"}, Headers: map[string]string{"X-Test": "1"}},
+		{URL: base.URL, Format: "png", HideSelectors: []string{".a", ".b"}, Headers: map[string]string{"X-Test": "1"}},
+		{URL: base.URL, Format: "jpeg", HideSelectors: []string{".a", ".b"}, Headers: map[string]string{"X-Test": "1"}, FullPage: true},
+		{URL: "http://example.com/?a=1&b=2", Format: "jpeg", HideSelectors: []string{".a", ".b"}, Headers: map[string]string{"X-Test": "1"}},
+	}
+	for _, req := range different {
+		assert.NotEqual(t, Key(base), Key(req), req.URL)
+	}
+
+	assert.Len(t, Key(base), 64)
+	assert.Equal(t, []string{".b", ".a"}, same[0].HideSelectors, "Key must not modify the request")
+}
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	added, err := cache.Add(ctx, "k", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, _ = cache.Add(ctx, "k", time.Hour)
+	assert.False(t, added)
+
+	now = now.Add(time.Hour)
+	added, _ = cache.Add(ctx, "k", time.Hour)
+	assert.True(t, added, "expired keys are added again")
+
+	require.NoError(t, cache.Remove(ctx, "k"))
+	added, _ = cache.Add(ctx, "k", time.Hour)
+	assert.True(t, added)
+}
+
+type failingCache struct{}
+
+func (failingCache) Add(context.Context, string, time.Duration) (bool, error) {
+	return false, errors.New("unavailable")
+}
+
+func (failingCache) Remove(context.Context, string) error { return nil }
+
+func TestFilter(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache()
+	a := &allscreenshots.ScreenshotRequest{URL: "https://example.com/a"}
+	b := &allscreenshots.ScreenshotRequest{URL: "https://example.com/b"}
+
+	due, err := Filter(ctx, cache, []*allscreenshots.ScreenshotRequest{a, b, {URL: "https://EXAMPLE.com/a"}}, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []*allscreenshots.ScreenshotRequest{a, b}, due)
+
+	due, err = Filter(ctx, cache, []*allscreenshots.ScreenshotRequest{a, {URL: "https://example.com/c"}}, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, "https://example.com/c", due[0].URL)
+
+	_, err = Filter(ctx, failingCache{}, []*allscreenshots.ScreenshotRequest{a}, time.Hour)
+	assert.EqualError(t, err, "unavailable")
+}
@@ -0,0 +1,223 @@
+package allscreenshots
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// partialSuffix is appended to the destination path of a resumable download
+// while it is in progress.
+const partialSuffix = ".part"
+
+// DownloadResultResumable downloads the file at resultURL to path and returns
+// its size. The download is written to path+".part" and renamed into place
+// once complete. If the connection drops, the download resumes where it
+// stopped with a Range request rather than starting over, both within the
+// call (up to the client's retry limit) and across calls, since an existing
+// .part file is picked up by the next call for the same path.
+//
+// When the server sends a SHA-256 digest of the file (in a Repr-Digest or
+// Digest header), the finished download is verified against it; a mismatch
+// removes the partial file and returns a *ChecksumError. If the server
+// ignores the Range header, the download restarts from the beginning.
+//
+// Example:
+//
+//	n, err := client.DownloadResultResumable(ctx, job.ResultURL, "report.pdf")
+//	if err != nil {
+//	    log.Fatal(err) // run again to resume
+//	}
+//	fmt.Printf("downloaded %d bytes\n", n)
+func (c *Client) DownloadResultResumable(ctx context.Context, resultURL, path string) (int64, error) {
+	if !strings.HasPrefix(resultURL, "http://") && !strings.HasPrefix(resultURL, "https://") {
+		return 0, &ValidationError{Field: "resultURL", Message: "result URL must start with http:// or https://"}
+	}
+	if path == "" {
+		return 0, &ValidationError{Field: "path", Message: "path is required"}
+	}
+
+	partPath := path + partialSuffix
+	f, err := os.OpenFile(partPath, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("allscreenshots: failed to open %s: %w", partPath, err)
+	}
+	defer f.Close()
+
+	d := &resumableDownload{client: c, url: resultURL, file: f}
+	for attempt := 0; ; attempt++ {
+		err = d.fetch(ctx)
+		if err == nil {
+			break
+		}
+		// Only a dropped connection mid-body is resumed here; failures
+		// before the body starts were already retried by the client.
+		if !d.interrupted || attempt >= c.maxRetries || ctx.Err() != nil {
+			return 0, err
+		}
+	}
+
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("allscreenshots: failed to read %s: %w", partPath, err)
+	}
+	if d.digest != nil {
+		if err := d.verify(); err != nil {
+			f.Close()
+			os.Remove(partPath)
+			return 0, err
+		}
+	}
+	if err := f.Sync(); err != nil {
+		return 0, fmt.Errorf("allscreenshots: failed to write %s: %w", partPath, err)
+	}
+	if err := f.Close(); err != nil {
+		return 0, fmt.Errorf("allscreenshots: failed to write %s: %w", partPath, err)
+	}
+	if err := os.Rename(partPath, path); err != nil {
+		return 0, fmt.Errorf("allscreenshots: failed to move download into place: %w", err)
+	}
+	return size, nil
+}
+
+// resumableDownload holds the state of a download across resumed requests.
+type resumableDownload struct {
+	client *Client
+	url    string
+	file   *os.File
+	// validator is the ETag or Last-Modified of the file, sent as If-Range
+	// so a file that changed between requests is downloaded afresh
+	validator string
+	// digest is the SHA-256 of the complete file, if the server sent one
+	digest []byte
+	// interrupted reports whether the last fetch failed while reading the body
+	interrupted bool
+}
+
+// fetch requests the rest of the file and appends it to the partial file.
+func (d *resumableDownload) fetch(ctx context.Context) error {
+	offset, err := d.file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("allscreenshots: failed to read partial download: %w", err)
+	}
+
+	header := http.Header{}
+	if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		if d.validator != "" {
+			header.Set("If-Range", d.validator)
+		}
+	}
+
+	d.interrupted = false
+	err = d.client.requestRawHeader(ctx, http.MethodGet, d.url, nil, header, func(resp *http.Response) error {
+		if resp.StatusCode == http.StatusPartialContent {
+			start, ok := parseContentRangeStart(resp.Header.Get("Content-Range"))
+			if !ok || start != offset {
+				return fmt.Errorf("allscreenshots: server returned range %q, want bytes from %d", resp.Header.Get("Content-Range"), offset)
+			}
+		} else if err := d.restart(); err != nil {
+			return err
+		}
+		d.remember(resp.Header)
+
+		if _, err := copyBody(d.file, resp.Body); err != nil {
+			if _, ok := err.(*ResponseTooLargeError); ok {
+				return err
+			}
+			d.interrupted = true
+			return &NetworkError{Message: "download interrupted", Cause: err}
+		}
+		return nil
+	})
+
+	// A partial file that is already complete (or belongs to a different
+	// file) makes the range unsatisfiable; start over.
+	if apiErr, ok := AsAPIError(err); ok && apiErr.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0 {
+		if err := d.restart(); err != nil {
+			return err
+		}
+		d.validator = ""
+		return d.fetch(ctx)
+	}
+	return err
+}
+
+// restart discards the partial file.
+func (d *resumableDownload) restart() error {
+	if err := d.file.Truncate(0); err != nil {
+		return fmt.Errorf("allscreenshots: failed to reset partial download: %w", err)
+	}
+	if _, err := d.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("allscreenshots: failed to reset partial download: %w", err)
+	}
+	return nil
+}
+
+// remember records the validator and digest of the file from a response.
+func (d *resumableDownload) remember(header http.Header) {
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		d.validator = etag
+	} else if modified := header.Get("Last-Modified"); modified != "" {
+		d.validator = modified
+	}
+	if digest := parseSHA256Digest(header); digest != nil {
+		d.digest = digest
+	}
+}
+
+// verify checks the downloaded file against the server's digest.
+func (d *resumableDownload) verify() error {
+	if _, err := d.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("allscreenshots: failed to verify download: %w", err)
+	}
+	h := sha256.New()
+	if _, err := copyBody(h, d.file); err != nil {
+		return fmt.Errorf("allscreenshots: failed to verify download: %w", err)
+	}
+	if sum := h.Sum(nil); string(sum) != string(d.digest) {
+		return &ChecksumError{Expected: hex.EncodeToString(d.digest), Actual: hex.EncodeToString(sum)}
+	}
+	return nil
+}
+
+// parseContentRangeStart returns the first byte position of a Content-Range
+// header such as "bytes 100-199/200".
+func parseContentRangeStart(value string) (int64, bool) {
+	rest, ok := strings.CutPrefix(value, "bytes ")
+	if !ok {
+		return 0, false
+	}
+	start, _, ok := strings.Cut(rest, "-")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(start, 10, 64)
+	return n, err == nil
+}
+
+// parseSHA256Digest returns the SHA-256 digest of the full file from a
+// Repr-Digest (RFC 9530, e.g. sha-256=:base64:) or Digest (RFC 3230, e.g.
+// SHA-256=base64) header, or nil if there is none.
+func parseSHA256Digest(header http.Header) []byte {
+	for _, name := range []string{"Repr-Digest", "Digest"} {
+		for _, part := range strings.Split(header.Get(name), ",") {
+			alg, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+			if !ok || !strings.EqualFold(alg, "sha-256") {
+				continue
+			}
+			sum, err := base64.StdEncoding.DecodeString(strings.Trim(value, ":"))
+			if err == nil && len(sum) == sha256.Size {
+				return sum
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package allscreenshots
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DownloadResultResumable(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 8192)
+	sum := sha256.Sum256(content)
+	digest := "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		w.Header().Set("Repr-Digest", digest)
+		w.Header().Set("ETag", `"v1"`)
+		if len(ranges) == 1 {
+			// Drop the connection halfway through the first response.
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.Write(content[:len(content)/2])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		http.ServeContent(w, r, "result.pdf", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "result.pdf")
+	client := NewClient(WithAPIKey("test-api-key"), WithRetryWait(time.Millisecond, time.Millisecond))
+
+	n, err := client.DownloadResultResumable(context.Background(), server.URL+"/result.pdf", path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), n)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+	assert.NoFileExists(t, path+partialSuffix)
+
+	require.Len(t, ranges, 2)
+	assert.Equal(t, "", ranges[0])
+	assert.Regexp(t, `^bytes=\d+-$`, ranges[1])
+	assert.NotEqual(t, "bytes=0-", ranges[1])
+}
+
+func TestClient_DownloadResultResumable_ExistingPartial(t *testing.T) {
+	content := []byte("the quick brown fox jumps over the lazy dog")
+
+	var gotRange string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRange = r.Header.Get("Range")
+		http.ServeContent(w, r, "result.png", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "result.png")
+	require.NoError(t, os.WriteFile(path+partialSuffix, content[:10], 0o644))
+
+	client := NewClient(WithAPIKey("test-api-key"))
+	n, err := client.DownloadResultResumable(context.Background(), server.URL+"/result.png", path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), n)
+	assert.Equal(t, "bytes=10-", gotRange)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+}
+
+func TestClient_DownloadResultResumable_RangeIgnored(t *testing.T) {
+	content := []byte("fresh content")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "result.png")
+	require.NoError(t, os.WriteFile(path+partialSuffix, []byte("stale partial data"), 0o644))
+
+	client := NewClient(WithAPIKey("test-api-key"))
+	_, err := client.DownloadResultResumable(context.Background(), server.URL+"/result.png", path)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+}
+
+func TestClient_DownloadResultResumable_ChecksumMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sum := sha256.Sum256([]byte("something else"))
+		w.Header().Set("Digest", "SHA-256="+base64.StdEncoding.EncodeToString(sum[:]))
+		fmt.Fprint(w, "corrupted")
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "result.png")
+	client := NewClient(WithAPIKey("test-api-key"))
+	_, err := client.DownloadResultResumable(context.Background(), server.URL+"/result.png", path)
+
+	var checksumErr *ChecksumError
+	require.ErrorAs(t, err, &checksumErr)
+	assert.NoFileExists(t, path)
+	assert.NoFileExists(t, path+partialSuffix)
+}
+
+func TestClient_DownloadResultResumable_Validation(t *testing.T) {
+	client := NewClient(WithAPIKey("test-api-key"))
+
+	_, err := client.DownloadResultResumable(context.Background(), "/relative", "out.png")
+	assert.True(t, IsValidationError(err))
+
+	_, err = client.DownloadResultResumable(context.Background(), "https://example.com/a.png", "")
+	assert.True(t, IsValidationError(err))
+}
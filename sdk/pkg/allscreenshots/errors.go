@@ -1,7 +1,12 @@
 package allscreenshots
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"time"
 )
 
 // APIError represents an error returned by the Allscreenshots API.
@@ -14,24 +19,44 @@ type APIError struct {
 	Message string
 	// Details contains additional error information
 	Details map[string]interface{}
+	// SDKVersion is the Version of the SDK that received the error, to
+	// include when reporting it
+	SDKVersion string
 }
 
 // Error implements the error interface.
 func (e *APIError) Error() string {
+	msg := fmt.Sprintf("allscreenshots: API error %d: %s", e.StatusCode, e.Message)
 	if e.Code != "" {
-		return fmt.Sprintf("allscreenshots: API error %d (%s): %s", e.StatusCode, e.Code, e.Message)
+		msg = fmt.Sprintf("allscreenshots: API error %d (%s): %s", e.StatusCode, e.Code, e.Message)
 	}
-	return fmt.Sprintf("allscreenshots: API error %d: %s", e.StatusCode, e.Message)
+	if e.SDKVersion != "" {
+		msg += " (sdk-go " + e.SDKVersion + ")"
+	}
+	return msg
+}
+
+// TargetStatus returns the HTTP status returned by the captured page for
+// ErrCodeTargetStatus errors, or 0 if it is not known.
+func (e *APIError) TargetStatus() int {
+	if status, ok := e.Details["targetStatus"].(float64); ok {
+		return int(status)
+	}
+	return 0
 }
 
 // IsAPIError checks if an error is an APIError.
 func IsAPIError(err error) bool {
-	_, ok := err.(*APIError)
+	_, ok := AsAPIError(err)
 	return ok
 }
 
-// AsAPIError converts an error to an APIError if possible.
+// AsAPIError converts an error to an APIError if possible. API errors with
+// the TIMEOUT code are returned as a *TimeoutError and are found through it.
 func AsAPIError(err error) (*APIError, bool) {
+	if timeoutErr, ok := err.(*TimeoutError); ok {
+		err = timeoutErr.Cause
+	}
 	apiErr, ok := err.(*APIError)
 	return apiErr, ok
 }
@@ -78,7 +103,10 @@ func IsNetworkError(err error) bool {
 	return ok
 }
 
-// TimeoutError represents a timeout error.
+// TimeoutError represents a timeout error: a context deadline or network
+// timeout during a request, an API error with the TIMEOUT code, or a wait
+// that did not finish within its poll timeout. Cause is the underlying error,
+// if any.
 type TimeoutError struct {
 	Message string
 	Cause   error
@@ -97,21 +125,163 @@ func (e *TimeoutError) Unwrap() error {
 	return e.Cause
 }
 
-// IsTimeoutError checks if an error is a TimeoutError.
+// IsTimeoutError checks if an error is a TimeoutError, anywhere in its
+// chain, or a JobFailedError for a job that timed out.
 func IsTimeoutError(err error) bool {
-	_, ok := err.(*TimeoutError)
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return true
+	}
+	var jobErr *JobFailedError
+	if errors.As(err, &jobErr) && jobErr.Job != nil {
+		return jobErr.Job.ErrorCode == ErrCodeTimeout
+	}
+	return false
+}
+
+// timeoutError returns err as a *TimeoutError if it reports a timeout: a
+// context deadline, a network timeout, or an API error with the TIMEOUT code.
+// Other errors, and errors that already are a *TimeoutError, are returned
+// unchanged.
+func timeoutError(err error) error {
+	switch e := err.(type) {
+	case *TimeoutError:
+		return err
+	case *APIError:
+		if e.Code == ErrCodeTimeout {
+			return &TimeoutError{Message: e.Message, Cause: e}
+		}
+		return err
+	}
+	if isTimeout(err) {
+		return &TimeoutError{Message: "request timed out", Cause: err}
+	}
+	return err
+}
+
+// isTimeout reports whether err is a context deadline or a network timeout.
+func isTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// JobFailedError is returned when an awaited job fails or is cancelled.
+type JobFailedError struct {
+	// Job is the job's final state
+	Job *JobResponse
+}
+
+// Error implements the error interface.
+func (e *JobFailedError) Error() string {
+	if e.Job == nil {
+		return "allscreenshots: job failed"
+	}
+	if e.Job.Status == JobStatusCancelled {
+		return fmt.Sprintf("allscreenshots: job %s was cancelled", e.Job.ID)
+	}
+	if e.Job.ErrorCode != "" {
+		return fmt.Sprintf("allscreenshots: job %s failed (%s): %s", e.Job.ID, e.Job.ErrorCode, e.Job.ErrorMessage)
+	}
+	return fmt.Sprintf("allscreenshots: job %s failed: %s", e.Job.ID, e.Job.ErrorMessage)
+}
+
+// IsJobFailedError checks if an error is a JobFailedError.
+func IsJobFailedError(err error) bool {
+	_, ok := err.(*JobFailedError)
 	return ok
 }
 
+// ResponseTooLargeError is returned when a response body exceeds the limit
+// set with WithMaxResponseSize.
+type ResponseTooLargeError struct {
+	// Limit is the configured maximum size in bytes
+	Limit int64
+	// Size is the response's declared Content-Length, or -1 if the body was
+	// cut off while reading
+	Size int64
+}
+
+// Error implements the error interface.
+func (e *ResponseTooLargeError) Error() string {
+	if e.Size >= 0 {
+		return fmt.Sprintf("allscreenshots: response of %d bytes exceeds limit of %d bytes", e.Size, e.Limit)
+	}
+	return fmt.Sprintf("allscreenshots: response exceeds limit of %d bytes", e.Limit)
+}
+
+// IsResponseTooLargeError checks if an error is a ResponseTooLargeError.
+func IsResponseTooLargeError(err error) bool {
+	var tooLarge *ResponseTooLargeError
+	return errors.As(err, &tooLarge)
+}
+
+// ChecksumError is returned when a downloaded file does not match the
+// digest sent by the server.
+type ChecksumError struct {
+	// Expected is the hex SHA-256 digest sent by the server
+	Expected string
+	// Actual is the hex SHA-256 digest of the downloaded file
+	Actual string
+}
+
+// Error implements the error interface.
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("allscreenshots: checksum mismatch: expected sha256 %s, got %s", e.Expected, e.Actual)
+}
+
+// QuotaExceededError is returned by a client with WithQuotaGuard when a
+// submission would leave less quota than the guard's threshold. The
+// submission was not sent.
+type QuotaExceededError struct {
+	// Remaining is the quota left before the submission
+	Remaining int
+	// Required is the number of screenshots the submission would use
+	Required int
+	// MinRemaining is the guard's threshold
+	MinRemaining int
+}
+
+// Error implements the error interface.
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("allscreenshots: submission of %d screenshots would leave %d of quota, below the guard of %d",
+		e.Required, e.Remaining-e.Required, e.MinRemaining)
+}
+
+// IsQuotaExceededError checks if an error is a QuotaExceededError.
+func IsQuotaExceededError(err error) bool {
+	var quotaErr *QuotaExceededError
+	return errors.As(err, &quotaErr)
+}
+
 // RetryError represents an error that occurred after all retries were exhausted.
 type RetryError struct {
-	Attempts int
-	LastErr  error
+	// Attempts records every failed attempt, in order
+	Attempts []AttemptInfo
+	// LastErr is the error of the final attempt
+	LastErr error
+}
+
+// AttemptInfo describes one failed attempt of a retried request.
+type AttemptInfo struct {
+	// Attempt is the 1-based attempt number
+	Attempt int
+	// StatusCode is the HTTP status of the response, or 0 if none was received
+	StatusCode int
+	// Err is the error the attempt failed with
+	Err error
+	// Wait is how long the client waited before making the attempt
+	Wait time.Duration
 }
 
 // Error implements the error interface.
 func (e *RetryError) Error() string {
-	return fmt.Sprintf("allscreenshots: failed after %d attempts: %v", e.Attempts, e.LastErr)
+	return fmt.Sprintf("allscreenshots: failed after %d attempts: %v", len(e.Attempts), e.LastErr)
 }
 
 // Unwrap returns the last error that occurred.
@@ -135,6 +305,7 @@ const (
 	ErrCodeInvalidDelay       = "INVALID_DELAY"
 	ErrCodeInvalidQuality     = "INVALID_QUALITY"
 	ErrCodeURLUnreachable     = "URL_UNREACHABLE"
+	ErrCodeTargetStatus       = "TARGET_STATUS"
 	ErrCodeTimeout            = "TIMEOUT"
 	ErrCodeRateLimitExceeded  = "RATE_LIMIT_EXCEEDED"
 	ErrCodeQuotaExceeded      = "QUOTA_EXCEEDED"
@@ -185,6 +356,19 @@ func IsRateLimited(err error) bool {
 	return false
 }
 
+// IsTargetStatusError returns true if the capture failed because the target
+// page responded with a status listed in FailOnStatus, FailOn4xx, or FailOn5xx.
+func IsTargetStatusError(err error) bool {
+	if apiErr, ok := AsAPIError(err); ok {
+		return apiErr.Code == ErrCodeTargetStatus
+	}
+	var jobErr *JobFailedError
+	if errors.As(err, &jobErr) && jobErr.Job != nil {
+		return jobErr.Job.ErrorCode == ErrCodeTargetStatus
+	}
+	return false
+}
+
 // IsServerError returns true if the error is a 5xx server error.
 func IsServerError(err error) bool {
 	if apiErr, ok := AsAPIError(err); ok {
@@ -192,3 +376,65 @@ func IsServerError(err error) bool {
 	}
 	return false
 }
+
+// IsRetryable reports whether err is a transient failure that the client
+// would retry itself: a 429, 502, 503, or 504 response, a transport timeout
+// such as a dial or HTTP client timeout, or a network error such as a
+// refused or reset connection. A TIMEOUT error from the API, for a page that
+// took too long to render, and the caller's own context deadline are not
+// retryable. Wrapped errors and
+// the last error of a RetryError are inspected, so a request that failed
+// after exhausting its retries can still be queued to try again later.
+//
+// Example:
+//
+//	if err := process(ctx, client, task); err != nil {
+//	    if allscreenshots.IsRetryable(err) {
+//	        queue.RetryLater(task)
+//	    } else {
+//	        queue.DeadLetter(task, allscreenshots.ErrorCode(err))
+//	    }
+//	}
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return isRetryableStatus(apiErr.StatusCode)
+	}
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return isTransportTimeout(timeoutErr.Cause)
+	}
+	var netErr *NetworkError
+	if errors.As(err, &netErr) {
+		return isRetryableError(netErr.Cause)
+	}
+	return false
+}
+
+// isTransportTimeout reports whether err, the cause of a TimeoutError, is a
+// timeout of the HTTP transport, which the client retries, rather than the
+// caller's context deadline. Both report context.DeadlineExceeded through
+// errors.Is, so only a deadline that is the request's own error counts as
+// the caller's.
+func isTransportTimeout(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	return err != context.DeadlineExceeded && isRetryableError(err)
+}
+
+// ErrorCode returns the API error code of err, such as ErrCodeRateLimitExceeded,
+// looking through wrapped errors. For a JobFailedError it is the job's error
+// code. It returns "" if err carries no error code.
+func ErrorCode(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	var jobErr *JobFailedError
+	if errors.As(err, &jobErr) && jobErr.Job != nil {
+		return jobErr.Job.ErrorCode
+	}
+	return ""
+}
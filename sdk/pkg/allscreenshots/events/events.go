@@ -0,0 +1,173 @@
+// Package events publishes job results to message brokers for event-driven
+// architectures. It defines the normalized JobCompleted message and a
+// Publisher interface with Kafka and NATS adapters; the relay package feeds
+// it from the API.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+// Message types of JobCompleted.
+const (
+	TypeJobCompleted = "job.completed"
+	TypeJobFailed    = "job.failed"
+	TypeJobCancelled = "job.cancelled"
+)
+
+// JobCompleted is the message published when a job reaches a final status.
+type JobCompleted struct {
+	// Type is TypeJobCompleted, TypeJobFailed, or TypeJobCancelled
+	Type string `json:"type"`
+	// JobID of the finished job
+	JobID string `json:"jobId"`
+	// Status of the job: COMPLETED, FAILED, or CANCELLED
+	Status allscreenshots.JobStatus `json:"status"`
+	// URL that was captured
+	URL string `json:"url"`
+	// ResultURL where the result can be downloaded, for completed jobs
+	ResultURL string `json:"resultUrl,omitempty"`
+	// StorageURL of the object uploaded to the customer's bucket, if any
+	StorageURL string `json:"storageUrl,omitempty"`
+	// ErrorCode of a failed job
+	ErrorCode string `json:"errorCode,omitempty"`
+	// ErrorMessage of a failed job
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// Tags the job was created with
+	Tags []string `json:"tags,omitempty"`
+	// CompletedAt is when the job finished
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// FromJob converts a job to a JobCompleted message. It reports false if the
+// job has not reached a final status.
+func FromJob(job *allscreenshots.JobResponse) (JobCompleted, bool) {
+	var msgType string
+	switch job.Status {
+	case allscreenshots.JobStatusCompleted:
+		msgType = TypeJobCompleted
+	case allscreenshots.JobStatusFailed:
+		msgType = TypeJobFailed
+	case allscreenshots.JobStatusCancelled:
+		msgType = TypeJobCancelled
+	default:
+		return JobCompleted{}, false
+	}
+	msg := JobCompleted{
+		Type:         msgType,
+		JobID:        job.ID,
+		Status:       job.Status,
+		URL:          job.URL,
+		ResultURL:    job.ResultURL,
+		ErrorCode:    job.ErrorCode,
+		ErrorMessage: job.ErrorMessage,
+		Tags:         job.Tags,
+		CompletedAt:  job.CompletedAt,
+	}
+	if job.Storage != nil {
+		msg.StorageURL = job.Storage.URL
+	}
+	return msg, true
+}
+
+// Publisher delivers messages to a broker. Implementations must be safe for
+// concurrent use.
+type Publisher interface {
+	Publish(ctx context.Context, msg JobCompleted) error
+}
+
+// PublisherFunc adapts a function to a Publisher.
+type PublisherFunc func(ctx context.Context, msg JobCompleted) error
+
+// Publish calls f.
+func (f PublisherFunc) Publish(ctx context.Context, msg JobCompleted) error {
+	return f(ctx, msg)
+}
+
+// KafkaProducer is the subset of a Kafka client used by Kafka. Adapt
+// segmentio/kafka-go, confluent-kafka-go, or sarama to it.
+//
+// Example adapter for github.com/segmentio/kafka-go:
+//
+//	type kafkaGo struct{ w *kafka.Writer }
+//
+//	func (k kafkaGo) Produce(ctx context.Context, topic string, key, value []byte) error {
+//	    return k.w.WriteMessages(ctx, kafka.Message{Topic: topic, Key: key, Value: value})
+//	}
+type KafkaProducer interface {
+	Produce(ctx context.Context, topic string, key, value []byte) error
+}
+
+// Kafka publishes messages as JSON to a Kafka topic, keyed by job ID so all
+// messages about a job land in the same partition.
+type Kafka struct {
+	producer KafkaProducer
+	topic    string
+}
+
+// NewKafka returns a publisher that writes to topic.
+//
+// Example:
+//
+//	publisher := events.NewKafka(kafkaGo{w: writer}, "screenshots.jobs")
+func NewKafka(producer KafkaProducer, topic string) *Kafka {
+	return &Kafka{producer: producer, topic: topic}
+}
+
+// Publish writes msg to the topic.
+func (k *Kafka) Publish(ctx context.Context, msg JobCompleted) error {
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return k.producer.Produce(ctx, k.topic, []byte(msg.JobID), value)
+}
+
+// NATSConn is the subset of a NATS connection used by NATS; *nats.Conn from
+// github.com/nats-io/nats.go satisfies it.
+type NATSConn interface {
+	Publish(subject string, data []byte) error
+}
+
+// NATS publishes messages as JSON on the subject "<prefix>.<type>", e.g.
+// "screenshots.job.completed", so subscribers can pick message types with
+// wildcards.
+type NATS struct {
+	conn   NATSConn
+	prefix string
+}
+
+// NewNATS returns a publisher that publishes under subject prefix.
+//
+// Example:
+//
+//	nc, _ := nats.Connect(nats.DefaultURL)
+//	publisher := events.NewNATS(nc, "screenshots")
+func NewNATS(conn NATSConn, prefix string) *NATS {
+	return &NATS{conn: conn, prefix: strings.TrimSuffix(prefix, ".")}
+}
+
+// Publish publishes msg.
+func (n *NATS) Publish(ctx context.Context, msg JobCompleted) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if msg.Type == "" {
+		return errors.New("events: message type is required")
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	subject := msg.Type
+	if n.prefix != "" {
+		subject = n.prefix + "." + subject
+	}
+	return n.conn.Publish(subject, data)
+}
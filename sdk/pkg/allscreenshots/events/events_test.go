@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromJob(t *testing.T) {
+	msg, ok := FromJob(&allscreenshots.JobResponse{
+		ID:        "job-1",
+		Status:    allscreenshots.JobStatusCompleted,
+		URL:       "https://example.com",
+		ResultURL: "https://cdn.example.com/job-1.png",
+		Tags:      []string{"nightly"},
+		Storage:   &allscreenshots.StoredObject{URL: "s3://bucket/job-1.png"},
+	})
+	require.True(t, ok)
+	assert.Equal(t, TypeJobCompleted, msg.Type)
+	assert.Equal(t, "s3://bucket/job-1.png", msg.StorageURL)
+	assert.Equal(t, []string{"nightly"}, msg.Tags)
+
+	msg, ok = FromJob(&allscreenshots.JobResponse{ID: "job-2", Status: allscreenshots.JobStatusFailed, ErrorCode: "TIMEOUT"})
+	require.True(t, ok)
+	assert.Equal(t, TypeJobFailed, msg.Type)
+
+	_, ok = FromJob(&allscreenshots.JobResponse{ID: "job-3", Status: allscreenshots.JobStatusProcessing})
+	assert.False(t, ok)
+}
+
+type fakeProducer struct {
+	topic      string
+	key, value []byte
+}
+
+func (p *fakeProducer) Produce(ctx context.Context, topic string, key, value []byte) error {
+	p.topic, p.key, p.value = topic, key, value
+	return nil
+}
+
+type fakeNATS struct {
+	subject string
+	data    []byte
+}
+
+func (n *fakeNATS) Publish(subject string, data []byte) error {
+	n.subject, n.data = subject, data
+	return nil
+}
+
+func TestAdapters(t *testing.T) {
+	msg := JobCompleted{Type: TypeJobCompleted, JobID: "job-1", Status: allscreenshots.JobStatusCompleted}
+
+	producer := &fakeProducer{}
+	require.NoError(t, NewKafka(producer, "screenshots.jobs").Publish(context.Background(), msg))
+	assert.Equal(t, "screenshots.jobs", producer.topic)
+	assert.Equal(t, []byte("job-1"), producer.key)
+	var decoded JobCompleted
+	require.NoError(t, json.Unmarshal(producer.value, &decoded))
+	assert.Equal(t, msg, decoded)
+
+	conn := &fakeNATS{}
+	require.NoError(t, NewNATS(conn, "screenshots.").Publish(context.Background(), msg))
+	assert.Equal(t, "screenshots.job.completed", conn.subject)
+	assert.JSONEq(t, `{"type":"job.completed","jobId":"job-1","status":"COMPLETED","url":""}`, string(conn.data))
+}
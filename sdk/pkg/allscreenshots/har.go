@@ -0,0 +1,79 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// NavigationTimings are the page load metrics recorded when CaptureTimings
+// is set. Each is the time in milliseconds from the start of navigation;
+// metrics the browser did not report are 0.
+type NavigationTimings struct {
+	// TTFBMs is the time to the first byte of the page response
+	TTFBMs int64 `json:"ttfbMs,omitempty"`
+	// FCPMs is the time to the first contentful paint
+	FCPMs int64 `json:"fcpMs,omitempty"`
+	// LCPMs is the time to the largest contentful paint
+	LCPMs int64 `json:"lcpMs,omitempty"`
+	// DOMContentLoadedMs is the time to the DOMContentLoaded event
+	DOMContentLoadedMs int64 `json:"domContentLoadedMs,omitempty"`
+	// LoadMs is the time to the load event
+	LoadMs int64 `json:"loadMs,omitempty"`
+}
+
+// TTFB returns the time to first byte as a time.Duration.
+func (t *NavigationTimings) TTFB() time.Duration {
+	return time.Duration(t.TTFBMs) * time.Millisecond
+}
+
+// FCP returns the time to first contentful paint as a time.Duration.
+func (t *NavigationTimings) FCP() time.Duration {
+	return time.Duration(t.FCPMs) * time.Millisecond
+}
+
+// LCP returns the time to largest contentful paint as a time.Duration.
+func (t *NavigationTimings) LCP() time.Duration {
+	return time.Duration(t.LCPMs) * time.Millisecond
+}
+
+// JobHAR holds the network recording of a job's page load.
+type JobHAR struct {
+	// HAR is the HTTP Archive (HAR 1.2) JSON document, ready to be saved as
+	// a .har file or opened in browser developer tools
+	HAR json.RawMessage `json:"har"`
+	// Timings of the page load, present when the job set CaptureTimings
+	Timings *NavigationTimings `json:"timings,omitempty"`
+}
+
+// GetJobHAR returns the HTTP Archive recorded for a completed job that set
+// CaptureHAR, together with its navigation timings.
+//
+// Example:
+//
+//	job, err := client.ScreenshotAsync(ctx, &allscreenshots.ScreenshotRequest{
+//	    URL:            "https://example.com",
+//	    CaptureHAR:     true,
+//	    CaptureTimings: true,
+//	})
+//	// ... wait for the job to complete ...
+//	har, err := client.GetJobHAR(ctx, job.ID)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	os.WriteFile("page.har", har.HAR, 0644)
+//	fmt.Printf("TTFB %v, LCP %v\n", har.Timings.TTFB(), har.Timings.LCP())
+func (c *Client) GetJobHAR(ctx context.Context, id string) (*JobHAR, error) {
+	if id == "" {
+		return nil, &ValidationError{Field: "id", Message: "job ID is required"}
+	}
+
+	var result JobHAR
+	err := c.request(ctx, http.MethodGet, c.endpoint("/screenshots/jobs/"+url.PathEscape(id)+"/har"), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
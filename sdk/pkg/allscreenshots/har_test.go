@@ -0,0 +1,63 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetJobHAR(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v1/screenshots/jobs/job-123/har", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"har": {"log": {"version": "1.2", "entries": [{"request": {"url": "https://example.com/"}}]}},
+			"timings": {"ttfbMs": 120, "fcpMs": 480, "lcpMs": 1350, "domContentLoadedMs": 600, "loadMs": 1500}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	har, err := client.GetJobHAR(context.Background(), "job-123")
+	require.NoError(t, err)
+
+	var doc struct {
+		Log struct {
+			Version string `json:"version"`
+			Entries []json.RawMessage
+		} `json:"log"`
+	}
+	require.NoError(t, json.Unmarshal(har.HAR, &doc))
+	assert.Equal(t, "1.2", doc.Log.Version)
+	assert.Len(t, doc.Log.Entries, 1)
+
+	require.NotNil(t, har.Timings)
+	assert.Equal(t, 120*time.Millisecond, har.Timings.TTFB())
+	assert.Equal(t, 480*time.Millisecond, har.Timings.FCP())
+	assert.Equal(t, 1350*time.Millisecond, har.Timings.LCP())
+	assert.Equal(t, int64(1500), har.Timings.LoadMs)
+
+	_, err = client.GetJobHAR(context.Background(), "")
+	assert.True(t, IsValidationError(err))
+}
+
+func TestCaptureFlags_JSON(t *testing.T) {
+	data, err := json.Marshal(&ScreenshotRequest{URL: "https://example.com", CaptureHAR: true, CaptureTimings: true})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"url":"https://example.com","captureHar":true,"captureTimings":true}`, string(data))
+
+	var job JobResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"job-1","status":"COMPLETED","timings":{"ttfbMs":95,"lcpMs":900}}`), &job))
+	require.NotNil(t, job.Timings)
+	assert.Equal(t, int64(95), job.Timings.TTFBMs)
+	assert.Equal(t, 900*time.Millisecond, job.Timings.LCP())
+}
@@ -0,0 +1,139 @@
+package allscreenshots
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTMLCaptureRequest represents a request to capture a page's rendered DOM.
+type HTMLCaptureRequest struct {
+	// URL of the page to capture (required)
+	URL string `json:"url"`
+	// Inline embeds stylesheets, images, and fonts into the document, so
+	// the result is a single self-contained file that renders offline
+	Inline bool `json:"inline,omitempty"`
+	// Viewport configuration for custom dimensions
+	Viewport *ViewportConfig `json:"viewport,omitempty"`
+	// Device preset name (e.g., "Desktop HD", "iPhone 14", "iPad")
+	Device string `json:"device,omitempty"`
+	// Delay in milliseconds before capture (0-30000)
+	Delay int `json:"delay,omitempty"`
+	// WaitFor is a CSS selector to wait for before capture
+	WaitFor string `json:"waitFor,omitempty"`
+	// WaitUntil specifies when to consider navigation complete: load, domcontentloaded, networkidle
+	WaitUntil string `json:"waitUntil,omitempty"`
+	// Timeout in milliseconds (1000-60000)
+	Timeout int `json:"timeout,omitempty"`
+	// CustomJS to run in the page before capture (max 10000 chars)
+	CustomJS string `json:"customJs,omitempty"`
+	// BlockAds enables ad blocking
+	BlockAds bool `json:"blockAds,omitempty"`
+	// BlockCookieBanners enables cookie banner blocking
+	BlockCookieBanners bool `json:"blockCookieBanners,omitempty"`
+	// Headers are extra HTTP headers sent with the page request (max 50, 8KB total)
+	Headers map[string]string `json:"headers,omitempty"`
+	// Cookies to set before loading the page (max 50)
+	Cookies []Cookie `json:"cookies,omitempty"`
+	// Auth supplies HTTP basic auth credentials for the target URL
+	Auth *BasicAuth `json:"auth,omitempty"`
+}
+
+// HTMLCaptureResult represents a captured DOM snapshot.
+type HTMLCaptureResult struct {
+	// HTML is the serialized DOM after scripts ran
+	HTML []byte
+	// ContentType of HTML, e.g. "text/html; charset=utf-8"
+	ContentType string
+	// FinalURL is the page URL after redirects, if the API reported it
+	FinalURL string
+	// RateLimit holds the rate limit and quota headers of the response, if any
+	RateLimit *RateLimit
+}
+
+// finalURLHeader carries the page URL after redirects on DOM snapshot
+// responses.
+const finalURLHeader = "X-Final-Url"
+
+// CaptureHTML loads a page in the browser and returns its fully rendered
+// DOM, after scripts ran, instead of an image. With Inline set, external
+// stylesheets, images, and fonts are embedded so the document is a single
+// self-contained file, suitable for archiving or for diffing markup between
+// captures.
+//
+// Example:
+//
+//	snapshot, err := client.CaptureHTML(ctx, &allscreenshots.HTMLCaptureRequest{
+//	    URL:    "https://example.com",
+//	    Inline: true,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	os.WriteFile("example.html", snapshot.HTML, 0644)
+func (c *Client) CaptureHTML(ctx context.Context, req *HTMLCaptureRequest) (*HTMLCaptureResult, error) {
+	if req != nil && c.urlNormalization != nil {
+		normalized := *req
+		normalized.URL = c.urlNormalization.normalize(req.URL)
+		req = &normalized
+	}
+	if err := validateHTMLCaptureRequest(req); err != nil {
+		return nil, err
+	}
+
+	release, err := c.acquireRender(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var result HTMLCaptureResult
+	err = c.requestRaw(ctx, http.MethodPost, c.endpoint("/screenshots/html"), req, func(resp *http.Response) error {
+		data, err := readBody(resp.Body, resp.ContentLength)
+		if err != nil {
+			return err
+		}
+		result.HTML = data
+		result.ContentType = resp.Header.Get("Content-Type")
+		result.FinalURL = resp.Header.Get(finalURLHeader)
+		result.RateLimit = parseRateLimit(resp.Header, time.Now())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// validateHTMLCaptureRequest validates a DOM snapshot request.
+func validateHTMLCaptureRequest(req *HTMLCaptureRequest) error {
+	if req == nil {
+		return &ValidationError{Field: "request", Message: "request cannot be nil"}
+	}
+	if req.URL == "" {
+		return &ValidationError{Field: "url", Message: "URL is required"}
+	}
+	if !strings.HasPrefix(req.URL, "http://") && !strings.HasPrefix(req.URL, "https://") {
+		return &ValidationError{Field: "url", Message: "URL must start with http:// or https://"}
+	}
+	if req.Delay != 0 && (req.Delay < 0 || req.Delay > 30000) {
+		return &ValidationError{Field: "delay", Message: "delay must be between 0 and 30000"}
+	}
+	if req.Timeout != 0 && (req.Timeout < 1000 || req.Timeout > 60000) {
+		return &ValidationError{Field: "timeout", Message: "timeout must be between 1000 and 60000"}
+	}
+	if req.Viewport != nil {
+		if err := validateViewport(req.Viewport); err != nil {
+			return err
+		}
+	}
+	if len(req.CustomJS) > maxCustomCodeLength {
+		return &ValidationError{Field: "customJs", Message: fmt.Sprintf("customJs must be at most %d characters", maxCustomCodeLength)}
+	}
+	if err := validateHeaders(req.Headers); err != nil {
+		return err
+	}
+	return validateCookies(req.Cookies)
+}
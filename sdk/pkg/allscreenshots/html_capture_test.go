@@ -0,0 +1,64 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CaptureHTML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/screenshots/html", r.URL.Path)
+
+		var req HTMLCaptureRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "https://example.com", req.URL)
+		assert.True(t, req.Inline)
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("X-Final-Url", "https://www.example.com/")
+		w.Write([]byte(`<html><head><style>body{margin:0}</style></head><body>Example</body></html>`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	result, err := client.CaptureHTML(context.Background(), &HTMLCaptureRequest{URL: "https://example.com", Inline: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(result.HTML), "<body>Example</body>")
+	assert.Equal(t, "text/html; charset=utf-8", result.ContentType)
+	assert.Equal(t, "https://www.example.com/", result.FinalURL)
+}
+
+func TestValidateHTMLCaptureRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *HTMLCaptureRequest
+		wantErr string
+	}{
+		{name: "valid", req: &HTMLCaptureRequest{URL: "https://example.com", Inline: true}},
+		{name: "nil request", req: nil, wantErr: "request cannot be nil"},
+		{name: "missing URL", req: &HTMLCaptureRequest{}, wantErr: "URL is required"},
+		{name: "invalid scheme", req: &HTMLCaptureRequest{URL: "ftp://example.com"}, wantErr: "URL must start with http:// or https://"},
+		{name: "timeout too short", req: &HTMLCaptureRequest{URL: "https://example.com", Timeout: 500}, wantErr: "timeout must be between 1000 and 60000"},
+		{name: "custom JS too long", req: &HTMLCaptureRequest{URL: "https://example.com", CustomJS: strings.Repeat("x", 10001)}, wantErr: "customJs must be at most 10000 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateHTMLCaptureRequest(tt.req)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
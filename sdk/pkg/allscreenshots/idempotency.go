@@ -0,0 +1,79 @@
+package allscreenshots
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// IdempotencyKeyHeader is the request header that lets the API recognize a
+// retried request it has already processed.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotentActions are POST endpoint suffixes that can safely be repeated:
+// cancelling, pausing, or resuming twice has the same effect as once.
+var idempotentActions = []string{"/cancel", "/pause", "/resume", "/result-url"}
+
+// WithRetryNonIdempotent allows requests that are not idempotent, such as
+// POST /screenshots, to be retried after failures that leave it unknown
+// whether the API processed them: timeouts, dropped connections, and 502
+// or 504 responses. Each such request carries an Idempotency-Key header that
+// stays the same across its retries, so the API can recognize a repeat
+// instead of capturing and charging twice.
+//
+// Without this option those requests are only retried when the API
+// certainly did not process them: the connection could not be established,
+// or the API answered 429 or 503.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(
+//	    allscreenshots.WithRetryNonIdempotent(),
+//	)
+func WithRetryNonIdempotent() ClientOption {
+	return func(c *Client) {
+		c.retryNonIdempotent = true
+	}
+}
+
+// isIdempotentRequest reports whether repeating a request has the same
+// effect as sending it once.
+func isIdempotentRequest(method, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		path, _, _ = strings.Cut(path, "?")
+		for _, action := range idempotentActions {
+			if strings.HasSuffix(path, action) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isUnprocessedStatus reports whether a retryable status means the API
+// rejected the request without processing it.
+func isUnprocessedStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// isConnectError reports whether err occurred before the request was sent,
+// while resolving the host or connecting to it.
+func isConnectError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// newIdempotencyKey returns a random key for IdempotencyKeyHeader.
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
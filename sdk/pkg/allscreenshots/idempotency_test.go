@@ -0,0 +1,104 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsIdempotentRequest(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{http.MethodGet, "/v1/screenshots/jobs/job-1", true},
+		{http.MethodDelete, "/v1/schedules/sched-1", true},
+		{http.MethodPut, "/v1/schedules/sched-1", true},
+		{http.MethodPost, "/v1/screenshots", false},
+		{http.MethodPost, "/v1/screenshots/bulk", false},
+		{http.MethodPost, "/v1/schedules/sched-1/trigger", false},
+		{http.MethodPost, "/v1/screenshots/jobs/job-1/cancel", true},
+		{http.MethodPost, "/v1/schedules/sched-1/pause?reason=x", true},
+		{http.MethodPatch, "/v1/schedules/sched-1", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isIdempotentRequest(tt.method, tt.path), "%s %s", tt.method, tt.path)
+	}
+}
+
+func TestClient_RetrySafety(t *testing.T) {
+	newServer := func(status int) (*httptest.Server, *[]string) {
+		var mu sync.Mutex
+		var keys []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+			mu.Unlock()
+			w.WriteHeader(status)
+		}))
+		return server, &keys
+	}
+	newClient := func(baseURL string, opts ...ClientOption) *Client {
+		return NewClient(append([]ClientOption{
+			WithAPIKey("test-api-key"),
+			WithBaseURL(baseURL),
+			WithMaxRetries(2),
+			WithRetryWait(1*time.Millisecond, 10*time.Millisecond),
+		}, opts...)...)
+	}
+	screenshot := func(c *Client) error {
+		_, err := c.Screenshot(context.Background(), &ScreenshotRequest{URL: "https://example.com"})
+		return err
+	}
+
+	t.Run("POST is not retried after an ambiguous failure", func(t *testing.T) {
+		server, keys := newServer(http.StatusGatewayTimeout)
+		defer server.Close()
+
+		err := screenshot(newClient(server.URL))
+		assert.True(t, IsAPIError(err))
+		assert.Equal(t, []string{""}, *keys)
+	})
+
+	t.Run("POST is retried when the API did not process it", func(t *testing.T) {
+		server, keys := newServer(http.StatusServiceUnavailable)
+		defer server.Close()
+
+		err := screenshot(newClient(server.URL))
+		assert.True(t, IsRetryError(err))
+		assert.Len(t, *keys, 3)
+	})
+
+	t.Run("opt-in retries carry one idempotency key", func(t *testing.T) {
+		server, keys := newServer(http.StatusGatewayTimeout)
+		defer server.Close()
+
+		err := screenshot(newClient(server.URL, WithRetryNonIdempotent()))
+		assert.True(t, IsRetryError(err))
+		require.Len(t, *keys, 3)
+		assert.Len(t, (*keys)[0], 32)
+		assert.Equal(t, (*keys)[0], (*keys)[1])
+		assert.Equal(t, (*keys)[0], (*keys)[2])
+
+		// Each request gets its own key.
+		require.Error(t, screenshot(newClient(server.URL, WithRetryNonIdempotent())))
+		assert.NotEqual(t, (*keys)[0], (*keys)[3])
+	})
+
+	t.Run("GET is retried without a key", func(t *testing.T) {
+		server, keys := newServer(http.StatusGatewayTimeout)
+		defer server.Close()
+
+		_, err := newClient(server.URL, WithRetryNonIdempotent()).GetJob(context.Background(), "job-1")
+		assert.True(t, IsRetryError(err))
+		assert.Equal(t, []string{"", "", ""}, *keys)
+	})
+}
@@ -0,0 +1,151 @@
+// Package imagehash computes perceptual hashes of screenshots.
+//
+// A perceptual hash summarizes what an image looks like in 64 bits. Images
+// that look alike have hashes a small Hamming distance apart, so comparing
+// two hashes is a cheap way to decide whether a page visually changed
+// before running a full pixel diff, storing a capture, or sending an alert.
+package imagehash
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoder
+	_ "image/jpeg" // register JPEG decoder
+	_ "image/png"  // register PNG decoder
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+)
+
+const (
+	// sampleSize is the width and height images are reduced to before hashing.
+	sampleSize = 32
+	// hashSize is the width and height of the low-frequency block kept.
+	hashSize = 8
+)
+
+// Hash is a 64-bit perceptual hash.
+type Hash uint64
+
+// String returns the hash as 16 hexadecimal digits.
+func (h Hash) String() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// ParseHash parses a hash produced by Hash.String.
+func ParseHash(s string) (Hash, error) {
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("imagehash: invalid hash %q", s)
+	}
+	return Hash(v), nil
+}
+
+// PHash decodes a PNG, JPEG, or GIF image and returns its DCT-based
+// perceptual hash.
+//
+// Example:
+//
+//	before, _ := imagehash.PHash(previous)
+//	after, _ := imagehash.PHash(current)
+//	if imagehash.Distance(before, after) > 10 {
+//	    notify("page changed")
+//	}
+func PHash(img []byte) (Hash, error) {
+	decoded, _, err := image.Decode(bytes.NewReader(img))
+	if err != nil {
+		return 0, fmt.Errorf("imagehash: failed to decode image: %w", err)
+	}
+	return PHashImage(decoded), nil
+}
+
+// PHashImage returns the perceptual hash of a decoded image.
+func PHashImage(img image.Image) Hash {
+	pixels := grayscale(img)
+	coeffs := dct(pixels)
+
+	// Keep the lowest frequencies, which describe the overall structure.
+	low := make([]float64, 0, hashSize*hashSize)
+	for y := 0; y < hashSize; y++ {
+		for x := 0; x < hashSize; x++ {
+			low = append(low, coeffs[y][x])
+		}
+	}
+
+	// The DC term is the average brightness, which would dominate the
+	// median, so it is left out when picking the threshold.
+	sorted := append([]float64(nil), low[1:]...)
+	sort.Float64s(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+
+	var h Hash
+	for i, v := range low {
+		if v > median {
+			h |= 1 << uint(len(low)-1-i)
+		}
+	}
+	return h
+}
+
+// Distance returns the number of bits that differ between a and b, from 0
+// (visually identical) to 64. Distances up to about 10 usually mean the
+// images look the same.
+func Distance(a, b Hash) int {
+	return bits.OnesCount64(uint64(a ^ b))
+}
+
+// grayscale reduces img to a sampleSize x sampleSize grid of luminance
+// values by averaging the pixels that fall into each cell.
+func grayscale(img image.Image) [sampleSize][sampleSize]float64 {
+	var out [sampleSize][sampleSize]float64
+	b := img.Bounds()
+	if b.Empty() {
+		return out
+	}
+
+	for cy := 0; cy < sampleSize; cy++ {
+		y0 := b.Min.Y + cy*b.Dy()/sampleSize
+		y1 := max(b.Min.Y+(cy+1)*b.Dy()/sampleSize, y0+1)
+		for cx := 0; cx < sampleSize; cx++ {
+			x0 := b.Min.X + cx*b.Dx()/sampleSize
+			x1 := max(b.Min.X+(cx+1)*b.Dx()/sampleSize, x0+1)
+
+			var sum float64
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					r, g, bl, _ := img.At(x, y).RGBA()
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)
+				}
+			}
+			out[cy][cx] = sum / float64((y1-y0)*(x1-x0)) / 0xffff
+		}
+	}
+	return out
+}
+
+// dct returns the lowest hashSize x hashSize coefficients of the
+// two-dimensional type-II discrete cosine transform of pixels.
+func dct(pixels [sampleSize][sampleSize]float64) [hashSize][hashSize]float64 {
+	var cos [hashSize][sampleSize]float64
+	for u := 0; u < hashSize; u++ {
+		for x := 0; x < sampleSize; x++ {
+			cos[u][x] = math.Cos(float64(2*x+1) * float64(u) * math.Pi / (2 * sampleSize))
+		}
+	}
+
+	var out [hashSize][hashSize]float64
+	for v := 0; v < hashSize; v++ {
+		for u := 0; u < hashSize; u++ {
+			var sum float64
+			for y := 0; y < sampleSize; y++ {
+				for x := 0; x < sampleSize; x++ {
+					sum += pixels[y][x] * cos[u][x] * cos[v][y]
+				}
+			}
+			out[v][u] = sum
+		}
+	}
+	return out
+}
@@ -0,0 +1,75 @@
+package imagehash
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// page draws a fake page layout: a dark header and a block of content.
+func page(w, h int, contentX int, tint uint8) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 255, G: 255, B: 255 - tint, A: 255}
+			switch {
+			case y < h/8:
+				c = color.RGBA{R: 30, G: 30, B: 60, A: 255}
+			case x >= contentX && x < contentX+w/3 && y > h/4 && y < h*3/4:
+				c = color.RGBA{R: 200, G: 60, B: 60, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
+func TestPHash(t *testing.T) {
+	original, err := PHash(page(320, 240, 20, 0))
+	require.NoError(t, err)
+
+	// The same layout at another size or with a slight tint looks the same.
+	resized, err := PHash(page(640, 480, 40, 0))
+	require.NoError(t, err)
+	tinted, err := PHash(page(320, 240, 20, 8))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, Distance(original, resized), 4)
+	assert.LessOrEqual(t, Distance(original, tinted), 4)
+
+	// Moving the content block is a visible change.
+	moved, err := PHash(page(320, 240, 200, 0))
+	require.NoError(t, err)
+	assert.Greater(t, Distance(original, moved), 10)
+}
+
+func TestHash_String(t *testing.T) {
+	h := Hash(0x00ff00ff00ff00ff)
+	assert.Equal(t, "00ff00ff00ff00ff", h.String())
+
+	parsed, err := ParseHash(h.String())
+	require.NoError(t, err)
+	assert.Equal(t, h, parsed)
+
+	_, err = ParseHash("zz")
+	assert.Error(t, err)
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, Distance(0xabc, 0xabc))
+	assert.Equal(t, 64, Distance(0, ^Hash(0)))
+	assert.Equal(t, 2, Distance(0b1010, 0b0110))
+}
+
+func TestPHash_InvalidImage(t *testing.T) {
+	_, err := PHash([]byte("not an image"))
+	assert.ErrorContains(t, err, "failed to decode image")
+}
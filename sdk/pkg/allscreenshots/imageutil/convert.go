@@ -0,0 +1,101 @@
+package imageutil
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"sync"
+)
+
+// Encoder writes img to w in some format. quality is 1-100, or 0 for the
+// encoder's default; encoders of lossless formats ignore it.
+type Encoder func(w io.Writer, img image.Image, quality int) error
+
+var (
+	encodersMu sync.RWMutex
+	encoders   = map[Format]Encoder{
+		FormatPNG:  pngEncoder,
+		FormatJPEG: jpegEncoder,
+		FormatGIF:  gifEncoder,
+	}
+)
+
+// RegisterEncoder makes Convert able to produce format, or replaces the
+// encoder used for it. The standard library has no WebP encoder, so
+// converting to WebP requires registering one:
+//
+//	imageutil.RegisterEncoder(imageutil.FormatWebP, func(w io.Writer, img image.Image, quality int) error {
+//	    return webp.Encode(w, img, &webp.Options{Quality: float32(quality)})
+//	})
+func RegisterEncoder(format Format, enc Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+	encoders[format] = enc
+}
+
+// Convert re-encodes an image in another format locally, so one capture can
+// serve several output formats without additional API requests. quality
+// (1-100, or 0 for the default) applies to lossy formats. Transparent areas
+// are flattened onto white when converting to JPEG.
+//
+// Example:
+//
+//	png, _ := client.Screenshot(ctx, &allscreenshots.ScreenshotRequest{URL: "https://example.com"})
+//	thumb, err := imageutil.Convert(png, imageutil.FormatJPEG, 80)
+func Convert(data []byte, to Format, quality int) ([]byte, error) {
+	if quality < 0 || quality > 100 {
+		return nil, fmt.Errorf("imageutil: quality must be between 0 and 100")
+	}
+	encodersMu.RLock()
+	enc, ok := encoders[to]
+	encodersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("imageutil: no encoder registered for %q", to)
+	}
+
+	if Sniff(data) == to && quality == 0 {
+		return data, nil
+	}
+	img, _, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := enc(&buf, img, quality); err != nil {
+		return nil, fmt.Errorf("imageutil: failed to encode %s: %w", to, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func pngEncoder(w io.Writer, img image.Image, quality int) error {
+	return png.Encode(w, img)
+}
+
+func jpegEncoder(w io.Writer, img image.Image, quality int) error {
+	if quality == 0 {
+		quality = jpeg.DefaultQuality
+	}
+	return jpeg.Encode(w, flatten(img), &jpeg.Options{Quality: quality})
+}
+
+func gifEncoder(w io.Writer, img image.Image, quality int) error {
+	return gif.Encode(w, img, nil)
+}
+
+// flatten draws img over a white background, since JPEG has no alpha channel.
+func flatten(img image.Image) image.Image {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && opaque.Opaque() {
+		return img
+	}
+	out := image.NewRGBA(img.Bounds())
+	draw.Draw(out, out.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(out, out.Bounds(), img, img.Bounds().Min, draw.Over)
+	return out
+}
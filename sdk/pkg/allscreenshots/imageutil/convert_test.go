@@ -0,0 +1,78 @@
+package imageutil
+
+import (
+	"bytes"
+	"image"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	src := encodePNG(t, testImage(40, 30))
+
+	jpg, err := Convert(src, FormatJPEG, 85)
+	require.NoError(t, err)
+	assert.Equal(t, FormatJPEG, Sniff(jpg))
+	w, h, err := Dimensions(jpg)
+	require.NoError(t, err)
+	assert.Equal(t, [2]int{40, 30}, [2]int{w, h})
+
+	back, err := Convert(jpg, FormatPNG, 0)
+	require.NoError(t, err)
+	assert.Equal(t, FormatPNG, Sniff(back))
+
+	gif, err := Convert(src, FormatGIF, 0)
+	require.NoError(t, err)
+	assert.Equal(t, FormatGIF, Sniff(gif))
+
+	same, err := Convert(src, FormatPNG, 0)
+	require.NoError(t, err)
+	assert.Equal(t, src, same)
+}
+
+func TestConvert_FlattensTransparency(t *testing.T) {
+	transparent := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	jpg, err := Convert(encodePNG(t, transparent), FormatJPEG, 100)
+	require.NoError(t, err)
+
+	img, _, err := Decode(jpg)
+	require.NoError(t, err)
+	r, g, b, _ := img.At(4, 4).RGBA()
+	assert.Greater(t, r, uint32(0xf000))
+	assert.Greater(t, g, uint32(0xf000))
+	assert.Greater(t, b, uint32(0xf000))
+}
+
+func TestConvert_Errors(t *testing.T) {
+	src := encodePNG(t, testImage(4, 4))
+
+	_, err := Convert(src, FormatJPEG, 101)
+	assert.ErrorContains(t, err, "quality must be between 0 and 100")
+	_, err = Convert(src, FormatPDF, 0)
+	assert.ErrorContains(t, err, `no encoder registered for "pdf"`)
+	_, err = Convert([]byte("%PDF-1.7"), FormatPNG, 0)
+	assert.ErrorIs(t, err, ErrPDF)
+}
+
+func TestRegisterEncoder(t *testing.T) {
+	defer func() {
+		encodersMu.Lock()
+		delete(encoders, FormatWebP)
+		encodersMu.Unlock()
+	}()
+
+	var gotQuality int
+	RegisterEncoder(FormatWebP, func(w io.Writer, img image.Image, quality int) error {
+		gotQuality = quality
+		_, err := w.Write(webpHeader("VP8L", nil))
+		return err
+	})
+
+	out, err := Convert(encodePNG(t, testImage(4, 4)), FormatWebP, 70)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(out, []byte("RIFF")))
+	assert.Equal(t, 70, gotQuality)
+}
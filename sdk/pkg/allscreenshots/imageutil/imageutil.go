@@ -0,0 +1,156 @@
+// Package imageutil inspects and decodes screenshot results.
+//
+// PNG, JPEG, and GIF are decoded with the standard library. WebP is always
+// recognized and its dimensions are read from the file header, but decoding
+// WebP pixels requires a decoder to be registered with the image package,
+// for example by importing golang.org/x/image/webp:
+//
+//	import _ "golang.org/x/image/webp"
+package imageutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoder
+	_ "image/jpeg" // register JPEG decoder
+	_ "image/png"  // register PNG decoder
+)
+
+// Format is an output format recognized by this package.
+type Format string
+
+// Formats recognized by Sniff.
+const (
+	FormatPNG  Format = "png"
+	FormatJPEG Format = "jpeg"
+	FormatGIF  Format = "gif"
+	FormatWebP Format = "webp"
+	FormatPDF  Format = "pdf"
+)
+
+var (
+	// ErrPDF is returned when a PDF is passed where an image is expected.
+	ErrPDF = errors.New("imageutil: data is a PDF, not an image")
+	// ErrUnknownFormat is returned when data is not a recognized image.
+	ErrUnknownFormat = errors.New("imageutil: unknown image format")
+)
+
+// Sniff returns the format of data judging by its leading bytes, or "" if
+// it is not recognized.
+func Sniff(data []byte) Format {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return FormatPNG
+	case bytes.HasPrefix(data, []byte{0xff, 0xd8, 0xff}):
+		return FormatJPEG
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return FormatGIF
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return FormatWebP
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		return FormatPDF
+	}
+	return ""
+}
+
+// Decode decodes an image and returns it with its format name, like
+// image.Decode. It returns ErrPDF for PDFs and ErrUnknownFormat for data
+// that is not a recognized image.
+//
+// Example:
+//
+//	img, format, err := imageutil.Decode(data)
+//	if errors.Is(err, imageutil.ErrPDF) {
+//	    // handle the PDF separately
+//	}
+func Decode(data []byte) (image.Image, string, error) {
+	format, err := check(data)
+	if err != nil {
+		return nil, "", err
+	}
+	img, name, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", decodeError(format, err)
+	}
+	return img, name, nil
+}
+
+// Dimensions returns the width and height of an image in pixels without
+// decoding its pixels. It works for WebP even when no WebP decoder is
+// registered.
+//
+// Example:
+//
+//	width, height, err := imageutil.Dimensions(screenshot)
+//	if err == nil && height > 10000 {
+//	    log.Printf("very long page: %dx%d", width, height)
+//	}
+func Dimensions(data []byte) (width, height int, err error) {
+	format, err := check(data)
+	if err != nil {
+		return 0, 0, err
+	}
+	if format == FormatWebP {
+		return webpDimensions(data)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, decodeError(format, err)
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// check sniffs data and rejects PDFs and unrecognized data.
+func check(data []byte) (Format, error) {
+	format := Sniff(data)
+	switch format {
+	case "":
+		return "", ErrUnknownFormat
+	case FormatPDF:
+		return "", ErrPDF
+	}
+	return format, nil
+}
+
+// decodeError explains a decoding failure.
+func decodeError(format Format, err error) error {
+	if format == FormatWebP && errors.Is(err, image.ErrFormat) {
+		return errors.New("imageutil: no WebP decoder registered; import golang.org/x/image/webp")
+	}
+	return fmt.Errorf("imageutil: failed to decode %s: %w", format, err)
+}
+
+// webpDimensions reads the canvas size from a WebP header.
+func webpDimensions(data []byte) (int, int, error) {
+	if len(data) < 30 {
+		return 0, 0, errors.New("imageutil: truncated WebP header")
+	}
+	chunk := data[12:16]
+	payload := data[20:]
+	switch string(chunk) {
+	case "VP8 ":
+		// Lossy: a 3-byte frame tag, the start code, then 14-bit dimensions.
+		if payload[3] != 0x9d || payload[4] != 0x01 || payload[5] != 0x2a {
+			return 0, 0, errors.New("imageutil: invalid VP8 frame")
+		}
+		w := int(binary.LittleEndian.Uint16(payload[6:8]) & 0x3fff)
+		h := int(binary.LittleEndian.Uint16(payload[8:10]) & 0x3fff)
+		return w, h, nil
+	case "VP8L":
+		// Lossless: a signature byte, then 14-bit width-1 and height-1.
+		if payload[0] != 0x2f {
+			return 0, 0, errors.New("imageutil: invalid VP8L signature")
+		}
+		bits := binary.LittleEndian.Uint32(payload[1:5])
+		return int(bits&0x3fff) + 1, int((bits>>14)&0x3fff) + 1, nil
+	case "VP8X":
+		// Extended: flags and reserved bytes, then 24-bit width-1 and height-1.
+		w := int(uint32(payload[4])|uint32(payload[5])<<8|uint32(payload[6])<<16) + 1
+		h := int(uint32(payload[7])|uint32(payload[8])<<8|uint32(payload[9])<<16) + 1
+		return w, h, nil
+	}
+	return 0, 0, fmt.Errorf("imageutil: unknown WebP chunk %q", chunk)
+}
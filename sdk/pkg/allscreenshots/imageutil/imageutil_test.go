@@ -0,0 +1,104 @@
+package imageutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+// webpHeader builds the first bytes of a WebP file with the given chunk.
+func webpHeader(chunk string, payload []byte) []byte {
+	data := []byte("RIFF\x00\x00\x00\x00WEBP" + chunk)
+	size := make([]byte, 4)
+	binary.LittleEndian.PutUint32(size, uint32(len(payload)))
+	data = append(data, size...)
+	return append(data, payload...)
+}
+
+func TestSniff(t *testing.T) {
+	assert.Equal(t, FormatPNG, Sniff(encodePNG(t, testImage(2, 2))))
+	assert.Equal(t, FormatJPEG, Sniff(encodeJPEG(t, testImage(2, 2))))
+	assert.Equal(t, FormatGIF, Sniff([]byte("GIF89a...")))
+	assert.Equal(t, FormatWebP, Sniff(webpHeader("VP8 ", nil)))
+	assert.Equal(t, FormatPDF, Sniff([]byte("%PDF-1.7\n")))
+	assert.Equal(t, Format(""), Sniff([]byte("<html>")))
+}
+
+func TestDecode(t *testing.T) {
+	img, format, err := Decode(encodePNG(t, testImage(40, 30)))
+	require.NoError(t, err)
+	assert.Equal(t, "png", format)
+	assert.Equal(t, image.Rect(0, 0, 40, 30), img.Bounds())
+
+	_, format, err = Decode(encodeJPEG(t, testImage(40, 30)))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+
+	_, _, err = Decode([]byte("%PDF-1.7\n"))
+	assert.ErrorIs(t, err, ErrPDF)
+	_, _, err = Decode([]byte("not an image"))
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+	_, _, err = Decode(webpHeader("VP8L", []byte{0x2f, 0, 0, 0, 0, 0, 0, 0, 0, 0}))
+	assert.ErrorContains(t, err, "no WebP decoder registered")
+}
+
+func TestDimensions(t *testing.T) {
+	w, h, err := Dimensions(encodePNG(t, testImage(40, 30)))
+	require.NoError(t, err)
+	assert.Equal(t, [2]int{40, 30}, [2]int{w, h})
+
+	w, h, err = Dimensions(encodeJPEG(t, testImage(17, 9)))
+	require.NoError(t, err)
+	assert.Equal(t, [2]int{17, 9}, [2]int{w, h})
+
+	// Lossy WebP: frame tag, start code, 14-bit width and height.
+	lossy := webpHeader("VP8 ", []byte{0, 0, 0, 0x9d, 0x01, 0x2a, 0x80, 0x07, 0x38, 0x04})
+	w, h, err = Dimensions(lossy)
+	require.NoError(t, err)
+	assert.Equal(t, [2]int{1920, 1080}, [2]int{w, h})
+
+	// Lossless WebP: width-1 and height-1 packed into 14-bit fields.
+	bits := uint32(1280-1) | uint32(720-1)<<14
+	lossless := webpHeader("VP8L", append([]byte{0x2f}, binary.LittleEndian.AppendUint32(nil, bits)...))
+	w, h, err = Dimensions(append(lossless, 0, 0, 0, 0, 0))
+	require.NoError(t, err)
+	assert.Equal(t, [2]int{1280, 720}, [2]int{w, h})
+
+	// Extended WebP: 24-bit width-1 and height-1.
+	extended := webpHeader("VP8X", []byte{0, 0, 0, 0, 0x7f, 0x0c, 0, 0xff, 0x3f, 0})
+	w, h, err = Dimensions(extended)
+	require.NoError(t, err)
+	assert.Equal(t, [2]int{3200, 16384}, [2]int{w, h})
+
+	_, _, err = Dimensions([]byte("%PDF-1.7\n"))
+	assert.ErrorIs(t, err, ErrPDF)
+}
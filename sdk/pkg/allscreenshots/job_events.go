@@ -0,0 +1,273 @@
+package allscreenshots
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxEventSize is the largest server-sent event accepted by SubscribeJobEvents.
+const maxEventSize = 1 << 20
+
+// SubscribeOptions filters the events delivered by SubscribeJobEvents.
+type SubscribeOptions struct {
+	// JobIDs limits events to these jobs; empty means all jobs
+	JobIDs []string
+	// Statuses limits events to jobs entering these statuses; empty means all statuses
+	Statuses []JobStatus
+	// LastEventID resumes a previous subscription after this event
+	LastEventID string
+}
+
+// JobEvent represents a job status change delivered by SubscribeJobEvents.
+type JobEvent struct {
+	// ID of the event; pass it as SubscribeOptions.LastEventID to resume
+	ID string
+	// Type of the event, e.g. "job.updated"
+	Type string
+	// Job is the job's state after the change
+	Job *JobResponse
+	// Err is set on the final event when the subscription ended because of an
+	// error that reconnecting cannot fix, such as an invalid API key
+	Err error
+}
+
+// SubscribeJobEvents streams job status changes from the API's server-sent
+// events endpoint. The subscription reconnects automatically after network
+// failures, resuming from the last event received, so no events are missed.
+//
+// The returned channel is closed when ctx is cancelled or when the
+// subscription fails permanently; in the latter case the last event carries
+// the error in Err. An error is returned directly if the first connection
+// cannot be established.
+//
+// Example:
+//
+//	events, err := client.SubscribeJobEvents(ctx, allscreenshots.SubscribeOptions{
+//	    Statuses: []allscreenshots.JobStatus{allscreenshots.JobStatusCompleted, allscreenshots.JobStatusFailed},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for event := range events {
+//	    if event.Err != nil {
+//	        log.Fatal(event.Err)
+//	    }
+//	    fmt.Printf("%s is now %s\n", event.Job.ID, event.Job.Status)
+//	}
+func (c *Client) SubscribeJobEvents(ctx context.Context, opts SubscribeOptions) (<-chan JobEvent, error) {
+	if c.apiKey == "" {
+		return nil, &ValidationError{Field: "apiKey", Message: "API key is required"}
+	}
+
+	// The stream stays open indefinitely, so it must not be subject to the
+	// client's overall request timeout.
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	s := &jobEventStream{
+		client:      c,
+		httpClient:  &httpClient,
+		opts:        opts,
+		lastEventID: opts.LastEventID,
+	}
+	done, err := c.track()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.closable(ctx)
+	resp, err := s.connect(ctx)
+	if err != nil {
+		cancel()
+		done()
+		return nil, err
+	}
+
+	events := make(chan JobEvent)
+	go func() {
+		defer done()
+		defer cancel()
+		s.run(ctx, resp, events)
+	}()
+	return events, nil
+}
+
+// jobEventStream holds the state of a job event subscription across reconnects.
+type jobEventStream struct {
+	client      *Client
+	httpClient  *http.Client
+	opts        SubscribeOptions
+	lastEventID string
+	retry       time.Duration
+}
+
+// connect opens the event stream, resuming after lastEventID.
+func (s *jobEventStream) connect(ctx context.Context) (*http.Response, error) {
+	params := url.Values{}
+	if len(s.opts.JobIDs) > 0 {
+		params.Set("jobIds", strings.Join(s.opts.JobIDs, ","))
+	}
+	if len(s.opts.Statuses) > 0 {
+		statuses := make([]string, len(s.opts.Statuses))
+		for i, status := range s.opts.Statuses {
+			statuses[i] = string(status)
+		}
+		params.Set("statuses", strings.Join(statuses, ","))
+	}
+	reqURL := s.client.baseURL + s.client.endpoint("/screenshots/jobs/events")
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to create request: %w", err)
+	}
+	s.client.setContextHeaders(ctx, req.Header)
+	req.Header.Set("X-API-Key", s.client.apiKey)
+	if s.client.project != "" {
+		req.Header.Set(ProjectHeader, s.client.project)
+	}
+	req.Header.Set("User-Agent", s.client.userAgentHeader())
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if s.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", s.lastEventID)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, &NetworkError{Message: "event stream connection failed", Cause: err}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, s.client.parseErrorResponse(resp)
+	}
+	return resp, nil
+}
+
+// run delivers events from resp, reconnecting until ctx is done or a
+// permanent error occurs.
+func (s *jobEventStream) run(ctx context.Context, resp *http.Response, events chan<- JobEvent) {
+	defer close(events)
+
+	for {
+		err := s.read(ctx, resp, events)
+		resp.Body.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && !isReconnectable(err) {
+			s.send(ctx, events, JobEvent{Err: err})
+			return
+		}
+
+		var prevWait time.Duration
+		for attempt := 1; ; attempt++ {
+			wait := s.retry
+			if wait == 0 {
+				wait = s.client.calculateBackoff(attempt, prevWait)
+				prevWait = wait
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+
+			resp, err = s.connect(ctx)
+			if err == nil {
+				break
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if !isReconnectable(err) {
+				s.send(ctx, events, JobEvent{Err: err})
+				return
+			}
+		}
+	}
+}
+
+// read parses server-sent events from resp until the stream ends.
+func (s *jobEventStream) read(ctx context.Context, resp *http.Response, events chan<- JobEvent) error {
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+
+	var id, eventType string
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if data.Len() > 0 {
+				if id != "" {
+					s.lastEventID = id
+				}
+				// Events that are not jobs (e.g. future event types) are skipped.
+				var job JobResponse
+				if err := json.Unmarshal([]byte(data.String()), &job); err == nil && job.ID != "" {
+					event := JobEvent{ID: id, Type: eventType, Job: &job}
+					if event.Type == "" {
+						event.Type = "message"
+					}
+					if !s.send(ctx, events, event) {
+						return ctx.Err()
+					}
+				}
+			}
+			eventType = ""
+			data.Reset()
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id = value
+		case "event":
+			eventType = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				s.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return &NetworkError{Message: "event stream interrupted", Cause: err}
+	}
+	return nil
+}
+
+// send delivers event unless ctx is done first.
+func (s *jobEventStream) send(ctx context.Context, events chan<- JobEvent, event JobEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// isReconnectable reports whether a subscription error may clear up on reconnect.
+func isReconnectable(err error) bool {
+	switch e := err.(type) {
+	case *NetworkError:
+		return true
+	case *APIError:
+		return isRetryableStatus(e.StatusCode) || e.StatusCode >= 500
+	}
+	return false
+}
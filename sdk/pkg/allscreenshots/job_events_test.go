@@ -0,0 +1,118 @@
+package allscreenshots
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SubscribeJobEvents(t *testing.T) {
+	var mu sync.Mutex
+	var lastEventIDs []string
+	connections := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots/jobs/events", r.URL.Path)
+		assert.Equal(t, "job-1,job-2", r.URL.Query().Get("jobIds"))
+		assert.Equal(t, "COMPLETED", r.URL.Query().Get("statuses"))
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+
+		mu.Lock()
+		connections++
+		n := connections
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch n {
+		case 1:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			fmt.Fprint(w, "retry: 5\nid: 1\nevent: job.updated\ndata: {\"id\":\"job-1\",\n")
+			fmt.Fprint(w, "data: \"status\":\"COMPLETED\"}\n\n")
+			// Connection drops here; the client must resume after event 1.
+		case 2:
+			fmt.Fprint(w, "id: 2\nevent: job.updated\ndata: {\"id\":\"job-2\",\"status\":\"COMPLETED\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := client.SubscribeJobEvents(ctx, SubscribeOptions{
+		JobIDs:   []string{"job-1", "job-2"},
+		Statuses: []JobStatus{JobStatusCompleted},
+	})
+	require.NoError(t, err)
+
+	first := <-events
+	require.NoError(t, first.Err)
+	assert.Equal(t, "1", first.ID)
+	assert.Equal(t, "job.updated", first.Type)
+	assert.Equal(t, "job-1", first.Job.ID)
+	assert.Equal(t, JobStatusCompleted, first.Job.Status)
+
+	second := <-events
+	require.NoError(t, second.Err)
+	assert.Equal(t, "job-2", second.Job.ID)
+
+	cancel()
+	_, open := <-events
+	assert.False(t, open, "channel must close after cancellation")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"", "1"}, lastEventIDs)
+}
+
+func TestClient_SubscribeJobEvents_Errors(t *testing.T) {
+	t.Run("returns initial connection errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		client := NewClient(WithAPIKey("bad-key"), WithBaseURL(server.URL))
+		_, err := client.SubscribeJobEvents(context.Background(), SubscribeOptions{})
+		require.Error(t, err)
+		assert.True(t, IsUnauthorized(err))
+	})
+
+	t.Run("reports permanent errors after reconnecting", func(t *testing.T) {
+		connections := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			connections++
+			if connections > 1 {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+		}))
+		defer server.Close()
+
+		client := NewClient(
+			WithAPIKey("test-api-key"),
+			WithBaseURL(server.URL),
+			WithRetryWait(time.Millisecond, 10*time.Millisecond),
+		)
+		events, err := client.SubscribeJobEvents(context.Background(), SubscribeOptions{})
+		require.NoError(t, err)
+
+		event := <-events
+		require.Error(t, event.Err)
+		assert.True(t, IsForbidden(event.Err))
+		_, open := <-events
+		assert.False(t, open)
+	})
+}
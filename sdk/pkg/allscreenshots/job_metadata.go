@@ -0,0 +1,86 @@
+package allscreenshots
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// JobMetadata describes the result of a completed job.
+type JobMetadata struct {
+	// Width of the result in pixels
+	Width int `json:"width,omitempty"`
+	// Height of the result in pixels
+	Height int `json:"height,omitempty"`
+	// FileSize of the result in bytes
+	FileSize int64 `json:"fileSize,omitempty"`
+	// RenderTimeMs is how long the capture took in milliseconds
+	RenderTimeMs int64 `json:"renderTimeMs,omitempty"`
+	// Format of the result, e.g. "png"
+	Format string `json:"format,omitempty"`
+	// Extra holds metadata keys not covered by the fields above
+	Extra map[string]interface{} `json:"-"`
+}
+
+// jobMetadataFields aliases JobMetadata without its JSON methods.
+type jobMetadataFields JobMetadata
+
+// UnmarshalJSON decodes the known fields and keeps every other key in Extra.
+func (m *JobMetadata) UnmarshalJSON(data []byte) error {
+	var fields jobMetadataFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	extra, err := unknownJSONFields(data, fields)
+	if err != nil {
+		return err
+	}
+	fields.Extra = extra
+	*m = JobMetadata(fields)
+	return nil
+}
+
+// MarshalJSON encodes the known fields together with the keys in Extra.
+func (m JobMetadata) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(jobMetadataFields(m))
+	if err != nil {
+		return nil, err
+	}
+	return mergeJSONFields(data, m.Extra)
+}
+
+// unknownJSONFields returns the keys of the JSON object in data that do not
+// correspond to a field of the struct v, or nil if there are none.
+func unknownJSONFields(data []byte, v interface{}) (map[string]interface{}, error) {
+	var all map[string]interface{}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			delete(all, name)
+		}
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
+
+// mergeJSONFields adds the keys in extra to the JSON object in data. Keys
+// already present in data take precedence.
+func mergeJSONFields(data []byte, extra map[string]interface{}) ([]byte, error) {
+	if len(extra) == 0 {
+		return data, nil
+	}
+	all := make(map[string]interface{}, len(extra))
+	for k, v := range extra {
+		all[k] = v
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	return json.Marshal(all)
+}
@@ -0,0 +1,34 @@
+package allscreenshots
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobMetadata_JSON(t *testing.T) {
+	input := `{"id":"job-1","status":"COMPLETED","metadata":{"width":1920,"height":1080,"fileSize":245120,"renderTimeMs":1830,"format":"png","region":"eu-west-1","retries":1}}`
+
+	var job JobResponse
+	require.NoError(t, json.Unmarshal([]byte(input), &job))
+	require.NotNil(t, job.Metadata)
+	assert.Equal(t, 1920, job.Metadata.Width)
+	assert.Equal(t, 1080, job.Metadata.Height)
+	assert.Equal(t, int64(245120), job.Metadata.FileSize)
+	assert.Equal(t, int64(1830), job.Metadata.RenderTimeMs)
+	assert.Equal(t, "png", job.Metadata.Format)
+	assert.Equal(t, map[string]interface{}{"region": "eu-west-1", "retries": float64(1)}, job.Metadata.Extra)
+
+	out, err := json.Marshal(job.Metadata)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"width":1920,"height":1080,"fileSize":245120,"renderTimeMs":1830,"format":"png","region":"eu-west-1","retries":1}`, string(out))
+}
+
+func TestJobMetadata_NoExtra(t *testing.T) {
+	var m JobMetadata
+	require.NoError(t, json.Unmarshal([]byte(`{"width":800}`), &m))
+	assert.Equal(t, 800, m.Width)
+	assert.Nil(t, m.Extra)
+}
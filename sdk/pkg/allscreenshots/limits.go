@@ -0,0 +1,66 @@
+package allscreenshots
+
+import (
+	"io"
+	"net/http"
+)
+
+// maxErrorBodySize is the most bytes of an error response that are read
+// when parsing it.
+const maxErrorBodySize = 64 << 10
+
+// WithMaxResponseSize limits the size of response bodies to maxBytes, so a
+// misbehaving endpoint or an unexpectedly large full-page capture cannot
+// exhaust memory. Responses whose Content-Length exceeds the limit fail
+// before any of the body is read; otherwise reading stops with a
+// ResponseTooLargeError once the limit is passed. The limit applies to
+// every response, including streamed ones. Zero (the default) means no
+// limit.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(allscreenshots.WithMaxResponseSize(50 << 20))
+func WithMaxResponseSize(maxBytes int64) ClientOption {
+	return func(c *Client) {
+		c.maxResponseSize = maxBytes
+	}
+}
+
+// limitResponse enforces the client's response size limit on resp.
+func (c *Client) limitResponse(resp *http.Response) error {
+	if c.maxResponseSize <= 0 {
+		return nil
+	}
+	if resp.ContentLength > c.maxResponseSize {
+		return &ResponseTooLargeError{Limit: c.maxResponseSize, Size: resp.ContentLength}
+	}
+	resp.Body = &limitedBody{ReadCloser: resp.Body, limit: c.maxResponseSize, remaining: c.maxResponseSize}
+	return nil
+}
+
+// limitedBody fails reads with a ResponseTooLargeError once more than limit
+// bytes have been read.
+type limitedBody struct {
+	io.ReadCloser
+	limit     int64
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, &ResponseTooLargeError{Limit: b.limit, Size: -1}
+	}
+	// Read one byte past the limit to tell a body of exactly limit bytes
+	// from a larger one.
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		n = int(b.remaining)
+		b.remaining = -1
+		return n, &ResponseTooLargeError{Limit: b.limit, Size: -1}
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,78 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithMaxResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		size := map[string]int{"/small": 100, "/exact": 1024, "/large": 4096}[r.URL.Path]
+		w.Header().Set("Content-Type", "image/png")
+		if r.URL.Query().Get("chunked") != "" {
+			// Flushing first forces chunked encoding, so no Content-Length is sent.
+			w.(http.Flusher).Flush()
+		} else {
+			w.Header().Set("Content-Length", strconv.Itoa(size))
+		}
+		w.Write([]byte(strings.Repeat("x", size)))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithMaxResponseSize(1024))
+
+	tests := []struct {
+		name     string
+		path     string
+		wantSize int64
+		wantErr  bool
+	}{
+		{name: "under limit", path: "/small"},
+		{name: "exactly at limit", path: "/exact"},
+		{name: "exactly at limit, chunked", path: "/exact?chunked=1"},
+		{name: "declared length over limit", path: "/large", wantSize: 4096, wantErr: true},
+		{name: "chunked body over limit", path: "/large?chunked=1", wantSize: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := client.requestBinary(context.Background(), http.MethodGet, tt.path, nil)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				assert.NotEmpty(t, data)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, IsResponseTooLargeError(err))
+			tooLarge := err.(*ResponseTooLargeError)
+			assert.Equal(t, int64(1024), tooLarge.Limit)
+			assert.Equal(t, tt.wantSize, tooLarge.Size)
+		})
+	}
+}
+
+func TestParseErrorResponse_CapsBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		// A message larger than the cap cannot be decoded, so the status
+		// text is used instead.
+		w.Write([]byte(`{"message":"` + strings.Repeat("x", maxErrorBodySize) + `"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	err := client.request(context.Background(), http.MethodGet, "/bad", nil, nil)
+
+	apiErr, ok := AsAPIError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "Bad Request", apiErr.Message)
+}
@@ -4,7 +4,13 @@
 // with various options for viewport, device emulation, and output format.
 package allscreenshots
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // ViewportConfig represents viewport dimensions and scale factor.
 type ViewportConfig struct {
@@ -16,18 +22,226 @@ type ViewportConfig struct {
 	DeviceScaleFactor int `json:"deviceScaleFactor,omitempty"`
 }
 
+// Cookie represents a cookie set on the target page before capture.
+type Cookie struct {
+	// Name of the cookie (required)
+	Name string `json:"name"`
+	// Value of the cookie
+	Value string `json:"value"`
+	// Domain the cookie applies to; defaults to the target URL's host
+	Domain string `json:"domain,omitempty"`
+	// Path the cookie applies to; defaults to "/"
+	Path string `json:"path,omitempty"`
+	// Secure restricts the cookie to HTTPS
+	Secure bool `json:"secure,omitempty"`
+}
+
+// BasicAuth holds HTTP basic auth credentials for the captured URL.
+//
+// String and GoString redact the password so credentials do not leak into
+// logs; the password is still sent to the API.
+type BasicAuth struct {
+	// Username for basic auth (required)
+	Username string `json:"username"`
+	// Password for basic auth
+	Password string `json:"password"`
+}
+
+// String returns the credentials with the password redacted.
+func (a BasicAuth) String() string {
+	return fmt.Sprintf("{Username:%s Password:%s}", a.Username, redacted(a.Password))
+}
+
+// GoString returns the credentials with the password redacted.
+func (a BasicAuth) GoString() string {
+	return fmt.Sprintf("allscreenshots.BasicAuth{Username:%q, Password:%q}", a.Username, redacted(a.Password))
+}
+
+// redacted masks a secret value for display.
+func redacted(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// CSS media types for ScreenshotRequest.MediaType.
+const (
+	MediaTypeScreen = "screen"
+	MediaTypePrint  = "print"
+)
+
+// Orientations for ScreenshotRequest.Orientation and the bulk, compose, and
+// schedule options.
+const (
+	OrientationPortrait  = "portrait"
+	OrientationLandscape = "landscape"
+)
+
+// Sticky element handling modes for ScreenshotRequest.StickyElements.
+const (
+	// StickyElementsKeep leaves sticky elements as rendered in every viewport slice
+	StickyElementsKeep = "keep"
+	// StickyElementsHide hides sticky elements entirely
+	StickyElementsHide = "hide"
+	// StickyElementsFirstOnly shows sticky elements only in the first viewport slice
+	StickyElementsFirstOnly = "first-only"
+)
+
+// Resource types for ScreenshotRequest.BlockResourceTypes.
+const (
+	ResourceTypeImage      = "image"
+	ResourceTypeFont       = "font"
+	ResourceTypeMedia      = "media"
+	ResourceTypeScript     = "script"
+	ResourceTypeStylesheet = "stylesheet"
+)
+
+// ClipConfig represents a pixel region of the page to capture.
+type ClipConfig struct {
+	// X offset of the region's left edge in CSS pixels
+	X int `json:"x"`
+	// Y offset of the region's top edge in CSS pixels
+	Y int `json:"y"`
+	// Width of the region in CSS pixels (required)
+	Width int `json:"width"`
+	// Height of the region in CSS pixels (required)
+	Height int `json:"height"`
+}
+
+// ScrollConfig controls pre-scrolling of the page before a full-page capture,
+// which triggers lazy-loaded images and sections.
+type ScrollConfig struct {
+	// Enabled turns pre-scrolling on
+	Enabled bool `json:"enabled"`
+	// StepPx is the distance scrolled per step in pixels (100-5000)
+	StepPx int `json:"stepPx,omitempty"`
+	// DelayMs is the pause after each step in milliseconds (0-5000)
+	DelayMs int `json:"delayMs,omitempty"`
+	// MaxScrolls caps the number of steps (1-200)
+	MaxScrolls int `json:"maxScrolls,omitempty"`
+}
+
+// CacheConfig controls caching of captures by the API.
+type CacheConfig struct {
+	// Enabled serves repeated captures of the same request from the cache
+	Enabled bool `json:"enabled"`
+	// TTLSeconds is how long a cached capture stays valid (60-2592000)
+	TTLSeconds int `json:"ttlSeconds,omitempty"`
+	// Key overrides the cache key derived from the request (max 255 chars)
+	Key string `json:"key,omitempty"`
+}
+
+// Storage providers for StorageConfig.Provider.
+const (
+	StorageProviderS3    = "s3"
+	StorageProviderGCS   = "gcs"
+	StorageProviderAzure = "azure"
+)
+
+// StorageConfig sends results directly to a customer-owned bucket. The bucket
+// must grant the API write access as described in the storage integration docs.
+type StorageConfig struct {
+	// Provider of the bucket: s3, gcs, or azure (required)
+	Provider string `json:"provider"`
+	// Bucket (or Azure container) name (required)
+	Bucket string `json:"bucket"`
+	// Prefix prepended to object keys, e.g. "screenshots/2024/"
+	Prefix string `json:"prefix,omitempty"`
+	// ACL applied to uploaded objects, e.g. "private" or "public-read"
+	ACL string `json:"acl,omitempty"`
+	// Endpoint overrides the provider endpoint, e.g. for S3-compatible storage
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// StoredObject describes a result uploaded to customer storage.
+type StoredObject struct {
+	// Provider the object was uploaded to
+	Provider string `json:"provider"`
+	// Bucket the object was uploaded to
+	Bucket string `json:"bucket"`
+	// Key of the uploaded object
+	Key string `json:"key"`
+	// URL of the uploaded object
+	URL string `json:"url,omitempty"`
+}
+
+// GeoConfig represents an emulated geolocation.
+type GeoConfig struct {
+	// Latitude in degrees (-90 to 90)
+	Latitude float64 `json:"latitude"`
+	// Longitude in degrees (-180 to 180)
+	Longitude float64 `json:"longitude"`
+	// Accuracy in meters (optional)
+	Accuracy float64 `json:"accuracy,omitempty"`
+}
+
+// Network profiles for NetworkConfig.Profile.
+const (
+	// NetworkProfile3G emulates a slow 3G connection
+	NetworkProfile3G = "3g"
+	// NetworkProfile4G emulates a typical 4G connection
+	NetworkProfile4G = "4g"
+	// NetworkProfileOfflineCache loads the page once, then captures it
+	// offline, served only from the browser cache
+	NetworkProfileOfflineCache = "offline-cache"
+)
+
+// NetworkConfig throttles the browser's connection, to see what users on
+// slow networks see: skeleton loaders, fallback fonts, late images.
+type NetworkConfig struct {
+	// Profile is a predefined connection: 3g, 4g, or offline-cache
+	Profile string `json:"profile,omitempty"`
+	// LatencyMs is the added round-trip latency in milliseconds (0-10000);
+	// overrides the profile's latency
+	LatencyMs int `json:"latencyMs,omitempty"`
+	// DownloadKbps caps the download throughput in kilobits per second;
+	// overrides the profile's throughput
+	DownloadKbps int `json:"downloadKbps,omitempty"`
+}
+
+// Font styles for FontConfig.Style.
+const (
+	FontStyleNormal = "normal"
+	FontStyleItalic = "italic"
+)
+
+// FontConfig is a font loaded into the page before capture, so text renders
+// in licensed brand fonts the page cannot load itself. Set either URL or
+// Data.
+type FontConfig struct {
+	// Family is the CSS font-family name the page refers to (required)
+	Family string `json:"family"`
+	// URL of the font file (WOFF2, WOFF, TTF, or OTF)
+	URL string `json:"url,omitempty"`
+	// Data is the font file itself, sent base64-encoded (max 2MB)
+	Data []byte `json:"data,omitempty"`
+	// Weight of the font face, e.g. 400 or 700 (default 400)
+	Weight int `json:"weight,omitempty"`
+	// Style of the font face: normal or italic (default normal)
+	Style string `json:"style,omitempty"`
+}
+
 // ScreenshotRequest represents a request to capture a screenshot.
 type ScreenshotRequest struct {
-	// URL is the target URL to capture (required, must start with http:// or https://)
-	URL string `json:"url"`
+	// URL is the target URL to capture (required unless HTML is set, must start with http:// or https://)
+	URL string `json:"url,omitempty"`
+	// HTML is a document to render instead of loading URL (max 2MB)
+	HTML string `json:"html,omitempty"`
 	// Viewport configuration for custom dimensions
 	Viewport *ViewportConfig `json:"viewport,omitempty"`
 	// Device preset name (e.g., "Desktop HD", "iPhone 14", "iPad")
 	Device string `json:"device,omitempty"`
+	// Orientation rotates the device preset: portrait or landscape (default the preset's own)
+	Orientation string `json:"orientation,omitempty"`
 	// Format of the output image: png, jpeg, jpg, webp, or pdf
 	Format string `json:"format,omitempty"`
 	// FullPage captures the entire scrollable page
 	FullPage bool `json:"fullPage,omitempty"`
+	// ScrollStrategy pre-scrolls the page so lazy-loaded content renders
+	ScrollStrategy *ScrollConfig `json:"scrollStrategy,omitempty"`
+	// StickyElements controls fixed/sticky elements in full-page captures: keep, hide, or first-only
+	StickyElements string `json:"stickyElements,omitempty"`
 	// Quality of the output image (1-100, for jpeg/webp)
 	Quality int `json:"quality,omitempty"`
 	// Delay in milliseconds before capture (0-30000)
@@ -40,24 +254,257 @@ type ScreenshotRequest struct {
 	Timeout int `json:"timeout,omitempty"`
 	// DarkMode enables dark mode for the capture
 	DarkMode bool `json:"darkMode,omitempty"`
+	// DisableJavaScript loads the page with JavaScript turned off
+	DisableJavaScript bool `json:"disableJavaScript,omitempty"`
+	// MediaType emulates a CSS media type: screen or print
+	MediaType string `json:"mediaType,omitempty"`
+	// ReducedMotion emulates prefers-reduced-motion: reduce
+	ReducedMotion bool `json:"reducedMotion,omitempty"`
 	// CustomCSS to inject into the page (max 10000 chars)
 	CustomCSS string `json:"customCss,omitempty"`
+	// CustomJS to run in the page before capture (max 10000 chars)
+	CustomJS string `json:"customJs,omitempty"`
+	// Fonts are loaded into the page before capture, for pages or templates
+	// whose fonts cannot be fetched otherwise (max 10)
+	Fonts []FontConfig `json:"fonts,omitempty"`
 	// HideSelectors is a list of CSS selectors to hide (max 50)
 	HideSelectors []string `json:"hideSelectors,omitempty"`
 	// Selector targets a specific element to capture (max 500 chars)
 	Selector string `json:"selector,omitempty"`
+	// Clip captures an exact pixel region; cannot be combined with Selector
+	Clip *ClipConfig `json:"clip,omitempty"`
+	// FailOnStatus fails the capture when the target page responds with one of these HTTP statuses
+	FailOnStatus []int `json:"failOnStatus,omitempty"`
+	// FailOn4xx fails the capture when the target page responds with any 4xx status
+	FailOn4xx bool `json:"failOn4xx,omitempty"`
+	// FailOn5xx fails the capture when the target page responds with any 5xx status
+	FailOn5xx bool `json:"failOn5xx,omitempty"`
 	// BlockAds enables ad blocking
 	BlockAds bool `json:"blockAds,omitempty"`
 	// BlockCookieBanners enables cookie banner blocking
 	BlockCookieBanners bool `json:"blockCookieBanners,omitempty"`
 	// BlockLevel sets the blocking level: none, light, normal, pro, pro_plus, ultimate
 	BlockLevel string `json:"blockLevel,omitempty"`
+	// BlockRequests aborts page requests whose URL matches one of these patterns (max 100).
+	// Patterns are globs such as "*://*.intercom.io/*", or regular expressions wrapped in slashes.
+	BlockRequests []string `json:"blockRequests,omitempty"`
+	// BlockResourceTypes aborts page requests of these types: image, font, media, script, stylesheet
+	BlockResourceTypes []string `json:"blockResourceTypes,omitempty"`
+	// Cache controls serving the capture from the API's cache
+	Cache *CacheConfig `json:"cache,omitempty"`
+	// Storage uploads the result to a customer-owned bucket (async jobs)
+	Storage *StorageConfig `json:"storage,omitempty"`
+	// Fresh bypasses the cache and always captures the page anew
+	Fresh bool `json:"fresh,omitempty"`
+	// ReturnMetadata extracts page metadata alongside the capture; see ScreenshotWithMeta
+	ReturnMetadata bool `json:"returnMetadata,omitempty"`
+	// ReturnBlockReport reports what ad and cookie banner blocking removed; see BlockReport
+	ReturnBlockReport bool `json:"returnBlockReport,omitempty"`
+	// CaptureConsole records browser console messages, returned on the job result
+	CaptureConsole bool `json:"captureConsole,omitempty"`
+	// CaptureFailedRequests records page requests that failed, returned on the job result
+	CaptureFailedRequests bool `json:"captureFailedRequests,omitempty"`
+	// CaptureHAR records an HTTP Archive of the page load, fetched with GetJobHAR (async jobs)
+	CaptureHAR bool `json:"captureHar,omitempty"`
+	// CaptureTimings records navigation timing metrics (TTFB, FCP, LCP); see NavigationTimings
+	CaptureTimings bool `json:"captureTimings,omitempty"`
 	// WebhookURL for async notification
 	WebhookURL string `json:"webhookUrl,omitempty"`
 	// WebhookSecret for webhook authentication (max 255 chars)
 	WebhookSecret string `json:"webhookSecret,omitempty"`
 	// ResponseType specifies the response format: BINARY or JSON
 	ResponseType string `json:"responseType,omitempty"`
+	// Tags group jobs for later retrieval with ListJobs(ctx, WithTag(...)) (max 20)
+	Tags []string `json:"tags,omitempty"`
+	// Headers are extra HTTP headers sent with the page request (max 50, 8KB total)
+	Headers map[string]string `json:"headers,omitempty"`
+	// Cookies to set before loading the page (max 50)
+	Cookies []Cookie `json:"cookies,omitempty"`
+	// Auth supplies HTTP basic auth credentials for the target URL
+	Auth *BasicAuth `json:"auth,omitempty"`
+	// Session is the token of a logged-in session created with CreateSession
+	Session string `json:"session,omitempty"`
+	// SessionState restores cookies and local storage before the page loads; cannot be combined with Session
+	SessionState *StorageState `json:"sessionState,omitempty"`
+	// UserAgent overrides the browser's User-Agent string
+	UserAgent string `json:"userAgent,omitempty"`
+	// Locale is a BCP 47 language tag, e.g. "de-DE", used for Accept-Language and navigator.language
+	Locale string `json:"locale,omitempty"`
+	// Timezone is an IANA time zone name, e.g. "Europe/Berlin", emulated by the browser
+	Timezone string `json:"timezone,omitempty"`
+	// Geolocation emulates the browser's reported position
+	Geolocation *GeoConfig `json:"geolocation,omitempty"`
+	// Network throttles the browser's connection, e.g. to a 3G profile
+	Network *NetworkConfig `json:"network,omitempty"`
+	// Actions are interaction steps performed before capture (max 50)
+	Actions []Action `json:"actions,omitempty"`
+	// ProxyCountry routes the capture through a proxy in this ISO 3166-1 alpha-2 country, e.g. "DE"
+	ProxyCountry string `json:"proxyCountry,omitempty"`
+	// Overlay stamps a watermark or text onto the captured image
+	Overlay *OverlayConfig `json:"overlay,omitempty"`
+}
+
+// Overlay positions for OverlayConfig.Position.
+const (
+	OverlayTopLeft      = "top-left"
+	OverlayTopCenter    = "top-center"
+	OverlayTopRight     = "top-right"
+	OverlayCenter       = "center"
+	OverlayBottomLeft   = "bottom-left"
+	OverlayBottomCenter = "bottom-center"
+	OverlayBottomRight  = "bottom-right"
+)
+
+// OverlayConfig stamps text or an image, such as a logo or a
+// "CONFIDENTIAL" notice, onto a generated image.
+type OverlayConfig struct {
+	// Text to render (max 500 chars); Text, ImageURL, or both are required
+	Text string `json:"text,omitempty"`
+	// ImageURL of an image to render, e.g. a logo
+	ImageURL string `json:"imageUrl,omitempty"`
+	// Position of the overlay (default bottom-right)
+	Position string `json:"position,omitempty"`
+	// Opacity of the overlay between 0 and 1 (default 1)
+	Opacity float64 `json:"opacity,omitempty"`
+	// FontSize of Text in pixels (8-200)
+	FontSize int `json:"fontSize,omitempty"`
+}
+
+// Response types for ScreenshotRequest.ResponseType.
+const (
+	ResponseTypeBinary = "BINARY"
+	ResponseTypeJSON   = "JSON"
+)
+
+// ScreenshotJSONResponse represents a synchronous capture returned in JSON
+// response mode; see ScreenshotJSON.
+type ScreenshotJSONResponse struct {
+	// URL that was captured
+	URL string `json:"url"`
+	// StorageURL where the image can be downloaded
+	StorageURL string `json:"storageUrl"`
+	// Width of the image in pixels
+	Width int `json:"width"`
+	// Height of the image in pixels
+	Height int `json:"height"`
+	// FileSize of the image in bytes
+	FileSize int64 `json:"fileSize"`
+	// RenderTimeMs is how long the capture took in milliseconds
+	RenderTimeMs int64 `json:"renderTimeMs"`
+	// ExpiresAt is when StorageURL stops working
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// Metadata about the captured page, present when ReturnMetadata was set
+	Metadata *PageMetadata `json:"metadata,omitempty"`
+	// BlockReport describes what blocking removed, present when ReturnBlockReport was set
+	BlockReport *BlockReport `json:"blockReport,omitempty"`
+	// ConsoleMessages logged by the page, present when CaptureConsole was set
+	ConsoleMessages []ConsoleMessage `json:"consoleMessages,omitempty"`
+	// FailedRequests made by the page, present when CaptureFailedRequests was set
+	FailedRequests []FailedRequest `json:"failedRequests,omitempty"`
+	// Timings of the page load, present when CaptureTimings was set
+	Timings *NavigationTimings `json:"timings,omitempty"`
+}
+
+// ScreenshotResult represents a captured image together with response details.
+type ScreenshotResult struct {
+	// Data is the image (or PDF) bytes
+	Data []byte
+	// ContentType of the image, e.g. "image/png"
+	ContentType string
+	// Cache is the X-Cache response header: "HIT" when served from the API's cache, "MISS" otherwise
+	Cache string
+	// Metadata about the captured page, present when ReturnMetadata was set
+	Metadata *PageMetadata
+	// BlockReport describes what blocking removed, present when ReturnBlockReport was set
+	BlockReport *BlockReport
+	// RateLimit holds the rate limit and quota headers of the response, if any
+	RateLimit *RateLimit
+}
+
+// PageMetadata describes the captured page.
+type PageMetadata struct {
+	// Title of the page
+	Title string `json:"title,omitempty"`
+	// FinalURL is the page URL after redirects
+	FinalURL string `json:"finalUrl,omitempty"`
+	// StatusCode is the HTTP status of the page response
+	StatusCode int `json:"statusCode,omitempty"`
+	// Description from the description meta tag
+	Description string `json:"description,omitempty"`
+	// FaviconURL is the absolute URL of the page's icon
+	FaviconURL string `json:"faviconUrl,omitempty"`
+	// OpenGraph holds og:* meta tags keyed without the "og:" prefix, e.g. "title", "image"
+	OpenGraph map[string]string `json:"openGraph,omitempty"`
+}
+
+// BlockReport describes what ad and cookie banner blocking removed from the
+// page, to explain why a capture differs from the live page.
+type BlockReport struct {
+	// SelectorsRemoved are the CSS selectors of the elements removed, such as cookie banners
+	SelectorsRemoved []string `json:"selectorsRemoved,omitempty"`
+	// RulesMatched are the names of the blocking rules that matched the page
+	RulesMatched []string `json:"rulesMatched,omitempty"`
+	// AdsBlocked is the number of ad requests blocked
+	AdsBlocked int `json:"adsBlocked"`
+}
+
+// Empty reports whether blocking removed nothing.
+func (r *BlockReport) Empty() bool {
+	return len(r.SelectorsRemoved) == 0 && len(r.RulesMatched) == 0 && r.AdsBlocked == 0
+}
+
+// CacheHit reports whether the capture was served from the API's cache.
+func (r *ScreenshotResult) CacheHit() bool {
+	return strings.EqualFold(r.Cache, "hit")
+}
+
+// RenderHTMLRequest represents a request to render an HTML document.
+type RenderHTMLRequest struct {
+	// HTML is the document to render (required, max 2MB)
+	HTML string `json:"html"`
+	// Viewport configuration for custom dimensions
+	Viewport *ViewportConfig `json:"viewport,omitempty"`
+	// Device preset name (e.g., "Desktop HD", "iPhone 14", "iPad")
+	Device string `json:"device,omitempty"`
+	// Format of the output image: png, jpeg, jpg, webp, or pdf
+	Format string `json:"format,omitempty"`
+	// FullPage captures the entire document
+	FullPage bool `json:"fullPage,omitempty"`
+	// Quality of the output image (1-100, for jpeg/webp)
+	Quality int `json:"quality,omitempty"`
+	// Delay in milliseconds before capture (0-30000)
+	Delay int `json:"delay,omitempty"`
+	// WaitFor is a CSS selector to wait for before capture
+	WaitFor string `json:"waitFor,omitempty"`
+	// Timeout in milliseconds (1000-60000)
+	Timeout int `json:"timeout,omitempty"`
+	// DarkMode enables dark mode for the capture
+	DarkMode bool `json:"darkMode,omitempty"`
+	// CustomCSS to inject into the page (max 10000 chars)
+	CustomCSS string `json:"customCss,omitempty"`
+	// Fonts are loaded into the document before capture (max 10)
+	Fonts []FontConfig `json:"fonts,omitempty"`
+	// Selector targets a specific element to capture (max 500 chars)
+	Selector string `json:"selector,omitempty"`
+}
+
+// screenshotRequest converts r into the equivalent ScreenshotRequest.
+func (r *RenderHTMLRequest) screenshotRequest() *ScreenshotRequest {
+	return &ScreenshotRequest{
+		HTML:      r.HTML,
+		Viewport:  r.Viewport,
+		Device:    r.Device,
+		Format:    r.Format,
+		FullPage:  r.FullPage,
+		Quality:   r.Quality,
+		Delay:     r.Delay,
+		WaitFor:   r.WaitFor,
+		Timeout:   r.Timeout,
+		DarkMode:  r.DarkMode,
+		CustomCSS: r.CustomCSS,
+		Fonts:     r.Fonts,
+		Selector:  r.Selector,
+	}
 }
 
 // JobStatus represents the status of an async job.
@@ -94,7 +541,102 @@ type JobResponse struct {
 	// ExpiresAt timestamp
 	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 	// Metadata contains additional job information
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Metadata *JobMetadata `json:"metadata,omitempty"`
+	// Tags the job was created with
+	Tags []string `json:"tags,omitempty"`
+	// BlockReport describes what blocking removed, present when ReturnBlockReport was set
+	BlockReport *BlockReport `json:"blockReport,omitempty"`
+	// ConsoleMessages logged by the page, present when CaptureConsole was set
+	ConsoleMessages []ConsoleMessage `json:"consoleMessages,omitempty"`
+	// FailedRequests made by the page, present when CaptureFailedRequests was set
+	FailedRequests []FailedRequest `json:"failedRequests,omitempty"`
+	// Timings of the page load, present when CaptureTimings was set
+	Timings *NavigationTimings `json:"timings,omitempty"`
+	// Storage describes the uploaded object when the request set Storage
+	Storage *StoredObject `json:"storage,omitempty"`
+	// RateLimit holds the rate limit and quota headers of the GetJob response, if any
+	RateLimit *RateLimit `json:"-"`
+}
+
+// JobListResponse represents one page of screenshot jobs.
+type JobListResponse struct {
+	// Jobs on this page
+	Jobs []JobResponse `json:"jobs"`
+	// Total is the number of jobs matching the filters across all pages
+	Total int `json:"total"`
+	// NextCursor fetches the next page with WithCursor; empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// UnmarshalJSON accepts both a page object and the plain job array returned
+// when the listing is not paginated, in which case Total is the number of
+// jobs in the array.
+func (r *JobListResponse) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		*r = JobListResponse{}
+		if err := json.Unmarshal(trimmed, &r.Jobs); err != nil {
+			return err
+		}
+		r.Total = len(r.Jobs)
+		return nil
+	}
+	type page JobListResponse
+	return json.Unmarshal(data, (*page)(r))
+}
+
+// ConsoleErrors returns the console messages of type "error".
+func (j *JobResponse) ConsoleErrors() []ConsoleMessage {
+	var errs []ConsoleMessage
+	for _, m := range j.ConsoleMessages {
+		if m.Type == ConsoleMessageError {
+			errs = append(errs, m)
+		}
+	}
+	return errs
+}
+
+// Console message types.
+const (
+	ConsoleMessageLog     = "log"
+	ConsoleMessageInfo    = "info"
+	ConsoleMessageWarning = "warning"
+	ConsoleMessageError   = "error"
+)
+
+// ConsoleMessage represents a message logged to the browser console.
+type ConsoleMessage struct {
+	// Type of the message: log, info, warning, or error
+	Type string `json:"type"`
+	// Text of the message
+	Text string `json:"text"`
+	// URL of the script that logged the message
+	URL string `json:"url,omitempty"`
+	// Line number in the script
+	Line int `json:"line,omitempty"`
+	// Timestamp when the message was logged
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+// FailedRequest represents a page request that failed or returned an error status.
+type FailedRequest struct {
+	// URL of the request
+	URL string `json:"url"`
+	// Method of the request, e.g. "GET"
+	Method string `json:"method,omitempty"`
+	// ResourceType of the request, e.g. "script" or "image"
+	ResourceType string `json:"resourceType,omitempty"`
+	// Status is the HTTP status, or 0 when no response was received
+	Status int `json:"status,omitempty"`
+	// ErrorText describes a network failure, e.g. "net::ERR_NAME_NOT_RESOLVED"
+	ErrorText string `json:"errorText,omitempty"`
+}
+
+// ResultURLResponse represents a freshly signed result URL.
+type ResultURLResponse struct {
+	// ResultURL where the result can be downloaded
+	ResultURL string `json:"resultUrl"`
+	// ExpiresAt is when ResultURL stops working
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
 
 // AsyncJobCreatedResponse represents the response when creating an async job.
@@ -121,6 +663,7 @@ type BulkURLRequest struct {
 type BulkURLOptions struct {
 	Viewport           *ViewportConfig `json:"viewport,omitempty"`
 	Device             string          `json:"device,omitempty"`
+	Orientation        string          `json:"orientation,omitempty"`
 	Format             string          `json:"format,omitempty"`
 	FullPage           bool            `json:"fullPage,omitempty"`
 	Quality            int             `json:"quality,omitempty"`
@@ -130,17 +673,26 @@ type BulkURLOptions struct {
 	Timeout            int             `json:"timeout,omitempty"`
 	DarkMode           bool            `json:"darkMode,omitempty"`
 	CustomCSS          string          `json:"customCss,omitempty"`
+	CustomJS           string          `json:"customJs,omitempty"`
 	HideSelectors      []string        `json:"hideSelectors,omitempty"`
 	Selector           string          `json:"selector,omitempty"`
 	BlockAds           bool            `json:"blockAds,omitempty"`
 	BlockCookieBanners bool            `json:"blockCookieBanners,omitempty"`
 	BlockLevel         string          `json:"blockLevel,omitempty"`
+	Auth               *BasicAuth      `json:"auth,omitempty"`
+	// WebhookURL notifies a different endpoint than BulkRequest.WebhookURL
+	// when this URL's job finishes
+	WebhookURL string `json:"webhookUrl,omitempty"`
+	// WebhookSecret signs this URL's webhook (max 255 chars); defaults to
+	// BulkRequest.WebhookSecret
+	WebhookSecret string `json:"webhookSecret,omitempty"`
 }
 
 // BulkDefaults represents default options for bulk screenshot requests.
 type BulkDefaults struct {
 	Viewport           *ViewportConfig `json:"viewport,omitempty"`
 	Device             string          `json:"device,omitempty"`
+	Orientation        string          `json:"orientation,omitempty"`
 	Format             string          `json:"format,omitempty"`
 	FullPage           bool            `json:"fullPage,omitempty"`
 	Quality            int             `json:"quality,omitempty"`
@@ -150,9 +702,11 @@ type BulkDefaults struct {
 	Timeout            int             `json:"timeout,omitempty"`
 	DarkMode           bool            `json:"darkMode,omitempty"`
 	CustomCSS          string          `json:"customCss,omitempty"`
+	CustomJS           string          `json:"customJs,omitempty"`
 	BlockAds           bool            `json:"blockAds,omitempty"`
 	BlockCookieBanners bool            `json:"blockCookieBanners,omitempty"`
 	BlockLevel         string          `json:"blockLevel,omitempty"`
+	Auth               *BasicAuth      `json:"auth,omitempty"`
 }
 
 // BulkRequest represents a request to capture multiple screenshots.
@@ -165,14 +719,19 @@ type BulkRequest struct {
 	WebhookURL string `json:"webhookUrl,omitempty"`
 	// WebhookSecret for webhook authentication
 	WebhookSecret string `json:"webhookSecret,omitempty"`
+	// Storage uploads every result to a customer-owned bucket
+	Storage *StorageConfig `json:"storage,omitempty"`
+	// Tags applied to every job in the bulk request (max 20)
+	Tags []string `json:"tags,omitempty"`
 }
 
 // BulkJobInfo represents info about a single job in a bulk request.
 type BulkJobInfo struct {
-	ID        string `json:"id"`
-	URL       string `json:"url"`
-	Status    string `json:"status"`
-	ResultURL string `json:"resultUrl,omitempty"`
+	ID        string        `json:"id"`
+	URL       string        `json:"url"`
+	Status    string        `json:"status"`
+	ResultURL string        `json:"resultUrl,omitempty"`
+	Storage   *StoredObject `json:"storage,omitempty"`
 }
 
 // BulkResponse represents the response from creating a bulk job.
@@ -238,27 +797,40 @@ type CaptureItem struct {
 	Label    string          `json:"label,omitempty"`
 	Viewport *ViewportConfig `json:"viewport,omitempty"`
 	Device   string          `json:"device,omitempty"`
-	FullPage bool            `json:"fullPage,omitempty"`
-	DarkMode bool            `json:"darkMode,omitempty"`
-	Delay    int             `json:"delay,omitempty"`
+	// Orientation rotates the device preset: portrait or landscape
+	Orientation string     `json:"orientation,omitempty"`
+	FullPage    bool       `json:"fullPage,omitempty"`
+	DarkMode    bool       `json:"darkMode,omitempty"`
+	Delay       int        `json:"delay,omitempty"`
+	Auth        *BasicAuth `json:"auth,omitempty"`
+	// Frame renders this capture inside a device bezel or browser chrome,
+	// overriding the output's frame
+	Frame *FrameConfig `json:"frame,omitempty"`
 }
 
 // VariantConfig represents a variant configuration for compose.
 type VariantConfig struct {
-	ID        string          `json:"id,omitempty"`
-	Label     string          `json:"label,omitempty"`
-	Viewport  *ViewportConfig `json:"viewport,omitempty"`
-	Device    string          `json:"device,omitempty"`
-	FullPage  bool            `json:"fullPage,omitempty"`
-	DarkMode  bool            `json:"darkMode,omitempty"`
-	Delay     int             `json:"delay,omitempty"`
-	CustomCSS string          `json:"customCss,omitempty"`
+	ID       string          `json:"id,omitempty"`
+	Label    string          `json:"label,omitempty"`
+	Viewport *ViewportConfig `json:"viewport,omitempty"`
+	Device   string          `json:"device,omitempty"`
+	// Orientation rotates the device preset: portrait or landscape
+	Orientation string `json:"orientation,omitempty"`
+	FullPage    bool   `json:"fullPage,omitempty"`
+	DarkMode    bool   `json:"darkMode,omitempty"`
+	Delay       int    `json:"delay,omitempty"`
+	CustomCSS   string `json:"customCss,omitempty"`
+	CustomJS    string `json:"customJs,omitempty"`
+	// Frame renders this variant inside a device bezel or browser chrome,
+	// overriding the output's frame
+	Frame *FrameConfig `json:"frame,omitempty"`
 }
 
 // CaptureDefaults represents default capture options for compose.
 type CaptureDefaults struct {
 	Viewport           *ViewportConfig `json:"viewport,omitempty"`
 	Device             string          `json:"device,omitempty"`
+	Orientation        string          `json:"orientation,omitempty"`
 	Format             string          `json:"format,omitempty"`
 	FullPage           bool            `json:"fullPage,omitempty"`
 	Quality            int             `json:"quality,omitempty"`
@@ -268,10 +840,12 @@ type CaptureDefaults struct {
 	Timeout            int             `json:"timeout,omitempty"`
 	DarkMode           bool            `json:"darkMode,omitempty"`
 	CustomCSS          string          `json:"customCss,omitempty"`
+	CustomJS           string          `json:"customJs,omitempty"`
 	HideSelectors      []string        `json:"hideSelectors,omitempty"`
 	BlockAds           bool            `json:"blockAds,omitempty"`
 	BlockCookieBanners bool            `json:"blockCookieBanners,omitempty"`
 	BlockLevel         string          `json:"blockLevel,omitempty"`
+	Auth               *BasicAuth      `json:"auth,omitempty"`
 }
 
 // LabelConfig represents label styling for compose output.
@@ -300,6 +874,21 @@ type ShadowConfig struct {
 	Color   string `json:"color,omitempty"`
 }
 
+// Frame styles for FrameConfig.Style.
+const (
+	FrameStyleIPhone14 = "iphone-14"
+	FrameStyleMacBook  = "macbook"
+	FrameStyleBrowser  = "browser"
+)
+
+// FrameConfig renders a capture inside a device mockup for compose output.
+type FrameConfig struct {
+	// Style of the frame: iphone-14, macbook, or browser
+	Style string `json:"style"`
+	// Color of the bezel or browser chrome (#RRGGBB; default depends on the style)
+	Color string `json:"color,omitempty"`
+}
+
 // ComposeOutputConfig represents output configuration for compose.
 type ComposeOutputConfig struct {
 	// Layout type: GRID, HORIZONTAL, VERTICAL, MASONRY, MONDRIAN, PARTITIONING, AUTO
@@ -330,6 +919,10 @@ type ComposeOutputConfig struct {
 	Border *BorderConfig `json:"border,omitempty"`
 	// Shadow configuration
 	Shadow *ShadowConfig `json:"shadow,omitempty"`
+	// Frame renders every capture inside a device bezel or browser chrome
+	Frame *FrameConfig `json:"frame,omitempty"`
+	// Overlay stamps a watermark or text onto the composed image
+	Overlay *OverlayConfig `json:"overlay,omitempty"`
 }
 
 // ComposeRequest represents a request to compose multiple screenshots.
@@ -354,6 +947,18 @@ type ComposeRequest struct {
 	CapturesMode bool `json:"capturesMode,omitempty"`
 	// VariantsMode indicates variants mode
 	VariantsMode bool `json:"variantsMode,omitempty"`
+	// Images are local images (logos, annotations, stored captures) composed
+	// alongside the captures; setting them uploads the request as multipart
+	// form data (max 20 captures and images combined)
+	Images []ImageInput `json:"-"`
+}
+
+// ImageInput is a local image uploaded for composition.
+type ImageInput struct {
+	// Name of the image, used as its file name and default label
+	Name string
+	// Data is the encoded image (PNG, JPEG, WebP, or GIF)
+	Data []byte
 }
 
 // ComposeMetadata represents metadata for a composed image.
@@ -404,6 +1009,26 @@ type ComposeJobSummaryResponse struct {
 	CompletedAt       *time.Time `json:"completedAt,omitempty"`
 }
 
+// ComposeJobListResponse is a page of compose jobs returned by
+// ListComposeJobsPage.
+type ComposeJobListResponse struct {
+	// Jobs on this page
+	Jobs []ComposeJobSummaryResponse `json:"jobs"`
+	// NextCursor fetches the next page with WithCursor; empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// UnmarshalJSON accepts both a page object and the plain job array returned
+// when the listing is not paginated.
+func (r *ComposeJobListResponse) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		*r = ComposeJobListResponse{}
+		return json.Unmarshal(trimmed, &r.Jobs)
+	}
+	type page ComposeJobListResponse
+	return json.Unmarshal(data, (*page)(r))
+}
+
 // PlacementPreview represents a placement preview for compose.
 type PlacementPreview struct {
 	Index  int    `json:"index"`
@@ -428,6 +1053,7 @@ type LayoutPreviewResponse struct {
 type ScheduleScreenshotOptions struct {
 	Viewport           *ViewportConfig `json:"viewport,omitempty"`
 	Device             string          `json:"device,omitempty"`
+	Orientation        string          `json:"orientation,omitempty"`
 	Format             string          `json:"format,omitempty"`
 	FullPage           bool            `json:"fullPage,omitempty"`
 	Quality            int             `json:"quality,omitempty"`
@@ -437,18 +1063,30 @@ type ScheduleScreenshotOptions struct {
 	Timeout            int             `json:"timeout,omitempty"`
 	DarkMode           bool            `json:"darkMode,omitempty"`
 	CustomCSS          string          `json:"customCss,omitempty"`
+	CustomJS           string          `json:"customJs,omitempty"`
 	HideSelectors      []string        `json:"hideSelectors,omitempty"`
 	BlockAds           bool            `json:"blockAds,omitempty"`
 	BlockCookieBanners bool            `json:"blockCookieBanners,omitempty"`
 	BlockLevel         string          `json:"blockLevel,omitempty"`
+	Auth               *BasicAuth      `json:"auth,omitempty"`
+	Storage            *StorageConfig  `json:"storage,omitempty"`
+	// CaptureHAR records an HTTP Archive of every execution's page load
+	CaptureHAR bool `json:"captureHar,omitempty"`
+	// CaptureTimings records navigation timing metrics on every execution
+	CaptureTimings bool `json:"captureTimings,omitempty"`
+	// Extra holds options not covered by the fields above; they are sent
+	// along with the known options
+	Extra map[string]interface{} `json:"-"`
 }
 
 // CreateScheduleRequest represents a request to create a schedule.
 type CreateScheduleRequest struct {
 	// Name of the schedule (required, max 255)
 	Name string `json:"name"`
-	// URL to capture (required)
-	URL string `json:"url"`
+	// URL to capture (required unless URLs is set)
+	URL string `json:"url,omitempty"`
+	// URLs to capture on every run, instead of a single URL (max 100)
+	URLs []string `json:"urls,omitempty"`
 	// Schedule is a cron expression (required)
 	Schedule string `json:"schedule"`
 	// Timezone for the schedule
@@ -471,6 +1109,7 @@ type CreateScheduleRequest struct {
 type UpdateScheduleRequest struct {
 	Name          string                     `json:"name,omitempty"`
 	URL           string                     `json:"url,omitempty"`
+	URLs          []string                   `json:"urls,omitempty"`
 	Schedule      string                     `json:"schedule,omitempty"`
 	Timezone      string                     `json:"timezone,omitempty"`
 	Options       *ScheduleScreenshotOptions `json:"options,omitempty"`
@@ -483,25 +1122,26 @@ type UpdateScheduleRequest struct {
 
 // ScheduleResponse represents a schedule.
 type ScheduleResponse struct {
-	ID                  string                 `json:"id"`
-	Name                string                 `json:"name"`
-	URL                 string                 `json:"url"`
-	Schedule            string                 `json:"schedule"`
-	ScheduleDescription string                 `json:"scheduleDescription,omitempty"`
-	Timezone            string                 `json:"timezone,omitempty"`
-	Status              string                 `json:"status"`
-	Options             map[string]interface{} `json:"options,omitempty"`
-	WebhookURL          string                 `json:"webhookUrl,omitempty"`
-	RetentionDays       int                    `json:"retentionDays,omitempty"`
-	StartsAt            *time.Time             `json:"startsAt,omitempty"`
-	EndsAt              *time.Time             `json:"endsAt,omitempty"`
-	LastExecutedAt      *time.Time             `json:"lastExecutedAt,omitempty"`
-	NextExecutionAt     *time.Time             `json:"nextExecutionAt,omitempty"`
-	ExecutionCount      int                    `json:"executionCount"`
-	SuccessCount        int                    `json:"successCount"`
-	FailureCount        int                    `json:"failureCount"`
-	CreatedAt           *time.Time             `json:"createdAt,omitempty"`
-	UpdatedAt           *time.Time             `json:"updatedAt,omitempty"`
+	ID                  string                     `json:"id"`
+	Name                string                     `json:"name"`
+	URL                 string                     `json:"url,omitempty"`
+	URLs                []string                   `json:"urls,omitempty"`
+	Schedule            string                     `json:"schedule"`
+	ScheduleDescription string                     `json:"scheduleDescription,omitempty"`
+	Timezone            string                     `json:"timezone,omitempty"`
+	Status              string                     `json:"status"`
+	Options             *ScheduleScreenshotOptions `json:"options,omitempty"`
+	WebhookURL          string                     `json:"webhookUrl,omitempty"`
+	RetentionDays       int                        `json:"retentionDays,omitempty"`
+	StartsAt            *time.Time                 `json:"startsAt,omitempty"`
+	EndsAt              *time.Time                 `json:"endsAt,omitempty"`
+	LastExecutedAt      *time.Time                 `json:"lastExecutedAt,omitempty"`
+	NextExecutionAt     *time.Time                 `json:"nextExecutionAt,omitempty"`
+	ExecutionCount      int                        `json:"executionCount"`
+	SuccessCount        int                        `json:"successCount"`
+	FailureCount        int                        `json:"failureCount"`
+	CreatedAt           *time.Time                 `json:"createdAt,omitempty"`
+	UpdatedAt           *time.Time                 `json:"updatedAt,omitempty"`
 }
 
 // ScheduleListResponse represents a list of schedules.
@@ -522,6 +1162,22 @@ type ScheduleExecutionResponse struct {
 	ErrorCode    string     `json:"errorCode,omitempty"`
 	ErrorMessage string     `json:"errorMessage,omitempty"`
 	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+	// Timings of the page load, present when the schedule sets CaptureTimings
+	Timings *NavigationTimings `json:"timings,omitempty"`
+	// Results holds one entry per URL for schedules that capture several URLs
+	Results []ScheduleURLResult `json:"results,omitempty"`
+}
+
+// ScheduleURLResult represents the capture of one URL in a multi-URL schedule execution.
+type ScheduleURLResult struct {
+	URL          string `json:"url"`
+	Status       string `json:"status"`
+	ResultURL    string `json:"resultUrl,omitempty"`
+	StorageURL   string `json:"storageUrl,omitempty"`
+	FileSize     int64  `json:"fileSize,omitempty"`
+	RenderTimeMs int64  `json:"renderTimeMs,omitempty"`
+	ErrorCode    string `json:"errorCode,omitempty"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
 }
 
 // ScheduleHistoryResponse represents schedule execution history.
@@ -531,6 +1187,39 @@ type ScheduleHistoryResponse struct {
 	Executions      []ScheduleExecutionResponse `json:"executions"`
 }
 
+// ScheduleStatsResponse represents execution statistics for a schedule.
+type ScheduleStatsResponse struct {
+	// ScheduleID of the schedule
+	ScheduleID string `json:"scheduleId"`
+	// ScheduleName of the schedule
+	ScheduleName string `json:"scheduleName,omitempty"`
+	// TotalExecutions is the number of executions so far
+	TotalExecutions int64 `json:"totalExecutions"`
+	// SuccessCount is the number of successful executions
+	SuccessCount int64 `json:"successCount"`
+	// FailureCount is the number of failed executions
+	FailureCount int64 `json:"failureCount"`
+	// SuccessRate is the percentage of successful executions (0-100)
+	SuccessRate float64 `json:"successRate"`
+	// AverageRenderTimeMs is the mean render time of successful executions
+	AverageRenderTimeMs int64 `json:"averageRenderTimeMs,omitempty"`
+	// ConsecutiveFailures is the number of failures since the last success
+	ConsecutiveFailures int `json:"consecutiveFailures"`
+	// LastErrorCode is the error code of the most recent failure
+	LastErrorCode string `json:"lastErrorCode,omitempty"`
+	// LastError is the error message of the most recent failure
+	LastError string `json:"lastError,omitempty"`
+	// LastFailureAt is when the most recent failure happened
+	LastFailureAt *time.Time `json:"lastFailureAt,omitempty"`
+	// LastSuccessAt is when the most recent success happened
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty"`
+}
+
+// ScheduleStatsListResponse represents a list of schedule statistics.
+type ScheduleStatsListResponse struct {
+	Schedules []ScheduleStatsResponse `json:"schedules"`
+}
+
 // QuotaDetailResponse represents quota details.
 type QuotaDetailResponse struct {
 	Limit       int `json:"limit"`
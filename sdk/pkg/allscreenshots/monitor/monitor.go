@@ -0,0 +1,318 @@
+// Package monitor watches a page for visual changes: it keeps a schedule
+// capturing the page, compares each new capture with the previous one, and
+// notifies when the page changed more than a threshold.
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots/visdiff"
+)
+
+// DefaultPollInterval is how often Watch checks for new executions by default.
+const DefaultPollInterval = time.Minute
+
+// historyLimit is the number of recent executions fetched per check.
+const historyLimit = 20
+
+// MonitorSpec describes a page to watch.
+type MonitorSpec struct {
+	// Name of the schedule; defaults to "monitor: <URL>". Watch creates the
+	// schedule or updates the one with this name to match the spec.
+	Name string
+	// URL of the page to watch (required)
+	URL string
+	// Cron expression for the captures (required), e.g. "0 * * * *"
+	Cron string
+	// Timezone of the cron expression
+	Timezone string
+	// Options for the captures (png or jpeg)
+	Options *allscreenshots.ScheduleScreenshotOptions
+	// Threshold is the share of pixels (0-100) that may change between two
+	// captures before a notification is sent; 0 notifies on any change
+	Threshold float64
+	// Diff configures the pixel comparison, e.g. to ignore a clock
+	Diff visdiff.Options
+	// Notifier receives the changes (required)
+	Notifier Notifier
+	// PollInterval between checks for new executions (default 1 minute)
+	PollInterval time.Duration
+	// OnError is called when a check, comparison, or notification fails;
+	// watching continues afterwards
+	OnError func(err error)
+}
+
+// Change describes a capture that differed from the previous one by more
+// than the threshold.
+type Change struct {
+	// ScheduleID of the monitor's schedule
+	ScheduleID string
+	// Name of the schedule
+	Name string
+	// URL of the watched page
+	URL string
+	// ExecutionID of the capture that changed
+	ExecutionID string
+	// PreviousExecutionID of the capture it was compared with
+	PreviousExecutionID string
+	// ResultURL links to the new capture, if the API kept one
+	ResultURL string
+	// ChangedPercent is the share of pixels that changed
+	ChangedPercent float64
+	// Diff is the full comparison, including an image highlighting the change
+	Diff *visdiff.DiffResult
+	// At is when the new capture was taken, if known
+	At *time.Time
+}
+
+// Notifier delivers change notifications.
+type Notifier interface {
+	Notify(ctx context.Context, c Change) error
+}
+
+// NotifierFunc adapts a function to the Notifier interface.
+type NotifierFunc func(ctx context.Context, c Change) error
+
+// Notify calls f.
+func (f NotifierFunc) Notify(ctx context.Context, c Change) error {
+	return f(ctx, c)
+}
+
+// Monitor is a running watch started by Watch.
+type Monitor struct {
+	// ScheduleID of the schedule capturing the page
+	ScheduleID string
+
+	done chan struct{}
+}
+
+// Done returns a channel that is closed once the monitor has stopped.
+func (m *Monitor) Done() <-chan struct{} {
+	return m.done
+}
+
+// Watch creates or updates the schedule described by spec and starts a
+// goroutine that compares each new execution's capture with the previous
+// one, sending a Change to spec.Notifier whenever more than spec.Threshold
+// percent of the pixels changed. Executions that existed before Watch was
+// called are not reported; the latest of them is the first baseline. Failed
+// executions are skipped. The monitor stops when ctx is cancelled; the
+// schedule is kept.
+//
+// Example:
+//
+//	m, err := monitor.Watch(ctx, client, monitor.MonitorSpec{
+//	    URL:       "https://example.com/pricing",
+//	    Cron:      "0 * * * *",
+//	    Threshold: 1.5,
+//	    Notifier: monitor.NotifierFunc(func(ctx context.Context, c monitor.Change) error {
+//	        log.Printf("%s changed by %.1f%%", c.URL, c.ChangedPercent)
+//	        return os.WriteFile(c.ExecutionID+"-diff.png", c.Diff.Image, 0644)
+//	    }),
+//	})
+func Watch(ctx context.Context, client *allscreenshots.Client, spec MonitorSpec) (*Monitor, error) {
+	if client == nil || spec.Notifier == nil {
+		return nil, errors.New("monitor: client and notifier are required")
+	}
+	if spec.URL == "" || spec.Cron == "" {
+		return nil, errors.New("monitor: URL and cron expression are required")
+	}
+	if spec.Threshold < 0 || spec.Threshold > 100 {
+		return nil, errors.New("monitor: threshold must be between 0 and 100")
+	}
+	if spec.PollInterval < 0 {
+		return nil, errors.New("monitor: poll interval must not be negative")
+	}
+	if spec.PollInterval == 0 {
+		spec.PollInterval = DefaultPollInterval
+	}
+	if spec.Name == "" {
+		spec.Name = "monitor: " + spec.URL
+	}
+
+	result, err := client.SyncSchedules(ctx, []allscreenshots.CreateScheduleRequest{{
+		Name:     spec.Name,
+		URL:      spec.URL,
+		Schedule: spec.Cron,
+		Timezone: spec.Timezone,
+		Options:  spec.Options,
+	}}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("monitor: syncing schedule: %w", err)
+	}
+	scheduleID := result.Changes[0].ID
+
+	w := &watcher{client: client, spec: spec, scheduleID: scheduleID, seen: make(map[string]bool)}
+	if err := w.init(ctx); err != nil {
+		return nil, err
+	}
+	m := &Monitor{ScheduleID: scheduleID, done: make(chan struct{})}
+	go func() {
+		defer close(m.done)
+		w.run(ctx)
+	}()
+	return m, nil
+}
+
+// watcher holds the state of a Watch goroutine.
+type watcher struct {
+	client     *allscreenshots.Client
+	spec       MonitorSpec
+	scheduleID string
+
+	// seen holds the IDs of executions already handled
+	seen map[string]bool
+	// previous is the latest successful capture and its execution
+	previous     []byte
+	previousExec string
+}
+
+// init records the existing executions and downloads the latest successful
+// capture as the first baseline.
+func (w *watcher) init(ctx context.Context) error {
+	execs, err := w.executions(ctx)
+	if err != nil {
+		return fmt.Errorf("monitor: reading schedule history: %w", err)
+	}
+	for _, exec := range execs {
+		if finished(exec) {
+			w.seen[exec.ID] = true
+		}
+	}
+	for i := len(execs) - 1; i >= 0; i-- {
+		if !succeeded(execs[i]) {
+			continue
+		}
+		data, err := w.download(ctx, execs[i].ResultURL)
+		if err != nil {
+			return fmt.Errorf("monitor: downloading execution %s: %w", execs[i].ID, err)
+		}
+		w.previous, w.previousExec = data, execs[i].ID
+		break
+	}
+	return nil
+}
+
+// run checks on every tick until ctx is done.
+func (w *watcher) run(ctx context.Context) {
+	ticker := time.NewTicker(w.spec.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if !w.check(ctx) {
+			return
+		}
+	}
+}
+
+// check compares the executions that finished since the last check, oldest
+// first. It reports false once the client is closed, since no later check
+// can succeed.
+func (w *watcher) check(ctx context.Context) bool {
+	execs, err := w.executions(ctx)
+	if errors.Is(err, allscreenshots.ErrClientClosed) {
+		return false
+	}
+	if err != nil {
+		w.report(ctx, err)
+		return true
+	}
+	for _, exec := range execs {
+		if w.seen[exec.ID] {
+			continue
+		}
+		if !finished(exec) {
+			// Still running; look again on the next check.
+			continue
+		}
+		w.seen[exec.ID] = true
+		if !succeeded(exec) {
+			continue
+		}
+
+		data, err := w.download(ctx, exec.ResultURL)
+		if err != nil {
+			w.report(ctx, fmt.Errorf("monitor: downloading execution %s: %w", exec.ID, err))
+			continue
+		}
+		previous, previousExec := w.previous, w.previousExec
+		w.previous, w.previousExec = data, exec.ID
+		if previous == nil {
+			continue
+		}
+
+		diff, err := visdiff.Compare(previous, data, w.spec.Diff)
+		if err != nil {
+			w.report(ctx, fmt.Errorf("monitor: comparing execution %s: %w", exec.ID, err))
+			continue
+		}
+		if diff.Identical() || diff.ChangedPercent <= w.spec.Threshold {
+			continue
+		}
+		change := Change{
+			ScheduleID:          w.scheduleID,
+			Name:                w.spec.Name,
+			URL:                 w.spec.URL,
+			ExecutionID:         exec.ID,
+			PreviousExecutionID: previousExec,
+			ResultURL:           exec.ResultURL,
+			ChangedPercent:      diff.ChangedPercent,
+			Diff:                diff,
+			At:                  exec.ExecutedAt,
+		}
+		if err := w.spec.Notifier.Notify(ctx, change); err != nil {
+			w.report(ctx, err)
+		}
+	}
+	return true
+}
+
+// executions returns the schedule's recent executions, oldest first.
+func (w *watcher) executions(ctx context.Context) ([]allscreenshots.ScheduleExecutionResponse, error) {
+	history, err := w.client.GetScheduleHistory(ctx, w.scheduleID, historyLimit)
+	if err != nil {
+		return nil, err
+	}
+	execs := history.Executions
+	// The API lists the most recent execution first.
+	for i, j := 0, len(execs)-1; i < j; i, j = i+1, j-1 {
+		execs[i], execs[j] = execs[j], execs[i]
+	}
+	return execs, nil
+}
+
+// download fetches a capture.
+func (w *watcher) download(ctx context.Context, resultURL string) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := w.client.DownloadResult(ctx, resultURL, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// report passes err to OnError unless ctx is done.
+func (w *watcher) report(ctx context.Context, err error) {
+	if ctx.Err() == nil && w.spec.OnError != nil {
+		w.spec.OnError(err)
+	}
+}
+
+// finished reports whether an execution is done, successfully or not.
+func finished(exec allscreenshots.ScheduleExecutionResponse) bool {
+	return exec.ErrorCode != "" || exec.ResultURL != ""
+}
+
+// succeeded reports whether an execution produced a capture.
+func succeeded(exec allscreenshots.ScheduleExecutionResponse) bool {
+	return exec.ErrorCode == "" && exec.ResultURL != ""
+}
@@ -0,0 +1,208 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encode returns a 10x10 white PNG with the first changed pixels black.
+func encode(t *testing.T, changed int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for i := 0; i < 100; i++ {
+		if i < changed {
+			img.Set(i%10, i/10, color.Black)
+		} else {
+			img.Set(i%10, i/10, color.White)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// fakeAPI serves schedules and a history that tests extend with addExecution.
+type fakeAPI struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu         sync.Mutex
+	schedules  []allscreenshots.ScheduleResponse
+	created    []allscreenshots.CreateScheduleRequest
+	executions []allscreenshots.ScheduleExecutionResponse
+	images     map[string][]byte
+}
+
+func newFakeAPI(t *testing.T) *fakeAPI {
+	api := &fakeAPI{t: t, images: make(map[string][]byte)}
+	api.server = httptest.NewServer(http.HandlerFunc(api.handle))
+	t.Cleanup(api.server.Close)
+	return api
+}
+
+func (a *fakeAPI) handle(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/schedules":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(allscreenshots.ScheduleListResponse{Schedules: a.schedules})
+	case r.Method == http.MethodPost && r.URL.Path == "/v1/schedules":
+		var req allscreenshots.CreateScheduleRequest
+		require.NoError(a.t, json.NewDecoder(r.Body).Decode(&req))
+		a.created = append(a.created, req)
+		s := allscreenshots.ScheduleResponse{ID: "s-1", Name: req.Name, URL: req.URL, Schedule: req.Schedule}
+		a.schedules = append(a.schedules, s)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s)
+	case r.URL.Path == "/v1/schedules/s-1/history":
+		assert.Equal(a.t, "20", r.URL.Query().Get("limit"))
+		// Most recent first, like the API.
+		execs := make([]allscreenshots.ScheduleExecutionResponse, len(a.executions))
+		for i, e := range a.executions {
+			execs[len(execs)-1-i] = e
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(allscreenshots.ScheduleHistoryResponse{ScheduleID: "s-1", Executions: execs})
+	case strings.HasPrefix(r.URL.Path, "/results/"):
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(a.images[strings.TrimPrefix(r.URL.Path, "/results/")])
+	default:
+		a.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}
+}
+
+// addExecution appends a successful execution capturing data, or a failed
+// one when data is nil.
+func (a *fakeAPI) addExecution(id string, data []byte) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	exec := allscreenshots.ScheduleExecutionResponse{ID: id, Status: "COMPLETED"}
+	if data == nil {
+		exec.Status, exec.ErrorCode = "FAILED", "TIMEOUT"
+	} else {
+		exec.ResultURL = a.server.URL + "/results/" + id
+		a.images[id] = data
+	}
+	a.executions = append(a.executions, exec)
+}
+
+func TestWatch(t *testing.T) {
+	api := newFakeAPI(t)
+	api.addExecution("e-1", encode(t, 0))
+
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(api.server.URL))
+	changes := make(chan Change, 10)
+	var errs []error
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m, err := Watch(ctx, client, MonitorSpec{
+		URL:          "https://example.com/pricing",
+		Cron:         "0 * * * *",
+		Threshold:    5,
+		PollInterval: 5 * time.Millisecond,
+		Notifier: NotifierFunc(func(ctx context.Context, c Change) error {
+			changes <- c
+			return nil
+		}),
+		OnError: func(err error) { errs = append(errs, err) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "s-1", m.ScheduleID)
+	require.Len(t, api.created, 1)
+	assert.Equal(t, "monitor: https://example.com/pricing", api.created[0].Name)
+	assert.Equal(t, "0 * * * *", api.created[0].Schedule)
+
+	api.addExecution("e-2", encode(t, 2))  // 2% changed: below the threshold
+	api.addExecution("e-3", nil)           // failed: skipped
+	api.addExecution("e-4", encode(t, 20)) // 18% changed since e-2
+
+	select {
+	case c := <-changes:
+		assert.Equal(t, "e-4", c.ExecutionID)
+		assert.Equal(t, "e-2", c.PreviousExecutionID)
+		assert.Equal(t, "s-1", c.ScheduleID)
+		assert.Equal(t, "https://example.com/pricing", c.URL)
+		assert.InDelta(t, 18, c.ChangedPercent, 0.001)
+		assert.NotEmpty(t, c.Diff.Image)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change reported")
+	}
+
+	cancel()
+	<-m.Done()
+	assert.Empty(t, changes)
+	assert.Empty(t, errs)
+}
+
+func TestWatch_ExistingSchedule(t *testing.T) {
+	api := newFakeAPI(t)
+	api.schedules = []allscreenshots.ScheduleResponse{{ID: "s-1", Name: "pricing", URL: "https://example.com/pricing", Schedule: "0 * * * *"}}
+	api.addExecution("e-1", encode(t, 0))
+	api.addExecution("e-2", encode(t, 50))
+
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(api.server.URL))
+	changes := make(chan Change, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m, err := Watch(ctx, client, MonitorSpec{
+		Name:         "pricing",
+		URL:          "https://example.com/pricing",
+		Cron:         "0 * * * *",
+		PollInterval: 5 * time.Millisecond,
+		Notifier: NotifierFunc(func(ctx context.Context, c Change) error {
+			changes <- c
+			return nil
+		}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "s-1", m.ScheduleID)
+	assert.Empty(t, api.created)
+
+	// Executions before Watch are not reported; the latest is the baseline.
+	api.addExecution("e-3", encode(t, 51))
+	select {
+	case c := <-changes:
+		assert.Equal(t, "e-3", c.ExecutionID)
+		assert.Equal(t, "e-2", c.PreviousExecutionID)
+		assert.InDelta(t, 1, c.ChangedPercent, 0.001)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change reported")
+	}
+}
+
+func TestWatch_Validation(t *testing.T) {
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"))
+	notifier := NotifierFunc(func(context.Context, Change) error { return nil })
+
+	tests := []struct {
+		name    string
+		spec    MonitorSpec
+		wantErr string
+	}{
+		{"missing notifier", MonitorSpec{URL: "https://example.com", Cron: "* * * * *"}, "monitor: client and notifier are required"},
+		{"missing cron", MonitorSpec{URL: "https://example.com", Notifier: notifier}, "monitor: URL and cron expression are required"},
+		{"threshold too high", MonitorSpec{URL: "https://example.com", Cron: "* * * * *", Threshold: 101, Notifier: notifier}, "monitor: threshold must be between 0 and 100"},
+		{"negative poll interval", MonitorSpec{URL: "https://example.com", Cron: "* * * * *", PollInterval: -1, Notifier: notifier}, "monitor: poll interval must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Watch(context.Background(), client, tt.spec)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
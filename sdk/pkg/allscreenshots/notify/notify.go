@@ -0,0 +1,211 @@
+// Package notify alerts a team when screenshot jobs or schedules fail, for
+// example in a Slack channel.
+package notify
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+// DefaultPollInterval is how often OnFailures checks for failures by default.
+const DefaultPollInterval = time.Minute
+
+// Kind is the kind of resource a Failure is about.
+type Kind string
+
+// Kinds of failures.
+const (
+	KindJob      Kind = "job"
+	KindSchedule Kind = "schedule"
+)
+
+// Failure describes a failed job or a failing schedule.
+type Failure struct {
+	// Kind of resource that failed
+	Kind Kind
+	// ID of the job or schedule
+	ID string
+	// Name of the schedule; empty for jobs
+	Name string
+	// URL of the page that was being captured, if known
+	URL string
+	// ErrorCode reported by the API
+	ErrorCode string
+	// ErrorMessage reported by the API
+	ErrorMessage string
+	// ResultURL links to the job's result, if the API kept one
+	ResultURL string
+	// ConsecutiveFailures of a schedule; 0 for jobs
+	ConsecutiveFailures int
+	// At is when the failure happened, if known
+	At *time.Time
+}
+
+// Notifier delivers failure notifications.
+type Notifier interface {
+	Notify(ctx context.Context, f Failure) error
+}
+
+// Options configures OnFailures.
+type Options struct {
+	// PollInterval between checks (default 1 minute)
+	PollInterval time.Duration
+	// Kinds of failures to watch (default jobs and schedules)
+	Kinds []Kind
+	// OnError is called when a check or a notification fails; watching
+	// continues afterwards
+	OnError func(err error)
+}
+
+// OnFailures starts a goroutine that polls for failed jobs and failing
+// schedules and sends each new failure to notifier. Failures that already
+// existed when it started are not reported. A schedule is reported again
+// each time it fails anew. The watcher stops when ctx is cancelled.
+//
+// Example:
+//
+//	err := notify.OnFailures(ctx, client, notify.NewSlack(os.Getenv("SLACK_WEBHOOK_URL")), notify.Options{
+//	    PollInterval: 2 * time.Minute,
+//	    Kinds:        []notify.Kind{notify.KindSchedule},
+//	})
+func OnFailures(ctx context.Context, client *allscreenshots.Client, notifier Notifier, opts Options) error {
+	if client == nil || notifier == nil {
+		return errors.New("notify: client and notifier are required")
+	}
+	if opts.PollInterval < 0 {
+		return errors.New("notify: poll interval must not be negative")
+	}
+	if opts.PollInterval == 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	kinds := opts.Kinds
+	if len(kinds) == 0 {
+		kinds = []Kind{KindJob, KindSchedule}
+	}
+
+	w := &watcher{client: client, notifier: notifier, opts: opts}
+	for _, k := range kinds {
+		switch k {
+		case KindJob:
+			w.jobs = true
+		case KindSchedule:
+			w.schedules = true
+		default:
+			return errors.New("notify: unknown kind " + string(k))
+		}
+	}
+	go w.run(ctx)
+	return nil
+}
+
+// watcher holds the state of an OnFailures goroutine.
+type watcher struct {
+	client    *allscreenshots.Client
+	notifier  Notifier
+	opts      Options
+	jobs      bool
+	schedules bool
+
+	// failedJobs and failingSchedules hold what the latest check found; nil
+	// until the first successful check, whose failures predate the watcher.
+	failedJobs       map[string]bool
+	failingSchedules map[string]time.Time
+}
+
+// run checks immediately and then on every tick until ctx is done.
+func (w *watcher) run(ctx context.Context) {
+	ticker := time.NewTicker(w.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if w.jobs {
+			w.checkJobs(ctx)
+		}
+		if w.schedules {
+			w.checkSchedules(ctx)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkJobs reports jobs that failed since the last check.
+func (w *watcher) checkJobs(ctx context.Context) {
+	jobs, err := w.client.ListJobs(ctx)
+	if err != nil {
+		w.report(ctx, err)
+		return
+	}
+	failed := make(map[string]bool)
+	for _, job := range jobs {
+		if job.Status != allscreenshots.JobStatusFailed {
+			continue
+		}
+		failed[job.ID] = true
+		if w.failedJobs != nil && !w.failedJobs[job.ID] {
+			w.notify(ctx, Failure{
+				Kind:         KindJob,
+				ID:           job.ID,
+				URL:          job.URL,
+				ErrorCode:    job.ErrorCode,
+				ErrorMessage: job.ErrorMessage,
+				ResultURL:    job.ResultURL,
+				At:           job.CompletedAt,
+			})
+		}
+	}
+	w.failedJobs = failed
+}
+
+// checkSchedules reports schedules that failed since the last check.
+func (w *watcher) checkSchedules(ctx context.Context) {
+	stats, err := w.client.ListUnhealthySchedules(ctx, 1)
+	if err != nil {
+		w.report(ctx, err)
+		return
+	}
+	failing := make(map[string]time.Time)
+	for _, s := range stats {
+		var at time.Time
+		if s.LastFailureAt != nil {
+			at = *s.LastFailureAt
+		}
+		failing[s.ScheduleID] = at
+		if w.failingSchedules == nil {
+			continue
+		}
+		if prev, ok := w.failingSchedules[s.ScheduleID]; ok && prev.Equal(at) {
+			continue
+		}
+		w.notify(ctx, Failure{
+			Kind:                KindSchedule,
+			ID:                  s.ScheduleID,
+			Name:                s.ScheduleName,
+			ErrorCode:           s.LastErrorCode,
+			ErrorMessage:        s.LastError,
+			ConsecutiveFailures: s.ConsecutiveFailures,
+			At:                  s.LastFailureAt,
+		})
+	}
+	w.failingSchedules = failing
+}
+
+// notify sends f, reporting a failure to do so.
+func (w *watcher) notify(ctx context.Context, f Failure) {
+	if err := w.notifier.Notify(ctx, f); err != nil {
+		w.report(ctx, err)
+	}
+}
+
+// report passes err to OnError unless ctx is done.
+func (w *watcher) report(ctx context.Context, err error) {
+	if ctx.Err() == nil && w.opts.OnError != nil {
+		w.opts.OnError(err)
+	}
+}
@@ -0,0 +1,123 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu       sync.Mutex
+	failures []Failure
+}
+
+func (r *recorder) Notify(ctx context.Context, f Failure) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failures = append(r.failures, f)
+	return nil
+}
+
+func (r *recorder) list() []Failure {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Failure(nil), r.failures...)
+}
+
+func TestOnFailures(t *testing.T) {
+	var mu sync.Mutex
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	jobs := []allscreenshots.JobResponse{{ID: "old", Status: allscreenshots.JobStatusFailed}}
+	schedules := []allscreenshots.ScheduleStatsResponse{{ScheduleID: "s-1", ScheduleName: "Home", ConsecutiveFailures: 1, LastFailureAt: &t1}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/v1/screenshots/jobs":
+			json.NewEncoder(w).Encode(jobs)
+		case "/v1/schedules/unhealthy":
+			assert.Equal(t, "1", r.URL.Query().Get("consecutiveFailures"))
+			json.NewEncoder(w).Encode(allscreenshots.ScheduleStatsListResponse{Schedules: schedules})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(server.URL))
+	rec := &recorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, OnFailures(ctx, client, rec, Options{PollInterval: 10 * time.Millisecond}))
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Empty(t, rec.list(), "failures that predate the watcher are not reported")
+
+	mu.Lock()
+	jobs = append(jobs, allscreenshots.JobResponse{ID: "job-2", Status: allscreenshots.JobStatusFailed, URL: "https://example.com", ErrorCode: "TIMEOUT"})
+	t2 := t1.Add(time.Hour)
+	schedules[0].ConsecutiveFailures = 2
+	schedules[0].LastFailureAt = &t2
+	mu.Unlock()
+
+	require.Eventually(t, func() bool { return len(rec.list()) == 2 }, time.Second, 5*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	failures := rec.list()
+	require.Len(t, failures, 2, "each failure is reported once")
+	assert.Equal(t, Failure{Kind: KindJob, ID: "job-2", URL: "https://example.com", ErrorCode: "TIMEOUT"}, failures[0])
+	assert.Equal(t, KindSchedule, failures[1].Kind)
+	assert.Equal(t, 2, failures[1].ConsecutiveFailures)
+}
+
+func TestOnFailures_Validation(t *testing.T) {
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"))
+	assert.EqualError(t, OnFailures(context.Background(), client, nil, Options{}), "notify: client and notifier are required")
+	assert.EqualError(t, OnFailures(context.Background(), client, &recorder{}, Options{Kinds: []Kind{"bulk"}}), "notify: unknown kind bulk")
+}
+
+func TestSlack(t *testing.T) {
+	var text string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		text = body["text"]
+	}))
+	defer server.Close()
+
+	err := NewSlack(server.URL).Notify(context.Background(), Failure{
+		Kind:         KindJob,
+		ID:           "job-1",
+		URL:          "https://example.com/?a=1&b=2",
+		ErrorCode:    "NAVIGATION_TIMEOUT",
+		ErrorMessage: "Page did not load in <30s>",
+		ResultURL:    "https://cdn.example.com/job-1.png",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ":red_circle: *Screenshot job failed:* `job-1`\n"+
+		"*Page:* <https://example.com/?a=1&amp;b=2>\n"+
+		"*Error:* `NAVIGATION_TIMEOUT` Page did not load in &lt;30s&gt;\n"+
+		"<https://cdn.example.com/job-1.png|View result>", text)
+
+	assert.Equal(t, ":red_circle: *Schedule failing:* Home (`s-1`), 3 consecutive failures\n*Error:* `TIMEOUT`",
+		SlackMessage(Failure{Kind: KindSchedule, ID: "s-1", Name: "Home", ConsecutiveFailures: 3, ErrorCode: "TIMEOUT"}))
+}
+
+func TestSlack_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("no_service"))
+	}))
+	defer server.Close()
+
+	err := NewSlack(server.URL).Notify(context.Background(), Failure{ID: "job-1"})
+	assert.EqualError(t, err, "notify: slack returned 404 Not Found: no_service")
+}
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Slack posts failures to a Slack incoming webhook.
+type Slack struct {
+	webhookURL string
+	// HTTPClient sends the requests (default http.DefaultClient)
+	HTTPClient *http.Client
+}
+
+// NewSlack returns a notifier that posts to a Slack incoming webhook URL.
+func NewSlack(webhookURL string) *Slack {
+	return &Slack{webhookURL: webhookURL}
+}
+
+// Notify posts f to the webhook.
+func (s *Slack) Notify(ctx context.Context, f Failure) error {
+	body, err := json.Marshal(map[string]string{"text": SlackMessage(f)})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notify: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify: slack request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notify: slack returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// SlackMessage formats f as Slack mrkdwn text.
+func SlackMessage(f Failure) string {
+	var b strings.Builder
+	switch f.Kind {
+	case KindSchedule:
+		name := f.Name
+		if name == "" {
+			name = f.ID
+		}
+		fmt.Fprintf(&b, ":red_circle: *Schedule failing:* %s (`%s`)", slackEscape(name), slackEscape(f.ID))
+		if f.ConsecutiveFailures > 1 {
+			fmt.Fprintf(&b, ", %d consecutive failures", f.ConsecutiveFailures)
+		}
+	default:
+		fmt.Fprintf(&b, ":red_circle: *Screenshot job failed:* `%s`", slackEscape(f.ID))
+	}
+	if f.URL != "" {
+		fmt.Fprintf(&b, "\n*Page:* <%s>", slackEscape(f.URL))
+	}
+	if f.ErrorCode != "" || f.ErrorMessage != "" {
+		b.WriteString("\n*Error:*")
+		if f.ErrorCode != "" {
+			fmt.Fprintf(&b, " `%s`", slackEscape(f.ErrorCode))
+		}
+		if f.ErrorMessage != "" {
+			b.WriteString(" " + slackEscape(f.ErrorMessage))
+		}
+	}
+	if f.ResultURL != "" {
+		fmt.Fprintf(&b, "\n<%s|View result>", slackEscape(f.ResultURL))
+	}
+	return b.String()
+}
+
+// slackEscape escapes the characters Slack treats as control sequences.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,78 @@
+package allscreenshots
+
+import (
+	"context"
+	"strings"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots/pdfutil"
+)
+
+// PDFPagesOptions configures CaptureToPDFPages.
+type PDFPagesOptions struct {
+	// Request is a template applied to every URL; its URL field is ignored.
+	// The format must be png or jpeg (default jpeg, which keeps documents small)
+	Request *ScreenshotRequest
+	// Page controls the page layout of the document
+	Page pdfutil.PageOptions
+	// Concurrency is the number of captures in flight (default 4)
+	Concurrency int
+}
+
+// CaptureToPDFPages captures each URL and binds the screenshots, in order,
+// into a single multi-page PDF. Captures run concurrently; if any fails, no
+// document is produced and the error names every failed URL.
+//
+// Example:
+//
+//	pdf, err := client.CaptureToPDFPages(ctx, []string{
+//	    "https://example.com/docs/intro",
+//	    "https://example.com/docs/install",
+//	    "https://example.com/docs/usage",
+//	}, allscreenshots.PDFPagesOptions{
+//	    Request: &allscreenshots.ScreenshotRequest{FullPage: true},
+//	    Page:    pdfutil.PageOptions{Size: pdfutil.A4, Margin: 24, Split: true, Title: "Docs"},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	os.WriteFile("docs.pdf", pdf, 0644)
+func (c *Client) CaptureToPDFPages(ctx context.Context, urls []string, opts PDFPagesOptions) ([]byte, error) {
+	if len(urls) == 0 {
+		return nil, &ValidationError{Field: "urls", Message: "at least one URL is required"}
+	}
+
+	var template ScreenshotRequest
+	if opts.Request != nil {
+		template = *opts.Request
+	}
+	template.URL = ""
+	template.HTML = ""
+	switch strings.ToLower(template.Format) {
+	case "":
+		template.Format = "jpeg"
+	case "png", "jpeg", "jpg":
+	default:
+		return nil, &ValidationError{Field: "format", Message: "format must be png or jpeg for PDF pages"}
+	}
+
+	reqs := make([]*ScreenshotRequest, len(urls))
+	for i, u := range urls {
+		req := template
+		req.URL = u
+		normalized := c.normalizeRequestURL(&req)
+		if err := validateScreenshotRequest(normalized); err != nil {
+			return nil, err
+		}
+		reqs[i] = normalized
+	}
+
+	results, err := NewPool(c, PoolOptions{Concurrency: opts.Concurrency}).CaptureAll(ctx, reqs)
+	if err != nil {
+		return nil, err
+	}
+	images := make([][]byte, len(results))
+	for i, r := range results {
+		images[i] = r.Data
+	}
+	return pdfutil.Merge(images, opts.Page)
+}
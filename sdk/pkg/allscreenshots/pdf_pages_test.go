@@ -0,0 +1,52 @@
+package allscreenshots
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CaptureToPDFPages(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 40, 30))))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "png", req.Format)
+		assert.True(t, req.FullPage)
+		if req.URL == "https://example.com/broken" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "bad page"})
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithMaxRetries(0))
+	opts := PDFPagesOptions{Request: &ScreenshotRequest{Format: "png", FullPage: true}}
+
+	pdf, err := client.CaptureToPDFPages(context.Background(), []string{"https://example.com/a", "https://example.com/b"}, opts)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-")))
+	assert.Contains(t, string(pdf), "/Count 2")
+
+	_, err = client.CaptureToPDFPages(context.Background(), []string{"https://example.com/a", "https://example.com/broken"}, opts)
+	assert.ErrorContains(t, err, "https://example.com/broken")
+
+	_, err = client.CaptureToPDFPages(context.Background(), nil, opts)
+	assert.ErrorContains(t, err, "at least one URL is required")
+
+	_, err = client.CaptureToPDFPages(context.Background(), []string{"https://example.com"}, PDFPagesOptions{Request: &ScreenshotRequest{Format: "webp"}})
+	assert.ErrorContains(t, err, "format must be png or jpeg")
+}
@@ -0,0 +1,310 @@
+// Package pdfutil binds screenshots into multi-page PDF documents.
+//
+// JPEG images are embedded as is; other formats are embedded losslessly
+// with Flate compression. The package has no dependencies beyond the
+// standard library.
+package pdfutil
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"strings"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots/imageutil"
+)
+
+// PageSize is a page size in PDF points (1/72 inch).
+type PageSize struct {
+	Width  float64
+	Height float64
+}
+
+// Common page sizes.
+var (
+	A4     = PageSize{Width: 595.28, Height: 841.89}
+	Letter = PageSize{Width: 612, Height: 792}
+)
+
+// pixelsPerPoint sizes pages fitted to their image: screenshots are taken
+// at 96 pixels per inch and PDF uses 72 points per inch.
+const pixelsPerPoint = 96.0 / 72.0
+
+// PageOptions configures how images are laid out on pages.
+type PageOptions struct {
+	// Size of every page; the zero value sizes each page to fit its image
+	Size PageSize
+	// Margin around the image in points (fixed page sizes only)
+	Margin float64
+	// Split slices images taller than the page across several pages instead
+	// of shrinking them to fit, which keeps full-page captures legible
+	Split bool
+	// Title is stored in the document information
+	Title string
+}
+
+// Merge creates a PDF with one page per image, in order. Images may be
+// PNG, JPEG, GIF, or, with a decoder registered, WebP.
+//
+// Example:
+//
+//	pdf, err := pdfutil.Merge([][]byte{home, pricing, about}, pdfutil.PageOptions{
+//	    Size:   pdfutil.A4,
+//	    Margin: 36,
+//	    Split:  true,
+//	})
+func Merge(images [][]byte, opts PageOptions) ([]byte, error) {
+	if len(images) == 0 {
+		return nil, errors.New("pdfutil: at least one image is required")
+	}
+	fixed := opts.Size.Width > 0 || opts.Size.Height > 0
+	if fixed && (opts.Size.Width <= 2*opts.Margin || opts.Size.Height <= 2*opts.Margin) {
+		return nil, errors.New("pdfutil: page size must be positive and larger than the margins")
+	}
+	if opts.Margin < 0 {
+		return nil, errors.New("pdfutil: margin must not be negative")
+	}
+
+	w := newWriter()
+	for i, data := range images {
+		img, err := decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("pdfutil: image %d: %w", i, err)
+		}
+		if !fixed {
+			w.addPage(img, PageSize{Width: float64(img.width) / pixelsPerPoint, Height: float64(img.height) / pixelsPerPoint}, 0)
+			continue
+		}
+		for _, part := range layout(img, opts) {
+			w.addPage(part, opts.Size, opts.Margin)
+		}
+	}
+	return w.finish(opts.Title), nil
+}
+
+// pdfImage is an image encoded for embedding as an XObject.
+type pdfImage struct {
+	width, height int
+	colorSpace    string
+	filter        string
+	data          []byte
+	// decoded is kept for images that may need to be split
+	decoded image.Image
+}
+
+// decode prepares an image for embedding.
+func decode(data []byte) (*pdfImage, error) {
+	img, format, err := imageutil.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	b := img.Bounds()
+	if format == "jpeg" {
+		if cs := jpegColorSpace(img); cs != "" {
+			return &pdfImage{width: b.Dx(), height: b.Dy(), colorSpace: cs, filter: "DCTDecode", data: data, decoded: img}, nil
+		}
+	}
+	return encodeFlate(img), nil
+}
+
+// jpegColorSpace returns the PDF color space for a decoded JPEG, or "" if
+// it cannot be embedded directly.
+func jpegColorSpace(img image.Image) string {
+	switch img.(type) {
+	case *image.Gray:
+		return "DeviceGray"
+	case *image.YCbCr:
+		return "DeviceRGB"
+	}
+	return ""
+}
+
+// encodeFlate encodes img as Flate-compressed RGB, flattening any
+// transparency onto white.
+func encodeFlate(img image.Image) *pdfImage {
+	b := img.Bounds()
+	var raw bytes.Buffer
+	raw.Grow(b.Dx() * b.Dy() * 3)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			a := uint32(c.A)
+			blend := func(v uint8) byte {
+				return byte((uint32(v)*a + 255*(255-a)) / 255)
+			}
+			raw.WriteByte(blend(c.R))
+			raw.WriteByte(blend(c.G))
+			raw.WriteByte(blend(c.B))
+		}
+	}
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(raw.Bytes())
+	zw.Close()
+	return &pdfImage{width: b.Dx(), height: b.Dy(), colorSpace: "DeviceRGB", filter: "FlateDecode", data: compressed.Bytes(), decoded: img}
+}
+
+// layout returns the images to place on fixed-size pages: img itself, or
+// with Split set, horizontal slices of it that each fill one page.
+func layout(img *pdfImage, opts PageOptions) []*pdfImage {
+	availW := opts.Size.Width - 2*opts.Margin
+	availH := opts.Size.Height - 2*opts.Margin
+	scale := availW / float64(img.width)
+	sliceHeight := int(availH / scale)
+	if !opts.Split || img.height <= sliceHeight || sliceHeight < 1 {
+		return []*pdfImage{img}
+	}
+
+	var parts []*pdfImage
+	b := img.decoded.Bounds()
+	for top := 0; top < img.height; top += sliceHeight {
+		h := min(sliceHeight, img.height-top)
+		slice := image.NewRGBA(image.Rect(0, 0, img.width, h))
+		draw.Draw(slice, slice.Bounds(), img.decoded, image.Pt(b.Min.X, b.Min.Y+top), draw.Src)
+		if img.filter == "DCTDecode" {
+			parts = append(parts, encodeJPEG(slice))
+		} else {
+			parts = append(parts, encodeFlate(slice))
+		}
+	}
+	return parts
+}
+
+// encodeJPEG re-encodes a slice of a JPEG image as JPEG.
+func encodeJPEG(img image.Image) *pdfImage {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		return encodeFlate(img)
+	}
+	b := img.Bounds()
+	return &pdfImage{width: b.Dx(), height: b.Dy(), colorSpace: "DeviceRGB", filter: "DCTDecode", data: buf.Bytes(), decoded: img}
+}
+
+// writer assembles a PDF document object by object.
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+	pages   []int
+}
+
+// Objects 1 and 2 are reserved for the catalog and the page tree.
+func newWriter() *writer {
+	w := &writer{offsets: make([]int, 3)}
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	return w
+}
+
+// object writes an indirect object with the given number.
+func (w *writer) object(num int, body string, stream []byte) {
+	for len(w.offsets) <= num {
+		w.offsets = append(w.offsets, 0)
+	}
+	w.offsets[num] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\n", num, body)
+	if stream != nil {
+		w.buf.WriteString("stream\n")
+		w.buf.Write(stream)
+		w.buf.WriteString("\nendstream\n")
+	}
+	w.buf.WriteString("endobj\n")
+}
+
+// next returns the next free object number.
+func (w *writer) next() int {
+	w.offsets = append(w.offsets, 0)
+	return len(w.offsets) - 1
+}
+
+// addPage adds a page showing img scaled to fit inside the margins, centered
+// horizontally and aligned to the top.
+func (w *writer) addPage(img *pdfImage, size PageSize, margin float64) {
+	imageNum, contentNum, pageNum := w.next(), w.next(), w.next()
+
+	w.object(imageNum, fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s /Length %d >>",
+		img.width, img.height, img.colorSpace, img.filter, len(img.data)), img.data)
+
+	availW, availH := size.Width-2*margin, size.Height-2*margin
+	scale := min(availW/float64(img.width), availH/float64(img.height))
+	drawW, drawH := float64(img.width)*scale, float64(img.height)*scale
+	x := margin + (availW-drawW)/2
+	y := size.Height - margin - drawH
+	content := []byte(fmt.Sprintf("q %s 0 0 %s %s %s cm /Im0 Do Q", num(drawW), num(drawH), num(x), num(y)))
+	w.object(contentNum, fmt.Sprintf("<< /Length %d >>", len(content)), content)
+
+	w.object(pageNum, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /XObject << /Im0 %d 0 R >> >> /Contents %d 0 R >>",
+		num(size.Width), num(size.Height), imageNum, contentNum), nil)
+	w.pages = append(w.pages, pageNum)
+}
+
+// finish writes the catalog, page tree, document information, and
+// cross-reference table, and returns the document.
+func (w *writer) finish(title string) []byte {
+	kids := make([]string, len(w.pages))
+	for i, p := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", p)
+	}
+	w.object(1, "<< /Type /Catalog /Pages 2 0 R >>", nil)
+	w.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)), nil)
+
+	info := 0
+	if title != "" {
+		info = w.next()
+		w.object(info, fmt.Sprintf("<< /Title %s /Producer (allscreenshots-sdk-go) >>", text(title)), nil)
+	}
+
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets))
+	for _, off := range w.offsets[1:] {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root 1 0 R", len(w.offsets))
+	if info != 0 {
+		fmt.Fprintf(&w.buf, " /Info %d 0 R", info)
+	}
+	fmt.Fprintf(&w.buf, " >>\nstartxref\n%d\n%%%%EOF\n", xref)
+	return w.buf.Bytes()
+}
+
+// num formats a number for a PDF content stream.
+func num(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-" {
+		return "0"
+	}
+	return s
+}
+
+// text encodes s as a PDF text string. ASCII is written as a literal
+// string; anything else as UTF-16BE with a byte order mark.
+func text(s string) string {
+	ascii := true
+	for _, r := range s {
+		if r > 0x7e || r < 0x20 {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		r := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+		return "(" + r.Replace(s) + ")"
+	}
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, r := range s {
+		if r > 0xffff {
+			r -= 0x10000
+			fmt.Fprintf(&b, "%04X%04X", 0xd800+(r>>10), 0xdc00+(r&0x3ff))
+			continue
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	b.WriteString(">")
+	return b.String()
+}
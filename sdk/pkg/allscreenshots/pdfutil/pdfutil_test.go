@@ -0,0 +1,101 @@
+package pdfutil
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImage(w, h int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func pngBytes(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func jpegBytes(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+// checkXref verifies that every cross-reference entry points at the start of
+// its object.
+func checkXref(t *testing.T, pdf []byte) {
+	start := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	require.NotNil(t, start)
+	off, err := strconv.Atoi(string(start[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(pdf[off:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[off:], -1)
+	for i, e := range entries {
+		pos, _ := strconv.Atoi(string(e[1]))
+		assert.True(t, bytes.HasPrefix(pdf[pos:], []byte(strconv.Itoa(i+1)+" 0 obj")), "object %d", i+1)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	pdf, err := Merge([][]byte{
+		pngBytes(t, testImage(200, 100)),
+		jpegBytes(t, testImage(100, 300)),
+	}, PageOptions{Title: "Site (archive)"})
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), "/Count 2")
+	assert.Contains(t, string(pdf), "/Filter /FlateDecode")
+	assert.Contains(t, string(pdf), "/Filter /DCTDecode")
+	assert.Contains(t, string(pdf), "/MediaBox [0 0 150 75]")
+	assert.Contains(t, string(pdf), `/Title (Site \(archive\))`)
+	checkXref(t, pdf)
+}
+
+func TestMerge_Split(t *testing.T) {
+	tall := pngBytes(t, testImage(100, 1000))
+	size := PageSize{Width: 100, Height: 300}
+
+	pdf, err := Merge([][]byte{tall}, PageOptions{Size: size})
+	require.NoError(t, err)
+	assert.Contains(t, string(pdf), "/Count 1")
+
+	pdf, err = Merge([][]byte{tall}, PageOptions{Size: size, Split: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(pdf), "/Count 4")
+	assert.Contains(t, string(pdf), "/Height 100 ")
+	checkXref(t, pdf)
+}
+
+func TestMerge_Errors(t *testing.T) {
+	_, err := Merge(nil, PageOptions{})
+	assert.ErrorContains(t, err, "at least one image is required")
+
+	_, err = Merge([][]byte{[]byte("not an image")}, PageOptions{})
+	assert.ErrorContains(t, err, "image 0")
+
+	_, err = Merge([][]byte{pngBytes(t, testImage(10, 10))}, PageOptions{Size: A4, Margin: 400})
+	assert.ErrorContains(t, err, "larger than the margins")
+}
+
+func TestText(t *testing.T) {
+	assert.Equal(t, `(a\\b)`, text(`a\b`))
+	assert.Equal(t, "<FEFF00E9>", text("é"))
+}
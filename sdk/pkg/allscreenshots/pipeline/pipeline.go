@@ -0,0 +1,273 @@
+// Package pipeline runs the capture, store, and notify flow as a sequence of
+// stages: user-supplied pre stages prepare each request, the capture stage
+// takes the screenshot, and post stages consume the result.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+// DefaultConcurrency is the number of items a pipeline processes at once by default.
+const DefaultConcurrency = 4
+
+// ErrSkip may be returned by a stage to drop an item from the rest of the
+// pipeline without treating it as a failure.
+var ErrSkip = errors.New("pipeline: item skipped")
+
+// ErrorPolicy decides what happens to the other items when one fails.
+type ErrorPolicy int
+
+const (
+	// ContinueOnError records the failure on the item and keeps processing
+	// the others (the default)
+	ContinueOnError ErrorPolicy = iota
+	// StopOnError cancels the items in flight and skips the remaining ones
+	// after the first failure
+	StopOnError
+)
+
+// Item is one input flowing through a pipeline.
+type Item struct {
+	// Index of the input in the slice passed to Run
+	Index int
+	// Request to capture, as passed to Run; pre stages may modify or
+	// replace it
+	Request *allscreenshots.ScreenshotRequest
+	// Data is the captured image, set for post stages
+	Data []byte
+	// ContentType of Data, detected from its contents
+	ContentType string
+	// Values passes data between stages, e.g. the URL an upload stage
+	// stored the image at
+	Values map[string]interface{}
+	// Skipped is set when a stage returned ErrSkip
+	Skipped bool
+	// Err is the failure that stopped the item, if any
+	Err error
+}
+
+// Set stores a value for later stages.
+func (it *Item) Set(key string, value interface{}) {
+	if it.Values == nil {
+		it.Values = make(map[string]interface{})
+	}
+	it.Values[key] = value
+}
+
+// Stage processes one item. Stages of the same item run in order; stages of
+// different items run concurrently, so a stage must be safe for concurrent
+// use or be wrapped with Limit.
+type Stage func(ctx context.Context, item *Item) error
+
+// Limit wraps stage so at most n items run it at once, e.g. to stay within
+// a storage or webhook rate.
+//
+// Example:
+//
+//	p.Post(pipeline.Limit(2, upload), notify)
+func Limit(n int, stage Stage) Stage {
+	if n <= 0 {
+		n = 1
+	}
+	sem := make(chan struct{}, n)
+	return func(ctx context.Context, item *Item) error {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		defer func() { <-sem }()
+		return stage(ctx, item)
+	}
+}
+
+// Pipeline captures a list of inputs through pre stages, the capture stage,
+// and post stages. Build it with New and the chainable methods, then call
+// Run; a built pipeline may be run any number of times.
+type Pipeline struct {
+	client      *allscreenshots.Client
+	pre         []Stage
+	post        []Stage
+	capture     bool
+	concurrency int
+	policy      ErrorPolicy
+}
+
+// New returns an empty pipeline capturing with client.
+//
+// Example:
+//
+//	results, err := pipeline.New(client).
+//	    Pre(normalizeURL).
+//	    Capture().
+//	    Post(upload, notify).
+//	    Concurrency(8).
+//	    Run(ctx, pipeline.URLs(urls...))
+func New(client *allscreenshots.Client) *Pipeline {
+	return &Pipeline{client: client, concurrency: DefaultConcurrency}
+}
+
+// Pre appends stages that run before the capture.
+func (p *Pipeline) Pre(stages ...Stage) *Pipeline {
+	p.pre = append(p.pre, stages...)
+	return p
+}
+
+// Capture adds the capture stage, which takes each item's screenshot with
+// the client's Screenshot method.
+func (p *Pipeline) Capture() *Pipeline {
+	p.capture = true
+	return p
+}
+
+// Post appends stages that run after the capture.
+func (p *Pipeline) Post(stages ...Stage) *Pipeline {
+	p.post = append(p.post, stages...)
+	return p
+}
+
+// Concurrency sets how many items are processed at once (default 4).
+func (p *Pipeline) Concurrency(n int) *Pipeline {
+	p.concurrency = n
+	return p
+}
+
+// OnError sets what happens to the other items when one fails.
+func (p *Pipeline) OnError(policy ErrorPolicy) *Pipeline {
+	p.policy = policy
+	return p
+}
+
+// Run sends every input through the pipeline and returns the items in input
+// order. With ContinueOnError, the returned error joins the failures of all
+// items; with StopOnError, it is the first failure. Skipped items are not
+// failures.
+func (p *Pipeline) Run(ctx context.Context, inputs []*allscreenshots.ScreenshotRequest) ([]*Item, error) {
+	if p.client == nil {
+		return nil, errors.New("pipeline: client is required")
+	}
+	if !p.capture {
+		return nil, errors.New("pipeline: Capture was not called")
+	}
+	concurrency := p.concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	items := make([]*Item, len(inputs))
+	for i, req := range inputs {
+		items[i] = &Item{Index: i, Request: req}
+	}
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, item := range items {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			item.Err = ctx.Err()
+			continue
+		}
+		if ctx.Err() != nil {
+			<-sem
+			item.Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(item *Item) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			p.process(ctx, item)
+			if item.Err != nil && p.policy == StopOnError {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = item.Err
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	if p.policy == StopOnError {
+		return items, firstErr
+	}
+	var errs []error
+	for _, item := range items {
+		if item.Err != nil {
+			errs = append(errs, fmt.Errorf("item %d (%s): %w", item.Index, itemURL(item), item.Err))
+		}
+	}
+	return items, errors.Join(errs...)
+}
+
+// process runs every stage for one item, stopping at the first failure or skip.
+func (p *Pipeline) process(ctx context.Context, item *Item) {
+	run := func(kind string, stages []Stage) bool {
+		for i, stage := range stages {
+			if err := stage(ctx, item); err != nil {
+				p.fail(item, fmt.Errorf("%s stage %d: %w", kind, i, err))
+				return false
+			}
+		}
+		return true
+	}
+
+	if !run("pre", p.pre) {
+		return
+	}
+	if item.Request == nil {
+		item.Err = errors.New("pipeline: request is nil")
+		return
+	}
+	data, err := p.client.Screenshot(ctx, item.Request)
+	if err != nil {
+		p.fail(item, fmt.Errorf("capture: %w", err))
+		return
+	}
+	item.Data = data
+	item.ContentType = http.DetectContentType(data)
+	run("post", p.post)
+}
+
+// fail records err on item, or marks it skipped for ErrSkip.
+func (p *Pipeline) fail(item *Item, err error) {
+	if errors.Is(err, ErrSkip) {
+		item.Skipped = true
+		return
+	}
+	item.Err = err
+}
+
+// URLs returns one request per URL, for inputs that need no other options.
+func URLs(urls ...string) []*allscreenshots.ScreenshotRequest {
+	reqs := make([]*allscreenshots.ScreenshotRequest, len(urls))
+	for i, u := range urls {
+		reqs[i] = &allscreenshots.ScreenshotRequest{URL: u}
+	}
+	return reqs
+}
+
+// itemURL returns the URL of item's request, for error messages.
+func itemURL(item *Item) string {
+	if item.Request == nil {
+		return ""
+	}
+	return item.Request.URL
+}
@@ -0,0 +1,178 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func newServer(t *testing.T, fail map[string]bool) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req allscreenshots.ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if fail[req.URL] {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"URL unreachable","code":"URL_UNREACHABLE"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(append(pngHeader, req.URL...))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPipeline_Run(t *testing.T) {
+	server := newServer(t, nil)
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(server.URL))
+
+	normalize := func(ctx context.Context, item *Item) error {
+		if !strings.HasPrefix(item.Request.URL, "https://") {
+			item.Request.URL = "https://" + item.Request.URL
+		}
+		return nil
+	}
+	skipInternal := func(ctx context.Context, item *Item) error {
+		if strings.Contains(item.Request.URL, "internal") {
+			return ErrSkip
+		}
+		return nil
+	}
+	var mu sync.Mutex
+	stored := map[string]string{}
+	upload := func(ctx context.Context, item *Item) error {
+		key := "shots/" + strings.TrimPrefix(item.Request.URL, "https://") + ".png"
+		mu.Lock()
+		stored[key] = item.ContentType
+		mu.Unlock()
+		item.Set("key", key)
+		return nil
+	}
+	var notified []string
+	notify := func(ctx context.Context, item *Item) error {
+		mu.Lock()
+		notified = append(notified, item.Values["key"].(string))
+		mu.Unlock()
+		return nil
+	}
+
+	items, err := New(client).
+		Pre(normalize, skipInternal).
+		Capture().
+		Post(upload, notify).
+		Concurrency(2).
+		Run(context.Background(), URLs("example.com", "https://github.com", "internal.example.com"))
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	assert.Equal(t, append(pngHeader, "https://example.com"...), items[0].Data)
+	assert.Equal(t, "image/png", items[0].ContentType)
+	assert.Equal(t, "shots/github.com.png", items[1].Values["key"])
+	assert.True(t, items[2].Skipped)
+	assert.Nil(t, items[2].Data)
+	assert.NoError(t, items[2].Err)
+
+	assert.Len(t, stored, 2)
+	assert.ElementsMatch(t, []string{"shots/example.com.png", "shots/github.com.png"}, notified)
+}
+
+func TestPipeline_ContinueOnError(t *testing.T) {
+	server := newServer(t, map[string]bool{"https://broken.example.com": true})
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(server.URL))
+
+	uploadErr := errors.New("bucket unavailable")
+	var posts atomic.Int32
+	items, err := New(client).
+		Capture().
+		Post(func(ctx context.Context, item *Item) error {
+			posts.Add(1)
+			if item.Index == 2 {
+				return uploadErr
+			}
+			return nil
+		}).
+		Run(context.Background(), URLs("https://example.com", "https://broken.example.com", "https://github.com"))
+	require.Error(t, err)
+
+	assert.NoError(t, items[0].Err)
+	var apiErr *allscreenshots.APIError
+	require.ErrorAs(t, items[1].Err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Contains(t, items[1].Err.Error(), "capture: ")
+	assert.ErrorIs(t, items[2].Err, uploadErr)
+	assert.Contains(t, items[2].Err.Error(), "post stage 0: ")
+	assert.Equal(t, int32(2), posts.Load())
+
+	assert.Contains(t, err.Error(), "item 1 (https://broken.example.com)")
+	assert.ErrorIs(t, err, uploadErr)
+}
+
+func TestPipeline_StopOnError(t *testing.T) {
+	server := newServer(t, nil)
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(server.URL))
+
+	stop := errors.New("invalid input")
+	items, err := New(client).
+		Pre(func(ctx context.Context, item *Item) error {
+			if item.Index == 0 {
+				return stop
+			}
+			return nil
+		}).
+		Capture().
+		Concurrency(1).
+		OnError(StopOnError).
+		Run(context.Background(), URLs("https://a.example.com", "https://b.example.com", "https://c.example.com"))
+	assert.ErrorIs(t, err, stop)
+	assert.ErrorIs(t, items[0].Err, stop)
+	for _, item := range items[1:] {
+		assert.ErrorIs(t, item.Err, context.Canceled)
+		assert.Nil(t, item.Data)
+	}
+}
+
+func TestLimit(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	stage := Limit(2, func(ctx context.Context, item *Item) error {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, stage(context.Background(), &Item{}))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestPipeline_RequiresCapture(t *testing.T) {
+	_, err := New(allscreenshots.NewClient()).Run(context.Background(), URLs("https://example.com"))
+	assert.EqualError(t, err, "pipeline: Capture was not called")
+}
@@ -0,0 +1,187 @@
+package allscreenshots
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultPoolConcurrency is the number of captures a Pool runs at once by default.
+const DefaultPoolConcurrency = 4
+
+// PoolOptions configures a Pool.
+type PoolOptions struct {
+	// Concurrency is the maximum number of captures in flight (default 4)
+	Concurrency int
+	// RPS limits how many captures are started per second; 0 means no limit
+	RPS float64
+	// Store, if set, receives every successful capture
+	Store ResultStore
+	// StoreKey returns the key a capture is stored under; the default is
+	// "<host>/<RequestKey><ext>"
+	StoreKey func(req *ScreenshotRequest, contentType string) string
+}
+
+// PoolResult is the outcome of a single capture run by a Pool.
+type PoolResult struct {
+	// Index of the request in the slice passed to CaptureAll; 0 for Go
+	Index int
+	// Request that was captured
+	Request *ScreenshotRequest
+	// Data is the image bytes when the capture succeeded
+	Data []byte
+	// Key the capture was stored under, when the pool has a Store
+	Key string
+	// Err is the capture error, if any
+	Err error
+}
+
+// Pool runs synchronous captures concurrently with a bounded number in
+// flight and an optional start rate. Each capture uses the client's own
+// retry policy, so a failing URL never affects the others. A Pool is safe
+// for concurrent use.
+type Pool struct {
+	client   *Client
+	sem      chan struct{}
+	limiter  *rateLimiter
+	store    ResultStore
+	storeKey func(req *ScreenshotRequest, contentType string) string
+}
+
+// NewPool creates a capture pool backed by client.
+//
+// Example:
+//
+//	pool := allscreenshots.NewPool(client, allscreenshots.PoolOptions{Concurrency: 8, RPS: 5})
+//	results, err := pool.CaptureAll(ctx, requests)
+//	for _, r := range results {
+//	    if r.Err != nil {
+//	        log.Printf("%s: %v", r.Request.URL, r.Err)
+//	        continue
+//	    }
+//	    os.WriteFile(fmt.Sprintf("shot-%d.png", r.Index), r.Data, 0644)
+//	}
+func NewPool(client *Client, opts PoolOptions) *Pool {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultPoolConcurrency
+	}
+	if opts.StoreKey == nil {
+		opts.StoreKey = storeKey
+	}
+	p := &Pool{
+		client:   client,
+		sem:      make(chan struct{}, opts.Concurrency),
+		store:    opts.Store,
+		storeKey: opts.StoreKey,
+	}
+	if opts.RPS > 0 {
+		p.limiter = &rateLimiter{interval: time.Duration(float64(time.Second) / opts.RPS)}
+	}
+	return p
+}
+
+// Go starts a capture and returns a channel that receives its result once
+// and is then closed.
+func (p *Pool) Go(ctx context.Context, req *ScreenshotRequest) <-chan PoolResult {
+	out := make(chan PoolResult, 1)
+	go func() {
+		defer close(out)
+		out <- p.capture(ctx, 0, req)
+	}()
+	return out
+}
+
+// CaptureAll captures every request and returns the results in the same
+// order as reqs. All captures are attempted; the returned error joins the
+// errors of those that failed and is nil when all succeeded.
+func (p *Pool) CaptureAll(ctx context.Context, reqs []*ScreenshotRequest) ([]PoolResult, error) {
+	results := make([]PoolResult, len(reqs))
+
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		go func(i int, req *ScreenshotRequest) {
+			defer wg.Done()
+			results[i] = p.capture(ctx, i, req)
+		}(i, req)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			url := ""
+			if r.Request != nil {
+				url = r.Request.URL
+			}
+			errs = append(errs, fmt.Errorf("request %d (%s): %w", r.Index, url, r.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// capture runs one capture once a slot and a rate limit token are available.
+func (p *Pool) capture(ctx context.Context, index int, req *ScreenshotRequest) PoolResult {
+	result := PoolResult{Index: index, Request: req}
+
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		result.Err = timeoutError(ctx.Err())
+		return result
+	}
+	defer func() { <-p.sem }()
+
+	if p.limiter != nil {
+		if err := p.limiter.wait(ctx); err != nil {
+			result.Err = err
+			return result
+		}
+	}
+
+	result.Data, result.Err = p.client.Screenshot(ctx, req)
+	if result.Err == nil && p.store != nil {
+		contentType := http.DetectContentType(result.Data)
+		result.Key = p.storeKey(req, contentType)
+		result.Err = p.store.Put(ctx, result.Key, result.Data, ResultMeta{
+			ContentType: contentType,
+			SourceURL:   req.URL,
+			CreatedAt:   time.Now(),
+		})
+	}
+	return result
+}
+
+// rateLimiter spaces out events by a fixed interval.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the next event may start or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return timeoutError(ctx.Err())
+	}
+}
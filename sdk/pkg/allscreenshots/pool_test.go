@@ -0,0 +1,142 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_CaptureAll(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		var req ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.URL == "https://example.com/broken" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(req.URL))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	pool := NewPool(client, PoolOptions{Concurrency: 3})
+
+	reqs := []*ScreenshotRequest{
+		{URL: "https://example.com/1"},
+		{URL: "https://example.com/2"},
+		{URL: "https://example.com/broken"},
+		{URL: "https://example.com/4"},
+		{URL: "https://example.com/5"},
+		{URL: "https://example.com/6"},
+	}
+	results, err := pool.CaptureAll(context.Background(), reqs)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "request 2 (https://example.com/broken)")
+	require.Len(t, results, len(reqs))
+	for i, r := range results {
+		assert.Equal(t, i, r.Index)
+		assert.Same(t, reqs[i], r.Request)
+		if i == 2 {
+			assert.True(t, IsBadRequest(r.Err))
+			continue
+		}
+		require.NoError(t, r.Err)
+		assert.Equal(t, reqs[i].URL, string(r.Data))
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(3))
+}
+
+func TestPool_Go(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	pool := NewPool(client, PoolOptions{})
+
+	result := <-pool.Go(context.Background(), &ScreenshotRequest{URL: "https://example.com"})
+	require.NoError(t, result.Err)
+	assert.Len(t, result.Data, 4)
+}
+
+func TestPool_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte{0x89})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	pool := NewPool(client, PoolOptions{Concurrency: 10, RPS: 50})
+
+	reqs := make([]*ScreenshotRequest, 5)
+	for i := range reqs {
+		reqs[i] = &ScreenshotRequest{URL: "https://example.com"}
+	}
+
+	start := time.Now()
+	_, err := pool.CaptureAll(context.Background(), reqs)
+	require.NoError(t, err)
+	// Five starts at 50/s are spaced 20ms apart: at least 80ms in total.
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func TestPool_ContextCancelled(t *testing.T) {
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL("http://127.0.0.1:1"))
+	pool := NewPool(client, PoolOptions{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := pool.CaptureAll(ctx, []*ScreenshotRequest{{URL: "https://example.com"}})
+	require.Error(t, err)
+	assert.ErrorIs(t, results[0].Err, context.Canceled)
+}
+
+func TestPool_RateLimitTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte{0x89})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	pool := NewPool(client, PoolOptions{Concurrency: 10, RPS: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	results, err := pool.CaptureAll(ctx, []*ScreenshotRequest{{URL: "https://example.com"}, {URL: "https://example.org"}})
+	require.Error(t, err)
+
+	// One capture starts at once; the other is still waiting for the rate
+	// limiter when the deadline passes.
+	var timedOut int
+	for _, r := range results {
+		if r.Err != nil {
+			var timeoutErr *TimeoutError
+			assert.ErrorAs(t, r.Err, &timeoutErr)
+			assert.ErrorIs(t, r.Err, context.DeadlineExceeded)
+			timedOut++
+		}
+	}
+	assert.Equal(t, 1, timedOut)
+}
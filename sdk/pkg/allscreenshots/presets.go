@@ -0,0 +1,152 @@
+package allscreenshots
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RegisterPreset stores req under name so captures can share its settings
+// with ScreenshotPreset and Preset. The preset's URL is ignored; it is set
+// when the preset is used. Registering a name again replaces the preset.
+//
+// Example:
+//
+//	err := client.RegisterPreset("blog-card", allscreenshots.ScreenshotRequest{
+//	    Viewport:   &allscreenshots.ViewportConfig{Width: 1200, Height: 630},
+//	    Format:     "png",
+//	    BlockLevel: "pro",
+//	})
+func (c *Client) RegisterPreset(name string, req ScreenshotRequest) error {
+	if name == "" {
+		return &ValidationError{Field: "name", Message: "preset name is required"}
+	}
+	req.URL = ""
+	stored := req.Clone()
+
+	c.presetsMu.Lock()
+	defer c.presetsMu.Unlock()
+	if c.presets == nil {
+		c.presets = make(map[string]*ScreenshotRequest)
+	}
+	c.presets[name] = stored
+	return nil
+}
+
+// RegisterPresets registers every preset in presets, e.g. the result of
+// LoadPresets.
+func (c *Client) RegisterPresets(presets map[string]ScreenshotRequest) error {
+	for name, req := range presets {
+		if err := c.RegisterPreset(name, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Preset returns a new request for targetURL with the settings of the named
+// preset, for use with ScreenshotAsync, bulk jobs, or further changes. The
+// returned request shares nothing with the registered preset.
+//
+// Example:
+//
+//	req, err := client.Preset("blog-card", "https://example.com/post/1")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	req.DarkMode = true
+//	job, err := client.ScreenshotAsync(ctx, req)
+func (c *Client) Preset(name, targetURL string) (*ScreenshotRequest, error) {
+	c.presetsMu.RLock()
+	preset, ok := c.presets[name]
+	c.presetsMu.RUnlock()
+	if !ok {
+		return nil, &ValidationError{Field: "preset", Message: fmt.Sprintf("preset %q is not registered", name)}
+	}
+
+	req := preset.Clone()
+	req.URL = targetURL
+	return req, nil
+}
+
+// Presets returns the names of the registered presets, sorted.
+func (c *Client) Presets() []string {
+	c.presetsMu.RLock()
+	defer c.presetsMu.RUnlock()
+	names := make([]string, 0, len(c.presets))
+	for name := range c.presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ScreenshotPreset captures targetURL with the settings of the named preset.
+//
+// Example:
+//
+//	imageData, err := client.ScreenshotPreset(ctx, "blog-card", "https://example.com/post/1")
+func (c *Client) ScreenshotPreset(ctx context.Context, name, targetURL string) ([]byte, error) {
+	req, err := c.Preset(name, targetURL)
+	if err != nil {
+		return nil, err
+	}
+	return c.Screenshot(ctx, req)
+}
+
+// LoadPresets reads presets from a YAML file mapping preset names to
+// requests. Request fields use the same names as the API's JSON, so presets
+// can be shared with services written in other languages.
+//
+// Example presets.yaml:
+//
+//	blog-card:
+//	  viewport: {width: 1200, height: 630}
+//	  format: png
+//	  blockLevel: pro
+//	mobile-full:
+//	  device: iPhone 14
+//	  fullPage: true
+//	  blockCookieBanners: true
+func LoadPresets(path string) (map[string]ScreenshotRequest, error) {
+	data, err := os.ReadFile(expandHome(path))
+	if err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to read presets: %w", err)
+	}
+	presets, err := ParsePresets(data)
+	if err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to parse presets %s: %w", path, err)
+	}
+	return presets, nil
+}
+
+// ParsePresets parses presets in the format read by LoadPresets. Unknown
+// fields are rejected so typos do not silently change captures.
+func ParsePresets(data []byte) (map[string]ScreenshotRequest, error) {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	presets := make(map[string]ScreenshotRequest, len(raw))
+	for name, fields := range raw {
+		// Round-trip through JSON so fields are matched by their JSON names.
+		encoded, err := json.Marshal(fields)
+		if err != nil {
+			return nil, fmt.Errorf("preset %q: %w", name, err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(encoded))
+		dec.DisallowUnknownFields()
+		var req ScreenshotRequest
+		if err := dec.Decode(&req); err != nil {
+			return nil, fmt.Errorf("preset %q: %w", name, err)
+		}
+		presets[name] = req
+	}
+	return presets, nil
+}
@@ -0,0 +1,97 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ScreenshotPreset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "https://example.com/post/1", req.URL)
+		assert.Equal(t, 1200, req.Viewport.Width)
+		assert.Equal(t, "png", req.Format)
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	require.NoError(t, client.RegisterPreset("blog-card", ScreenshotRequest{
+		URL:      "https://ignored.example.com",
+		Viewport: &ViewportConfig{Width: 1200, Height: 630},
+		Format:   "png",
+	}))
+
+	data, err := client.ScreenshotPreset(context.Background(), "blog-card", "https://example.com/post/1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png"), data)
+
+	_, err = client.ScreenshotPreset(context.Background(), "missing", "https://example.com")
+	assert.True(t, IsValidationError(err))
+	assert.Contains(t, err.Error(), `preset "missing" is not registered`)
+}
+
+func TestClient_Preset_ReturnsCopy(t *testing.T) {
+	client := NewClient(WithAPIKey("test-api-key"))
+	viewport := &ViewportConfig{Width: 1200, Height: 630}
+	require.NoError(t, client.RegisterPreset("card", ScreenshotRequest{Viewport: viewport, HideSelectors: []string{".ad"}}))
+
+	// Changes to the registered value or to returned requests do not leak.
+	viewport.Width = 1
+	first, err := client.Preset("card", "https://a.example.com")
+	require.NoError(t, err)
+	first.Viewport.Width = 2
+	first.HideSelectors[0] = ".banner"
+
+	second, err := client.Preset("card", "https://b.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 1200, second.Viewport.Width)
+	assert.Equal(t, []string{".ad"}, second.HideSelectors)
+	assert.Equal(t, "https://b.example.com", second.URL)
+	assert.Equal(t, []string{"card"}, client.Presets())
+
+	assert.True(t, IsValidationError(client.RegisterPreset("", ScreenshotRequest{})))
+}
+
+func TestLoadPresets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "presets.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+blog-card:
+  viewport: {width: 1200, height: 630}
+  format: png
+  blockLevel: pro
+mobile-full:
+  device: iPhone 14
+  fullPage: true
+  hideSelectors: [".cookie", "#chat"]
+`), 0o644))
+
+	presets, err := LoadPresets(path)
+	require.NoError(t, err)
+	require.Len(t, presets, 2)
+	assert.Equal(t, &ViewportConfig{Width: 1200, Height: 630}, presets["blog-card"].Viewport)
+	assert.Equal(t, "pro", presets["blog-card"].BlockLevel)
+	assert.True(t, presets["mobile-full"].FullPage)
+	assert.Equal(t, []string{".cookie", "#chat"}, presets["mobile-full"].HideSelectors)
+
+	client := NewClient(WithAPIKey("test-api-key"))
+	require.NoError(t, client.RegisterPresets(presets))
+	assert.Equal(t, []string{"blog-card", "mobile-full"}, client.Presets())
+}
+
+func TestParsePresets_UnknownField(t *testing.T) {
+	_, err := ParsePresets([]byte("card:\n  fullPageMode: true\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `preset "card"`)
+	assert.Contains(t, err.Error(), "fullPageMode")
+}
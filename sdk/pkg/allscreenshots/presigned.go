@@ -0,0 +1,98 @@
+package allscreenshots
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxUploadErrorBody is how much of a failed upload's response is included
+// in the error.
+const maxUploadErrorBody = 512
+
+// ScreenshotToPresignedURL captures a screenshot synchronously and streams it
+// to presignedPUT with an HTTP PUT, as the image is received from the API.
+// The image is never held in memory as a whole, which suits serverless
+// functions with tight memory limits; no storage credentials are needed, only
+// a presigned URL such as an S3 or R2 presigned PUT URL, a Google Cloud
+// Storage signed URL, or an Azure Blob SAS URL.
+//
+// The upload is sent with the Content-Length and Content-Type of the API's
+// response, so a URL signed for a specific content type must match the
+// requested format. The response is requested without compression so its
+// length is known; if it still is not, an error is returned rather than a
+// chunked upload, which presigned PUT URLs reject. Uploads to Azure Blob
+// Storage also carry the x-ms-blob-type header it requires. The API key is
+// never sent to the presigned URL.
+//
+// Example:
+//
+//	presigned, _ := s3.NewPresignClient(s3Client).PresignPutObject(ctx, &s3.PutObjectInput{
+//	    Bucket: aws.String("screenshots"),
+//	    Key:    aws.String("captures/example.png"),
+//	})
+//	err := client.ScreenshotToPresignedURL(ctx, &allscreenshots.ScreenshotRequest{
+//	    URL: "https://example.com",
+//	}, presigned.URL)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Client) ScreenshotToPresignedURL(ctx context.Context, req *ScreenshotRequest, presignedPUT string) error {
+	req = c.normalizeRequestURL(req)
+	if err := validateScreenshotRequest(req); err != nil {
+		return err
+	}
+	if presignedPUT == "" {
+		return &ValidationError{Field: "presignedURL", Message: "presigned URL is required"}
+	}
+	if !strings.HasPrefix(presignedPUT, "http://") && !strings.HasPrefix(presignedPUT, "https://") {
+		return &ValidationError{Field: "presignedURL", Message: "presigned URL must start with http:// or https://"}
+	}
+
+	release, err := c.acquireRender(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	header := http.Header{"Accept-Encoding": {"identity"}}
+	return c.requestRawHeader(ctx, http.MethodPost, c.endpoint("/screenshots"), c.applyAutoTimeout(ctx, c.applyDefaults(req)), header, func(resp *http.Response) error {
+		return c.putPresigned(ctx, presignedPUT, resp.Body, resp.ContentLength, resp.Header.Get("Content-Type"))
+	})
+}
+
+// putPresigned uploads body to a presigned URL. size is the body's length in
+// bytes; an unknown (negative) size is refused, as presigned PUT URLs reject
+// chunked uploads.
+func (c *Client) putPresigned(ctx context.Context, presignedURL string, body io.Reader, size int64, contentType string) error {
+	if size < 0 {
+		return fmt.Errorf("allscreenshots: presigned upload needs the image size, but the API response has no Content-Length")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, presignedURL, body)
+	if err != nil {
+		return fmt.Errorf("allscreenshots: failed to create upload request: %w", err)
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if strings.Contains(presignedURL, ".blob.core.windows.net/") {
+		req.Header.Set("X-Ms-Blob-Type", "BlockBlob")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &NetworkError{Message: "presigned upload failed", Cause: err}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxUploadErrorBody))
+		if msg := strings.TrimSpace(string(data)); msg != "" {
+			return fmt.Errorf("allscreenshots: presigned upload failed: %s: %s", resp.Status, msg)
+		}
+		return fmt.Errorf("allscreenshots: presigned upload failed: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package allscreenshots
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ScreenshotToPresignedURL(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots", r.URL.Path)
+		assert.Equal(t, "identity", r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png-bytes"))
+	}))
+	defer api.Close()
+
+	var uploaded []byte
+	var status int
+	bucket := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/captures/example.png", r.URL.Path)
+		assert.Equal(t, "sig", r.URL.Query().Get("X-Amz-Signature"))
+		assert.Equal(t, "image/png", r.Header.Get("Content-Type"))
+		assert.Equal(t, int64(9), r.ContentLength)
+		assert.Empty(t, r.Header.Get("X-API-Key"))
+		uploaded, _ = io.ReadAll(r.Body)
+		if status != 0 {
+			w.WriteHeader(status)
+			w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+		}
+	}))
+	defer bucket.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(api.URL))
+	presigned := bucket.URL + "/captures/example.png?X-Amz-Signature=sig"
+
+	err := client.ScreenshotToPresignedURL(context.Background(), &ScreenshotRequest{URL: "https://example.com"}, presigned)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png-bytes"), uploaded)
+
+	status = http.StatusForbidden
+	err = client.ScreenshotToPresignedURL(context.Background(), &ScreenshotRequest{URL: "https://example.com"}, presigned)
+	assert.EqualError(t, err, "allscreenshots: presigned upload failed: 403 Forbidden: <Error><Code>AccessDenied</Code></Error>")
+
+	err = client.ScreenshotToPresignedURL(context.Background(), &ScreenshotRequest{URL: "https://example.com"}, "")
+	assert.True(t, IsValidationError(err))
+	err = client.ScreenshotToPresignedURL(context.Background(), &ScreenshotRequest{URL: "https://example.com"}, "s3://screenshots/example.png")
+	assert.ErrorContains(t, err, "presigned URL must start with http:// or https://")
+}
+
+func TestClient_ScreenshotToPresignedURL_UnknownLength(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		// Flushing before the body is written sends it chunked, without a
+		// Content-Length.
+		w.(http.Flusher).Flush()
+		w.Write([]byte("png-bytes"))
+	}))
+	defer api.Close()
+
+	var uploads int
+	bucket := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploads++
+	}))
+	defer bucket.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(api.URL))
+	err := client.ScreenshotToPresignedURL(context.Background(), &ScreenshotRequest{URL: "https://example.com"}, bucket.URL+"/captures/example.png")
+	assert.EqualError(t, err, "allscreenshots: presigned upload needs the image size, but the API response has no Content-Length")
+	assert.Zero(t, uploads)
+}
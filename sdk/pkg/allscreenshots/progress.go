@@ -0,0 +1,427 @@
+package allscreenshots
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ProgressEventType identifies what a progress event reports.
+type ProgressEventType string
+
+// Progress event types delivered by BulkProgress and ComposeProgress.
+const (
+	// ProgressJobStarted reports that a job began processing
+	ProgressJobStarted ProgressEventType = "job.started"
+	// ProgressJobCompleted reports that a job, or for compose a capture, completed
+	ProgressJobCompleted ProgressEventType = "job.completed"
+	// ProgressJobFailed reports that a job failed or was cancelled
+	ProgressJobFailed ProgressEventType = "job.failed"
+	// ProgressBatchDone reports that the bulk or compose job finished; no
+	// events follow it
+	ProgressBatchDone ProgressEventType = "batch.done"
+)
+
+// BulkProgressEvent reports a change in the progress of a bulk job.
+type BulkProgressEvent struct {
+	// Type of the event; empty when the event only carries Err
+	Type ProgressEventType
+	// Job that changed; nil for ProgressBatchDone
+	Job *BulkJobDetailInfo
+	// Completed is the number of jobs completed so far
+	Completed int
+	// Failed is the number of jobs failed or cancelled so far
+	Failed int
+	// Total is the number of jobs in the bulk job
+	Total int
+	// Err is set on the final event when progress tracking stopped because
+	// of an error
+	Err error
+}
+
+// ComposeProgressEvent reports a change in the progress of a compose job.
+type ComposeProgressEvent struct {
+	// Type of the event; empty when the event only carries Err
+	Type ProgressEventType
+	// Completed is the number of captures completed so far
+	Completed int
+	// Total is the number of captures in the compose job
+	Total int
+	// Result of the compose job, set on ProgressBatchDone when it succeeded
+	Result *ComposeResponse
+	// Err is set on the final event when the compose job failed, with a
+	// *JobFailedError, or when progress tracking stopped because of an error
+	Err error
+}
+
+// BulkProgress streams typed progress events for a bulk job, for feeding
+// progress bars and dashboards. Events for jobs that started or finished
+// before the call are delivered first. Changes are received from the job
+// events stream when the API offers it and by polling, with the intervals
+// set by opts, otherwise.
+//
+// The channel is closed after the ProgressBatchDone event, after an event
+// carrying Err, or when ctx is cancelled. An error is returned directly if
+// the bulk job cannot be read.
+//
+// Example:
+//
+//	events, err := client.BulkProgress(ctx, bulk.ID)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for event := range events {
+//	    if event.Err != nil {
+//	        log.Fatal(event.Err)
+//	    }
+//	    bar.Set(event.Completed+event.Failed, event.Total)
+//	}
+func (c *Client) BulkProgress(ctx context.Context, id string, opts ...PollOption) (<-chan BulkProgressEvent, error) {
+	status, err := c.GetBulkJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	done, err := c.track()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &bulkProgress{
+		client:   c,
+		id:       id,
+		poll:     newPollConfig(opts),
+		statuses: make(map[string]JobStatus),
+		events:   make(chan BulkProgressEvent),
+	}
+	ctx, cancel := c.closable(ctx)
+	go func() {
+		defer done()
+		defer cancel()
+		t.run(ctx, status)
+	}()
+	return t.events, nil
+}
+
+// bulkProgress tracks the jobs of a bulk job and emits their transitions.
+type bulkProgress struct {
+	client *Client
+	id     string
+	poll   pollConfig
+
+	statuses  map[string]JobStatus
+	total     int
+	completed int
+	failed    int
+	events    chan BulkProgressEvent
+}
+
+// run emits events until the bulk job finishes, preferring the job events
+// stream and falling back to polling.
+func (t *bulkProgress) run(ctx context.Context, status *BulkStatusResponse) {
+	defer close(t.events)
+
+	parent := ctx
+	if t.poll.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.poll.timeout)
+		defer cancel()
+		defer func() {
+			if parent.Err() == nil && ctx.Err() == context.DeadlineExceeded {
+				t.send(parent, BulkProgressEvent{Err: &TimeoutError{
+					Message: fmt.Sprintf("bulk job %s did not finish within %s", t.id, t.poll.timeout),
+				}})
+			}
+		}()
+	}
+
+	if !t.apply(ctx, status) || t.finish(ctx, status) {
+		return
+	}
+	if done, ok := t.stream(ctx, status); done || !ok {
+		return
+	}
+
+	// The timeout is enforced on ctx above.
+	p := t.poll
+	p.timeout = 0
+	pl := p.newPoller("bulk job " + t.id)
+	for {
+		if err := pl.wait(ctx); err != nil {
+			return
+		}
+		before := t.completed + t.failed
+		if done, ok := t.refresh(ctx); done || !ok {
+			return
+		}
+		if t.completed+t.failed != before {
+			pl.reset()
+		}
+	}
+}
+
+// stream follows the bulk's jobs on the job events stream, re-reading the
+// bulk job at the maximum poll interval in case events were missed. It
+// reports whether the bulk job finished and whether tracking may continue;
+// it returns false, true when the stream is unavailable so polling takes
+// over.
+func (t *bulkProgress) stream(ctx context.Context, status *BulkStatusResponse) (done, ok bool) {
+	// Jobs not yet listed could not be subscribed to.
+	if len(status.Jobs) < status.TotalJobs {
+		return false, true
+	}
+	var ids []string
+	for _, job := range status.Jobs {
+		if !isFinishedStatus(JobStatus(job.Status)) {
+			ids = append(ids, job.ID)
+		}
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sub, err := t.client.SubscribeJobEvents(streamCtx, SubscribeOptions{JobIDs: ids})
+	if err != nil {
+		return false, ctx.Err() == nil
+	}
+
+	// Catch up on changes made before the subscription started.
+	if done, ok := t.refresh(ctx); done || !ok {
+		return done, ok
+	}
+
+	backstop := time.NewTicker(t.poll.maxInterval)
+	defer backstop.Stop()
+	for {
+		select {
+		case event, open := <-sub:
+			if !open || event.Err != nil {
+				return false, ctx.Err() == nil
+			}
+			if !t.applyJob(ctx, bulkJobFromEvent(event.Job)) {
+				return false, false
+			}
+			if t.completed+t.failed < t.total {
+				continue
+			}
+		case <-backstop.C:
+		case <-ctx.Done():
+			return false, false
+		}
+		if done, ok := t.refresh(ctx); done || !ok {
+			return done, ok
+		}
+	}
+}
+
+// refresh re-reads the bulk job and emits its changes. It reports whether
+// the bulk job finished and whether tracking may continue.
+func (t *bulkProgress) refresh(ctx context.Context) (done, ok bool) {
+	status, err := t.client.GetBulkJob(ctx, t.id)
+	if err != nil {
+		t.fail(ctx, err)
+		return false, false
+	}
+	if !t.apply(ctx, status) {
+		return false, false
+	}
+	return t.finish(ctx, status), true
+}
+
+// apply emits events for every job of status that changed, reporting
+// whether the events could be delivered.
+func (t *bulkProgress) apply(ctx context.Context, status *BulkStatusResponse) bool {
+	t.total = status.TotalJobs
+	if len(status.Jobs) > t.total {
+		t.total = len(status.Jobs)
+	}
+	for i := range status.Jobs {
+		if !t.applyJob(ctx, status.Jobs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// applyJob emits the events for one job's transition to its current status.
+func (t *bulkProgress) applyJob(ctx context.Context, job BulkJobDetailInfo) bool {
+	prev := t.statuses[job.ID]
+	next := JobStatus(job.Status)
+	if prev == next || isFinishedStatus(prev) {
+		return true
+	}
+	t.statuses[job.ID] = next
+
+	var types []ProgressEventType
+	switch next {
+	case JobStatusProcessing:
+		types = []ProgressEventType{ProgressJobStarted}
+	case JobStatusCompleted:
+		types = []ProgressEventType{ProgressJobCompleted}
+	case JobStatusFailed, JobStatusCancelled:
+		types = []ProgressEventType{ProgressJobFailed}
+	}
+	if len(types) > 0 && prev != JobStatusProcessing && next != JobStatusProcessing {
+		// The job went through processing between two observations.
+		types = append([]ProgressEventType{ProgressJobStarted}, types...)
+	}
+
+	for _, typ := range types {
+		switch typ {
+		case ProgressJobCompleted:
+			t.completed++
+		case ProgressJobFailed:
+			t.failed++
+		}
+		job := job
+		if !t.send(ctx, BulkProgressEvent{Type: typ, Job: &job}) {
+			return false
+		}
+	}
+	return true
+}
+
+// finish emits ProgressBatchDone if the bulk job is done and reports whether
+// it is.
+func (t *bulkProgress) finish(ctx context.Context, status *BulkStatusResponse) bool {
+	allFinished := len(status.Jobs) > 0 && t.completed+t.failed >= t.total
+	if !allFinished && !isFinishedStatus(JobStatus(status.Status)) {
+		return false
+	}
+	t.send(ctx, BulkProgressEvent{Type: ProgressBatchDone})
+	return true
+}
+
+// fail emits a final event carrying err, unless ctx is done.
+func (t *bulkProgress) fail(ctx context.Context, err error) {
+	if ctx.Err() == nil {
+		t.send(ctx, BulkProgressEvent{Err: err})
+	}
+}
+
+// send fills in the counts of event and delivers it unless ctx is done first.
+func (t *bulkProgress) send(ctx context.Context, event BulkProgressEvent) bool {
+	event.Completed, event.Failed, event.Total = t.completed, t.failed, t.total
+	select {
+	case t.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// bulkJobFromEvent converts a job from the job events stream.
+func bulkJobFromEvent(job *JobResponse) BulkJobDetailInfo {
+	info := BulkJobDetailInfo{
+		ID:           job.ID,
+		URL:          job.URL,
+		Status:       string(job.Status),
+		ResultURL:    job.ResultURL,
+		ErrorCode:    job.ErrorCode,
+		ErrorMessage: job.ErrorMessage,
+		CreatedAt:    job.CreatedAt,
+		CompletedAt:  job.CompletedAt,
+	}
+	if job.Metadata != nil {
+		info.Format = job.Metadata.Format
+		info.Width = job.Metadata.Width
+		info.Height = job.Metadata.Height
+		info.FileSize = job.Metadata.FileSize
+	}
+	return info
+}
+
+// ComposeProgress streams typed progress events for a compose job, polling
+// it with the intervals set by opts: ProgressJobStarted when it begins
+// processing, ProgressJobCompleted whenever more captures have completed,
+// and ProgressBatchDone when it finishes. For a failed or cancelled compose
+// job, the ProgressBatchDone event carries a *JobFailedError.
+//
+// The channel is closed after the last event or when ctx is cancelled. An
+// error is returned directly if the compose job cannot be read.
+//
+// Example:
+//
+//	events, err := client.ComposeProgress(ctx, job.JobID)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for event := range events {
+//	    fmt.Printf("%s %d/%d\n", event.Type, event.Completed, event.Total)
+//	}
+func (c *Client) ComposeProgress(ctx context.Context, jobID string, opts ...PollOption) (<-chan ComposeProgressEvent, error) {
+	job, err := c.GetComposeJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	done, err := c.track()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan ComposeProgressEvent)
+	ctx, cancel := c.closable(ctx)
+	go func() {
+		defer done()
+		defer cancel()
+		c.composeProgress(ctx, job, newPollConfig(opts), events)
+	}()
+	return events, nil
+}
+
+// composeProgress emits events for a compose job until it finishes.
+func (c *Client) composeProgress(ctx context.Context, job *ComposeJobStatusResponse, p pollConfig, events chan<- ComposeProgressEvent) {
+	defer close(events)
+
+	send := func(event ComposeProgressEvent) bool {
+		event.Completed, event.Total = job.CompletedCaptures, job.TotalCaptures
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	id := job.JobID
+	started := false
+	completed := 0
+	pl := p.newPoller("compose job " + id)
+	for {
+		status := JobStatus(job.Status)
+		if !started && status != JobStatusQueued {
+			started = true
+			if !send(ComposeProgressEvent{Type: ProgressJobStarted}) {
+				return
+			}
+		}
+		if job.CompletedCaptures > completed {
+			completed = job.CompletedCaptures
+			pl.reset()
+			if !send(ComposeProgressEvent{Type: ProgressJobCompleted}) {
+				return
+			}
+		}
+
+		switch status {
+		case JobStatusCompleted:
+			send(ComposeProgressEvent{Type: ProgressBatchDone, Result: job.Result})
+			return
+		case JobStatusFailed, JobStatusCancelled:
+			send(ComposeProgressEvent{Type: ProgressBatchDone, Err: composeJobFailed(job)})
+			return
+		}
+
+		err := pl.wait(ctx)
+		if err == nil {
+			var next *ComposeJobStatusResponse
+			if next, err = c.GetComposeJob(ctx, id); err == nil {
+				job = next
+			}
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				send(ComposeProgressEvent{Err: err})
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collectBulkProgress drains events into a compact "type job counts" form.
+func collectBulkProgress(t *testing.T, events <-chan BulkProgressEvent) []string {
+	var got []string
+	for event := range events {
+		require.NoError(t, event.Err)
+		id := "-"
+		if event.Job != nil {
+			id = event.Job.ID
+		}
+		got = append(got, fmt.Sprintf("%s %s %d/%d/%d", event.Type, id, event.Completed, event.Failed, event.Total))
+	}
+	return got
+}
+
+func TestClient_BulkProgress_Polling(t *testing.T) {
+	polls := []BulkStatusResponse{
+		{ID: "bulk-1", Status: "PROCESSING", TotalJobs: 3, Jobs: []BulkJobDetailInfo{
+			{ID: "job-1", Status: "COMPLETED"}, {ID: "job-2", Status: "PROCESSING"}, {ID: "job-3", Status: "QUEUED"},
+		}},
+		{ID: "bulk-1", Status: "PROCESSING", TotalJobs: 3, Jobs: []BulkJobDetailInfo{
+			{ID: "job-1", Status: "COMPLETED"}, {ID: "job-2", Status: "PROCESSING"}, {ID: "job-3", Status: "PROCESSING"},
+		}},
+		{ID: "bulk-1", Status: "COMPLETED", TotalJobs: 3, Jobs: []BulkJobDetailInfo{
+			{ID: "job-1", Status: "COMPLETED"}, {ID: "job-2", Status: "FAILED"}, {ID: "job-3", Status: "COMPLETED"},
+		}},
+	}
+	var poll atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/screenshots/jobs/events" {
+			// No event stream; progress falls back to polling.
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "/v1/screenshots/bulk/bulk-1", r.URL.Path)
+		i := min(int(poll.Add(1))-1, len(polls)-1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(polls[i])
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithMaxRetries(0))
+
+	events, err := client.BulkProgress(context.Background(), "bulk-1", WithPollInterval(time.Millisecond))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"job.started job-1 0/0/3",
+		"job.completed job-1 1/0/3",
+		"job.started job-2 1/0/3",
+		"job.started job-3 1/0/3",
+		"job.failed job-2 1/1/3",
+		"job.completed job-3 2/1/3",
+		"batch.done - 2/1/3",
+	}, collectBulkProgress(t, events))
+}
+
+func TestClient_BulkProgress_EventStream(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/screenshots/jobs/events":
+			assert.Equal(t, "job-1,job-2", r.URL.Query().Get("jobIds"))
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: 1\ndata: {\"id\":\"job-1\",\"status\":\"PROCESSING\"}\n\n")
+			fmt.Fprint(w, "id: 2\ndata: {\"id\":\"job-1\",\"status\":\"COMPLETED\"}\n\n")
+			fmt.Fprint(w, "id: 3\ndata: {\"id\":\"job-2\",\"status\":\"FAILED\",\"errorMessage\":\"timeout\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case "/v1/screenshots/bulk/bulk-1":
+			status := BulkStatusResponse{ID: "bulk-1", Status: "QUEUED", TotalJobs: 2, Jobs: []BulkJobDetailInfo{
+				{ID: "job-1", Status: "QUEUED"}, {ID: "job-2", Status: "QUEUED"},
+			}}
+			// The initial read and the catch-up after subscribing see
+			// queued jobs; the final read sees the finished batch.
+			if polls.Add(1) > 2 {
+				status.Status = "COMPLETED"
+				status.Jobs[0].Status, status.Jobs[1].Status = "COMPLETED", "FAILED"
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(status)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// A long poll interval shows the events came from the stream.
+	events, err := client.BulkProgress(ctx, "bulk-1", WithPollInterval(time.Minute))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"job.started job-1 0/0/2",
+		"job.completed job-1 1/0/2",
+		"job.started job-2 1/0/2",
+		"job.failed job-2 1/1/2",
+		"batch.done - 1/1/2",
+	}, collectBulkProgress(t, events))
+	assert.Equal(t, int32(3), polls.Load())
+}
+
+func TestClient_BulkProgress_Errors(t *testing.T) {
+	client := NewClient(WithAPIKey("test-api-key"))
+	_, err := client.BulkProgress(context.Background(), "")
+	assert.True(t, IsValidationError(err))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/screenshots/jobs/events" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(BulkStatusResponse{ID: "bulk-1", Status: "PROCESSING", TotalJobs: 1,
+			Jobs: []BulkJobDetailInfo{{ID: "job-1", Status: "PROCESSING"}}})
+	}))
+	defer server.Close()
+
+	client = NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithMaxRetries(0))
+	events, err := client.BulkProgress(context.Background(), "bulk-1",
+		WithPollInterval(5*time.Millisecond), WithPollTimeout(30*time.Millisecond))
+	require.NoError(t, err)
+
+	var last BulkProgressEvent
+	for event := range events {
+		last = event
+	}
+	var timeoutErr *TimeoutError
+	assert.ErrorAs(t, last.Err, &timeoutErr)
+}
+
+func TestClient_ComposeProgress(t *testing.T) {
+	polls := []ComposeJobStatusResponse{
+		{JobID: "compose-1", Status: "QUEUED", TotalCaptures: 3},
+		{JobID: "compose-1", Status: "PROCESSING", TotalCaptures: 3, CompletedCaptures: 1},
+		{JobID: "compose-1", Status: "PROCESSING", TotalCaptures: 3, CompletedCaptures: 1},
+		{JobID: "compose-1", Status: "COMPLETED", TotalCaptures: 3, CompletedCaptures: 3, Result: &ComposeResponse{URL: "https://cdn.example.com/c.png"}},
+	}
+	var poll atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots/compose/jobs/compose-1", r.URL.Path)
+		i := min(int(poll.Add(1))-1, len(polls)-1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(polls[i])
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	events, err := client.ComposeProgress(context.Background(), "compose-1", WithPollInterval(time.Millisecond))
+	require.NoError(t, err)
+
+	var got []string
+	var last ComposeProgressEvent
+	for event := range events {
+		require.NoError(t, event.Err)
+		got = append(got, fmt.Sprintf("%s %d/%d", event.Type, event.Completed, event.Total))
+		last = event
+	}
+	assert.Equal(t, []string{"job.started 1/3", "job.completed 1/3", "job.completed 3/3", "batch.done 3/3"}, got)
+	require.NotNil(t, last.Result)
+	assert.Equal(t, "https://cdn.example.com/c.png", last.Result.URL)
+}
+
+func TestClient_ComposeProgress_Failed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ComposeJobStatusResponse{JobID: "compose-1", Status: "FAILED", ErrorMessage: "capture timed out"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	events, err := client.ComposeProgress(context.Background(), "compose-1")
+	require.NoError(t, err)
+
+	var got []ComposeProgressEvent
+	for event := range events {
+		got = append(got, event)
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, ProgressJobStarted, got[0].Type)
+	assert.Equal(t, ProgressBatchDone, got[1].Type)
+	assert.True(t, IsJobFailedError(got[1].Err))
+}
@@ -0,0 +1,84 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Project partitions jobs, schedules, and usage within an account, e.g. one
+// project per customer.
+type Project struct {
+	// ID of the project; pass it to WithProject
+	ID string `json:"id"`
+	// Name of the project
+	Name string `json:"name"`
+	// Description of the project
+	Description string `json:"description,omitempty"`
+	// CreatedAt timestamp
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// CreateProjectRequest represents a request to create a project.
+type CreateProjectRequest struct {
+	// Name of the project (required, max 255)
+	Name string `json:"name"`
+	// Description of the project (max 1000)
+	Description string `json:"description,omitempty"`
+}
+
+// ProjectListResponse represents a list of projects.
+type ProjectListResponse struct {
+	Projects []Project `json:"projects"`
+	Total    int       `json:"total"`
+}
+
+// ListProjects returns the account's projects.
+func (c *Client) ListProjects(ctx context.Context) (*ProjectListResponse, error) {
+	var result ProjectListResponse
+	err := c.request(ctx, http.MethodGet, c.endpoint("/projects"), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateProject creates a project. Use WithProject with the new project's ID
+// to capture and query within it.
+//
+// Example:
+//
+//	project, err := client.CreateProject(ctx, &allscreenshots.CreateProjectRequest{Name: "Acme Corp"})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	acme := allscreenshots.NewClient(allscreenshots.WithProject(project.ID))
+func (c *Client) CreateProject(ctx context.Context, req *CreateProjectRequest) (*Project, error) {
+	if err := validateCreateProjectRequest(req); err != nil {
+		return nil, err
+	}
+
+	var result Project
+	err := c.request(ctx, http.MethodPost, c.endpoint("/projects"), req, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// validateCreateProjectRequest validates a create project request.
+func validateCreateProjectRequest(req *CreateProjectRequest) error {
+	if req == nil {
+		return &ValidationError{Field: "request", Message: "request cannot be nil"}
+	}
+	if req.Name == "" {
+		return &ValidationError{Field: "name", Message: "name is required"}
+	}
+	if len(req.Name) > 255 {
+		return &ValidationError{Field: "name", Message: "name must be at most 255 characters"}
+	}
+	if len(req.Description) > 1000 {
+		return &ValidationError{Field: "description", Message: "description must be at most 1000 characters"}
+	}
+	return nil
+}
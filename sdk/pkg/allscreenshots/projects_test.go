@@ -0,0 +1,73 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Projects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get(ProjectHeader))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"projects":[{"id":"prj-1","name":"Acme Corp"}],"total":1}`))
+		case http.MethodPost:
+			var req CreateProjectRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			json.NewEncoder(w).Encode(Project{ID: "prj-2", Name: req.Name, Description: req.Description})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	list, err := client.ListProjects(ctx)
+	require.NoError(t, err)
+	require.Len(t, list.Projects, 1)
+	assert.Equal(t, "Acme Corp", list.Projects[0].Name)
+
+	project, err := client.CreateProject(ctx, &CreateProjectRequest{Name: "Globex", Description: "Globex monitors"})
+	require.NoError(t, err)
+	assert.Equal(t, "prj-2", project.ID)
+	assert.Equal(t, "Globex monitors", project.Description)
+
+	_, err = client.CreateProject(ctx, &CreateProjectRequest{})
+	assert.True(t, IsValidationError(err))
+	_, err = client.CreateProject(ctx, &CreateProjectRequest{Name: strings.Repeat("x", 256)})
+	assert.True(t, IsValidationError(err))
+}
+
+func TestClient_WithProject(t *testing.T) {
+	var projects []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		projects = append(projects, r.Header.Get(ProjectHeader))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	acme := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithProject("prj-1"))
+	_, err := acme.ListJobs(context.Background())
+	require.NoError(t, err)
+
+	// The project, like the API key, is only sent to the API itself.
+	_, err = acme.DownloadResult(context.Background(), server.URL+"/result", &strings.Builder{})
+	require.NoError(t, err)
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		projects = append(projects, r.Header.Get(ProjectHeader))
+	}))
+	defer other.Close()
+	_, err = acme.DownloadResult(context.Background(), other.URL+"/result", &strings.Builder{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"prj-1", "prj-1", ""}, projects)
+}
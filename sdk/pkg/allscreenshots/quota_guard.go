@@ -0,0 +1,150 @@
+package allscreenshots
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// quotaGuardTTL is how long a quota reading is trusted before the guard
+// fetches a fresh one.
+const quotaGuardTTL = time.Minute
+
+// WithQuotaGuard refuses bulk jobs and compose requests locally, with a
+// QuotaExceededError, when submitting them would leave fewer than
+// minRemaining screenshots of the billing period's quota. It protects
+// accounts shared by several services from one job draining the month.
+//
+// The guard uses the quota headers of recent responses when available and
+// otherwise calls GetQuotaStatus, caching the result for a minute. Accounts
+// without a screenshot quota are never refused.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(
+//	    allscreenshots.WithQuotaGuard(500),
+//	)
+//	_, err := client.CreateBulkJob(ctx, req)
+//	if allscreenshots.IsQuotaExceededError(err) {
+//	    log.Printf("skipping nightly run: %v", err)
+//	}
+func WithQuotaGuard(minRemaining int) ClientOption {
+	return func(c *Client) {
+		c.quotaGuard = &quotaGuard{minRemaining: minRemaining}
+	}
+}
+
+// quotaGuard tracks the remaining quota between readings.
+type quotaGuard struct {
+	minRemaining int
+
+	mu sync.Mutex
+	// remaining is the last reading, less what was submitted since
+	remaining int
+	// unlimited is set when the account has no screenshot quota
+	unlimited  bool
+	observedAt time.Time
+	// pending is the cost of guarded submissions still in flight
+	pending int
+	// fetching is closed when the GetQuotaStatus call in progress returns
+	fetching chan struct{}
+}
+
+// reserveQuota checks that submitting cost screenshots keeps the quota above
+// the guard's threshold and reserves it. The returned release must be
+// called once the submission finishes. Without a guard it does nothing.
+func (c *Client) reserveQuota(ctx context.Context, cost int) (release func(submitted bool), err error) {
+	q := c.quotaGuard
+	if q == nil || cost <= 0 {
+		return func(bool) {}, nil
+	}
+
+	if err := c.refreshQuota(ctx); err != nil {
+		return nil, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.unlimited {
+		available := q.remaining - q.pending
+		if available-cost < q.minRemaining {
+			return nil, &QuotaExceededError{Remaining: available, Required: cost, MinRemaining: q.minRemaining}
+		}
+	}
+
+	q.pending += cost
+	return func(submitted bool) {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		q.pending -= cost
+		if submitted {
+			q.remaining -= cost
+		}
+	}, nil
+}
+
+// refreshQuota updates the guard's reading from the latest response headers,
+// or from the API once the reading is older than quotaGuardTTL. Only one
+// call fetches from the API at a time; others wait for its reading, or until
+// their context is done.
+func (c *Client) refreshQuota(ctx context.Context) error {
+	q := c.quotaGuard
+	for {
+		q.mu.Lock()
+		if rl := c.LastRateLimit(); rl != nil && rl.QuotaLimit > 0 && rl.ObservedAt.After(q.observedAt) {
+			q.remaining, q.unlimited, q.observedAt = rl.QuotaRemaining, false, rl.ObservedAt
+		}
+		if !q.observedAt.IsZero() && time.Since(q.observedAt) < quotaGuardTTL {
+			q.mu.Unlock()
+			return nil
+		}
+		fetching := q.fetching
+		if fetching == nil {
+			fetching = make(chan struct{})
+			q.fetching = fetching
+			q.mu.Unlock()
+			return c.fetchQuota(ctx, fetching)
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-fetching:
+		case <-ctx.Done():
+			return timeoutError(ctx.Err())
+		}
+	}
+}
+
+// fetchQuota reads the quota from the API without holding q.mu, applies the
+// reading unless a newer one arrived meanwhile, and closes done to wake the
+// calls waiting for it.
+func (c *Client) fetchQuota(ctx context.Context, done chan struct{}) error {
+	q := c.quotaGuard
+	fetchedAt := time.Now()
+	status, err := c.GetQuotaStatus(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.fetching = nil
+	close(done)
+	if err != nil {
+		return err
+	}
+	if fetchedAt.After(q.observedAt) {
+		q.observedAt = fetchedAt
+		q.unlimited = status.Screenshots == nil || status.Screenshots.Limit <= 0
+		if !q.unlimited {
+			q.remaining = status.Screenshots.Remaining
+		}
+	}
+	return nil
+}
+
+// composeCost returns the number of screenshots a compose request captures.
+func composeCost(req *ComposeRequest) int {
+	cost := len(req.Captures) + len(req.Variants)
+	if cost == 0 && req.URL != "" {
+		cost = 1
+	}
+	return cost
+}
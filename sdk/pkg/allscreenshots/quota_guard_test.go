@@ -0,0 +1,159 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bulkOf(n int) *BulkRequest {
+	req := &BulkRequest{}
+	for i := 0; i < n; i++ {
+		req.URLs = append(req.URLs, BulkURLRequest{URL: fmt.Sprintf("https://example.com/%d", i)})
+	}
+	return req
+}
+
+func TestClient_QuotaGuard(t *testing.T) {
+	var quotaCalls, submits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/usage/quota":
+			quotaCalls.Add(1)
+			json.NewEncoder(w).Encode(QuotaStatusResponse{
+				Screenshots: &QuotaDetailResponse{Limit: 1000, Used: 880, Remaining: 120},
+			})
+		case "/v1/screenshots/bulk":
+			submits.Add(1)
+			json.NewEncoder(w).Encode(BulkResponse{ID: "bulk-1"})
+		case "/v1/screenshots/compose":
+			submits.Add(1)
+			json.NewEncoder(w).Encode(ComposeResponse{})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithQuotaGuard(100))
+	ctx := context.Background()
+
+	_, err := client.CreateBulkJob(ctx, bulkOf(10))
+	require.NoError(t, err)
+
+	// 110 left after the first job; 15 more would drop below 100.
+	_, err = client.CreateBulkJob(ctx, bulkOf(15))
+	require.Error(t, err)
+	assert.True(t, IsQuotaExceededError(err))
+	var quotaErr *QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr)
+	assert.Equal(t, 110, quotaErr.Remaining)
+	assert.Equal(t, 15, quotaErr.Required)
+	assert.Contains(t, err.Error(), "would leave 95 of quota, below the guard of 100")
+
+	_, err = client.Compose(ctx, &ComposeRequest{
+		Captures: []CaptureItem{{URL: "https://example.com/a"}, {URL: "https://example.com/b"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), quotaCalls.Load(), "quota reading is cached")
+	assert.Equal(t, int32(2), submits.Load())
+}
+
+func TestClient_QuotaGuard_Concurrent(t *testing.T) {
+	release := make(chan struct{})
+	var quotaCalls, submits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/usage/quota":
+			quotaCalls.Add(1)
+			<-release
+			json.NewEncoder(w).Encode(QuotaStatusResponse{
+				Screenshots: &QuotaDetailResponse{Limit: 1000, Remaining: 500},
+			})
+		case "/v1/screenshots/bulk":
+			submits.Add(1)
+			json.NewEncoder(w).Encode(BulkResponse{ID: "bulk-1"})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithQuotaGuard(100))
+
+	errs := make(chan error, 2)
+	submit := func() {
+		_, err := client.CreateBulkJob(context.Background(), bulkOf(10))
+		errs <- err
+	}
+	go submit()
+	for quotaCalls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	go submit()
+
+	// A reservation waiting for the slow quota reading gives up when its
+	// context is done, without waiting for the reading.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.CreateBulkJob(ctx, bulkOf(10))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+	assert.Equal(t, int32(1), quotaCalls.Load(), "concurrent reservations share one reading")
+	assert.Equal(t, int32(2), submits.Load())
+}
+
+func TestClient_QuotaGuard_UsesResponseHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/usage/quota":
+			json.NewEncoder(w).Encode(QuotaStatusResponse{
+				Screenshots: &QuotaDetailResponse{Limit: 1000, Remaining: 500},
+			})
+		case "/v1/screenshots/bulk":
+			// The API reports far less quota than the cached reading.
+			w.Header().Set(headerQuotaLimit, "1000")
+			w.Header().Set(headerQuotaRemaining, "5")
+			json.NewEncoder(w).Encode(BulkResponse{ID: "bulk-1"})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithQuotaGuard(0))
+	ctx := context.Background()
+
+	_, err := client.CreateBulkJob(ctx, bulkOf(3))
+	require.NoError(t, err)
+	_, err = client.CreateBulkJob(ctx, bulkOf(6))
+	assert.True(t, IsQuotaExceededError(err))
+}
+
+func TestClient_QuotaGuard_Unlimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/usage/quota":
+			json.NewEncoder(w).Encode(QuotaStatusResponse{Tier: "enterprise"})
+		case "/v1/screenshots/bulk":
+			json.NewEncoder(w).Encode(BulkResponse{ID: "bulk-1"})
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithQuotaGuard(1000))
+	_, err := client.CreateBulkJob(context.Background(), bulkOf(50))
+	assert.NoError(t, err)
+}
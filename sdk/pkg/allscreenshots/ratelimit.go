@@ -0,0 +1,101 @@
+package allscreenshots
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Rate limit and quota response headers.
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerQuotaLimit         = "X-Quota-Limit"
+	headerQuotaRemaining     = "X-Quota-Remaining"
+)
+
+// RateLimit is a snapshot of the rate limit and quota headers of an API response.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window
+	Limit int
+	// Remaining is the number of requests left in the current window
+	Remaining int
+	// Reset is when the current window ends
+	Reset time.Time
+	// QuotaLimit is the number of screenshots allowed in the billing period
+	QuotaLimit int
+	// QuotaRemaining is the number of screenshots left in the billing period
+	QuotaRemaining int
+	// ObservedAt is when the response was received
+	ObservedAt time.Time
+}
+
+// LastRateLimit returns the rate limit and quota headers of the most recent
+// API response that carried them, or nil if none has yet. It lets
+// applications throttle themselves without calling GetQuotaStatus.
+//
+// Example:
+//
+//	if rl := client.LastRateLimit(); rl != nil && rl.Remaining == 0 {
+//	    time.Sleep(time.Until(rl.Reset))
+//	}
+func (c *Client) LastRateLimit() *RateLimit {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	if c.rateLimit == nil {
+		return nil
+	}
+	rl := *c.rateLimit
+	return &rl
+}
+
+// recordRateLimit stores the rate limit headers of resp, if any, as the
+// client's latest snapshot and returns them.
+func (c *Client) recordRateLimit(resp *http.Response) *RateLimit {
+	rl := parseRateLimit(resp.Header, time.Now())
+	if rl == nil {
+		return nil
+	}
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	// Responses of concurrent requests may arrive out of order; keep the newest.
+	if c.rateLimit == nil || !rl.ObservedAt.Before(c.rateLimit.ObservedAt) {
+		snapshot := *rl
+		c.rateLimit = &snapshot
+	}
+	return rl
+}
+
+// parseRateLimit reads the rate limit and quota headers, returning nil when
+// none are present. X-RateLimit-Reset may be a Unix timestamp or a number of
+// seconds from now.
+func parseRateLimit(h http.Header, now time.Time) *RateLimit {
+	rl := &RateLimit{ObservedAt: now}
+	found := false
+	readInt := func(name string, dst *int) {
+		if v, err := strconv.Atoi(h.Get(name)); err == nil {
+			*dst = v
+			found = true
+		}
+	}
+	readInt(headerRateLimitLimit, &rl.Limit)
+	readInt(headerRateLimitRemaining, &rl.Remaining)
+	readInt(headerQuotaLimit, &rl.QuotaLimit)
+	readInt(headerQuotaRemaining, &rl.QuotaRemaining)
+
+	if v, err := strconv.ParseInt(h.Get(headerRateLimitReset), 10, 64); err == nil && v >= 0 {
+		found = true
+		// Values this small cannot be timestamps from this century.
+		if v < 1_000_000_000 {
+			rl.Reset = now.Add(time.Duration(v) * time.Second)
+		} else {
+			rl.Reset = time.Unix(v, 0)
+		}
+	}
+
+	if !found {
+		return nil
+	}
+	return rl
+}
@@ -0,0 +1,82 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, parseRateLimit(http.Header{}, now))
+
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", "60")
+	h.Set("X-RateLimit-Remaining", "12")
+	h.Set("X-RateLimit-Reset", "30")
+	h.Set("X-Quota-Limit", "10000")
+	h.Set("X-Quota-Remaining", "2500")
+	rl := parseRateLimit(h, now)
+	require.NotNil(t, rl)
+	assert.Equal(t, 60, rl.Limit)
+	assert.Equal(t, 12, rl.Remaining)
+	assert.Equal(t, now.Add(30*time.Second), rl.Reset)
+	assert.Equal(t, 10000, rl.QuotaLimit)
+	assert.Equal(t, 2500, rl.QuotaRemaining)
+
+	h.Set("X-RateLimit-Reset", "1709283600")
+	assert.True(t, time.Unix(1709283600, 0).Equal(parseRateLimit(h, now).Reset))
+}
+
+func TestClient_LastRateLimit(t *testing.T) {
+	remaining := 10
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remaining--
+		w.Header().Set("X-RateLimit-Limit", "10")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		switch r.URL.Path {
+		case "/v1/screenshots":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte{0x89})
+		case "/v1/screenshots/jobs/job-1":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"job-1","status":"COMPLETED"}`))
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithMaxRetries(0))
+	ctx := context.Background()
+	assert.Nil(t, client.LastRateLimit())
+
+	result, err := client.ScreenshotDetailed(ctx, &ScreenshotRequest{URL: "https://example.com"})
+	require.NoError(t, err)
+	require.NotNil(t, result.RateLimit)
+	assert.Equal(t, 9, result.RateLimit.Remaining)
+
+	job, err := client.GetJob(ctx, "job-1")
+	require.NoError(t, err)
+	require.NotNil(t, job.RateLimit)
+	assert.Equal(t, 8, job.RateLimit.Remaining)
+
+	// Error responses update the snapshot too.
+	_, err = client.GetJob(ctx, "missing")
+	require.Error(t, err)
+	rl := client.LastRateLimit()
+	require.NotNil(t, rl)
+	assert.Equal(t, 10, rl.Limit)
+	assert.Equal(t, 7, rl.Remaining)
+
+	// The snapshot is a copy.
+	rl.Remaining = 100
+	assert.Equal(t, 7, client.LastRateLimit().Remaining)
+}
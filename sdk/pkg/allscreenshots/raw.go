@@ -0,0 +1,45 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Do calls any API endpoint with the client's authentication, retries, and
+// error handling, for endpoints that do not have a typed method yet. path
+// is an unversioned API path such as "/screenshots/jobs/abc"; the version
+// prefix is added according to WithAPIVersion and WithEndpointVersion. body,
+// if not nil, is sent as JSON.
+//
+// The response is decoded according to result: nil discards it, a *[]byte
+// receives the raw body, an io.Writer has the body copied to it, and
+// anything else is decoded from JSON.
+//
+// Example:
+//
+//	var job map[string]interface{}
+//	err := client.Do(ctx, http.MethodGet, "/screenshots/jobs/"+id, nil, &job)
+func (c *Client) Do(ctx context.Context, method, path string, body, result interface{}) error {
+	if !strings.HasPrefix(path, "/") {
+		return &ValidationError{Field: "path", Message: "path must start with /"}
+	}
+
+	return c.requestRaw(ctx, method, c.endpoint(path), body, func(resp *http.Response) error {
+		switch r := result.(type) {
+		case nil:
+			return nil
+		case *[]byte:
+			data, err := readBody(resp.Body, resp.ContentLength)
+			*r = data
+			return err
+		case io.Writer:
+			_, err := copyBody(r, resp.Body)
+			return err
+		default:
+			return json.NewDecoder(resp.Body).Decode(result)
+		}
+	})
+}
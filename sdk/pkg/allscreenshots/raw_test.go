@@ -0,0 +1,75 @@
+package allscreenshots
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-api-key", r.Header.Get("X-API-Key"))
+		switch r.URL.Path {
+		case "/v1/new-endpoint":
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "value", body["key"])
+			w.Write([]byte(`{"ok":true}`))
+		case "/v1/binary":
+			w.Write([]byte{0x89, 'P', 'N', 'G'})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	var result struct {
+		OK bool `json:"ok"`
+	}
+	require.NoError(t, client.Do(ctx, http.MethodPost, "/new-endpoint", map[string]string{"key": "value"}, &result))
+	assert.True(t, result.OK)
+
+	var data []byte
+	require.NoError(t, client.Do(ctx, http.MethodGet, "/binary", nil, &data))
+	assert.Equal(t, []byte{0x89, 'P', 'N', 'G'}, data)
+
+	var buf bytes.Buffer
+	require.NoError(t, client.Do(ctx, http.MethodGet, "/binary", nil, &buf))
+	assert.Equal(t, 4, buf.Len())
+
+	err := client.Do(ctx, http.MethodGet, "/missing", nil, nil)
+	assert.True(t, IsNotFound(err))
+
+	err = client.Do(ctx, http.MethodGet, "https://elsewhere.example.com/", nil, nil)
+	assert.ErrorContains(t, err, "path must start with /")
+}
+
+func TestClient_Do_APIVersion(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+		WithAPIVersion("v2"),
+		WithEndpointVersion("/screenshots/compose", "v3"),
+	)
+	ctx := context.Background()
+	require.NoError(t, client.Do(ctx, http.MethodGet, "/screenshots/jobs/abc", nil, nil))
+	require.NoError(t, client.Do(ctx, http.MethodGet, "/screenshots/compose/jobs/abc", nil, nil))
+	assert.Equal(t, []string{"/v2/screenshots/jobs/abc", "/v3/screenshots/compose/jobs/abc"}, paths)
+}
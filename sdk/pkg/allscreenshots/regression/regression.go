@@ -0,0 +1,191 @@
+// Package regression runs visual regression checks: it captures pages,
+// compares them against approved baselines, and reports what changed.
+package regression
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots/baseline"
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots/visdiff"
+)
+
+// Status is the outcome of a single check.
+type Status string
+
+// Check outcomes.
+const (
+	// StatusPassed means the capture matched its baseline
+	StatusPassed Status = "passed"
+	// StatusChanged means the capture differed from its baseline by more than
+	// the spec allows; the capture was stored as a candidate
+	StatusChanged Status = "changed"
+	// StatusNew means there was no baseline; the capture was stored as a candidate
+	StatusNew Status = "new"
+	// StatusError means the check could not be completed
+	StatusError Status = "error"
+)
+
+// Spec describes one page to check.
+type Spec struct {
+	// Name identifies the check in the report; defaults to the request URL
+	Name string
+	// Request is the screenshot to capture (png or jpeg)
+	Request *allscreenshots.ScreenshotRequest
+	// MaxChangedPercent is the share of pixels (0-100) that may change
+	// before the check fails; 0 requires an exact match
+	MaxChangedPercent float64
+	// Diff configures the pixel comparison
+	Diff visdiff.Options
+}
+
+// Result is the outcome of checking one Spec.
+type Result struct {
+	// Name of the spec
+	Name string `json:"name"`
+	// Key the baseline is stored under
+	Key baseline.Key `json:"key"`
+	// Status of the check
+	Status Status `json:"status"`
+	// ChangedPercent is the share of pixels that changed, when compared
+	ChangedPercent float64 `json:"changedPercent,omitempty"`
+	// Diff is the full comparison, when compared
+	Diff *visdiff.DiffResult `json:"-"`
+	// Error describes why the check failed, for StatusError
+	Error string `json:"error,omitempty"`
+	// Err is the underlying error, for StatusError
+	Err error `json:"-"`
+}
+
+// Report summarizes a regression run.
+type Report struct {
+	// Results in the same order as the specs
+	Results []Result `json:"results"`
+	// Passed is the number of checks that matched their baseline
+	Passed int `json:"passed"`
+	// Changed is the number of checks that differed from their baseline
+	Changed int `json:"changed"`
+	// New is the number of checks without a baseline
+	New int `json:"new"`
+	// Errors is the number of checks that could not be completed
+	Errors int `json:"errors"`
+}
+
+// OK reports whether every check passed.
+func (r *Report) OK() bool {
+	return r.Passed == len(r.Results)
+}
+
+// Run captures each spec, compares it with its baseline in store, and returns
+// a report. Changed and new captures are written to the store as candidates;
+// approve them with store.Approve once reviewed. Failures of individual
+// checks are recorded in the report; Run only returns an error if ctx is
+// cancelled.
+//
+// Example:
+//
+//	store, _ := baseline.NewFSStore("testdata/baselines")
+//	report, err := regression.Run(ctx, client, store, []regression.Spec{
+//	    {Request: &allscreenshots.ScreenshotRequest{URL: "https://example.com", Device: "Desktop HD"}},
+//	    {Request: &allscreenshots.ScreenshotRequest{URL: "https://example.com/pricing"}, MaxChangedPercent: 0.5},
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if !report.OK() {
+//	    json.NewEncoder(os.Stdout).Encode(report)
+//	    os.Exit(1)
+//	}
+func Run(ctx context.Context, client *allscreenshots.Client, store baseline.Store, specs []Spec) (*Report, error) {
+	report := &Report{Results: make([]Result, 0, len(specs))}
+
+	for _, spec := range specs {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		result := check(ctx, client, store, spec)
+		switch result.Status {
+		case StatusPassed:
+			report.Passed++
+		case StatusChanged:
+			report.Changed++
+		case StatusNew:
+			report.New++
+		case StatusError:
+			report.Errors++
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+// check runs a single spec.
+func check(ctx context.Context, client *allscreenshots.Client, store baseline.Store, spec Spec) Result {
+	result := Result{Name: spec.Name}
+	if spec.Request == nil {
+		return failed(result, errors.New("regression: spec has no request"))
+	}
+	if result.Name == "" {
+		result.Name = spec.Request.URL
+	}
+	result.Key = KeyFor(spec.Request)
+
+	reference, err := store.Get(ctx, result.Key)
+	if errors.Is(err, baseline.ErrNotFound) {
+		data, err := client.Screenshot(ctx, spec.Request)
+		if err != nil {
+			return failed(result, err)
+		}
+		if err := store.Update(ctx, result.Key, data); err != nil {
+			return failed(result, err)
+		}
+		result.Status = StatusNew
+		return result
+	}
+	if err != nil {
+		return failed(result, err)
+	}
+
+	compared, err := client.CaptureAndCompare(ctx, spec.Request, reference, spec.Diff)
+	if err != nil {
+		return failed(result, err)
+	}
+	result.Diff = compared.Diff
+	result.ChangedPercent = compared.Diff.ChangedPercent
+
+	if compared.Diff.ChangedPercent <= spec.MaxChangedPercent {
+		result.Status = StatusPassed
+		return result
+	}
+	if err := store.Update(ctx, result.Key, compared.Screenshot); err != nil {
+		return failed(result, err)
+	}
+	result.Status = StatusChanged
+	return result
+}
+
+// failed marks result as an error.
+func failed(result Result, err error) Result {
+	result.Status = StatusError
+	result.Err = err
+	result.Error = err.Error()
+	return result
+}
+
+// KeyFor returns the baseline key for a screenshot request.
+func KeyFor(req *allscreenshots.ScreenshotRequest) baseline.Key {
+	key := baseline.Key{URL: req.URL, Device: req.Device}
+	if req.Viewport != nil {
+		key.Width = req.Viewport.Width
+		key.Height = req.Viewport.Height
+	}
+	if key.URL == "" && req.HTML != "" {
+		sum := sha256.Sum256([]byte(req.HTML))
+		key.URL = "html:" + hex.EncodeToString(sum[:8])
+	}
+	return key
+}
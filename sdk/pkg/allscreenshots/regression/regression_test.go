@@ -0,0 +1,102 @@
+package regression
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots/baseline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encode(t *testing.T, c color.Color, changed int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for i := 0; i < 100; i++ {
+		if i < changed {
+			img.Set(i%10, i/10, color.Black)
+		} else {
+			img.Set(i%10, i/10, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestRun(t *testing.T) {
+	pages := map[string][]byte{
+		"https://example.com/same":    encode(t, color.White, 0),
+		"https://example.com/tweaked": encode(t, color.White, 1),
+		"https://example.com/changed": encode(t, color.White, 20),
+		"https://example.com/new":     encode(t, color.White, 0),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req allscreenshots.ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		data, ok := pages[req.URL]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(server.URL), allscreenshots.WithMaxRetries(0))
+	store, err := baseline.NewFSStore(t.TempDir())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	white := encode(t, color.White, 0)
+	for _, u := range []string{"https://example.com/same", "https://example.com/tweaked", "https://example.com/changed"} {
+		key := KeyFor(&allscreenshots.ScreenshotRequest{URL: u})
+		require.NoError(t, store.Update(ctx, key, white))
+		require.NoError(t, store.Approve(ctx, key))
+	}
+
+	report, err := Run(ctx, client, store, []Spec{
+		{Request: &allscreenshots.ScreenshotRequest{URL: "https://example.com/same"}},
+		{Name: "tweaked", Request: &allscreenshots.ScreenshotRequest{URL: "https://example.com/tweaked"}, MaxChangedPercent: 5},
+		{Request: &allscreenshots.ScreenshotRequest{URL: "https://example.com/changed"}, MaxChangedPercent: 5},
+		{Request: &allscreenshots.ScreenshotRequest{URL: "https://example.com/new"}},
+		{Request: &allscreenshots.ScreenshotRequest{URL: "https://example.com/missing"}},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, report.OK())
+	assert.Equal(t, 2, report.Passed)
+	assert.Equal(t, 1, report.Changed)
+	assert.Equal(t, 1, report.New)
+	assert.Equal(t, 1, report.Errors)
+
+	assert.Equal(t, "https://example.com/same", report.Results[0].Name)
+	assert.Equal(t, "tweaked", report.Results[1].Name)
+	assert.InDelta(t, 1.0, report.Results[1].ChangedPercent, 0.001)
+	assert.Equal(t, StatusChanged, report.Results[2].Status)
+	assert.Len(t, report.Results[2].Diff.Regions, 1)
+	assert.Equal(t, StatusNew, report.Results[3].Status)
+	assert.Equal(t, StatusError, report.Results[4].Status)
+	assert.True(t, allscreenshots.IsBadRequest(report.Results[4].Err))
+
+	// Changed and new captures become baselines once approved.
+	changedKey := report.Results[2].Key
+	require.NoError(t, store.Approve(ctx, changedKey))
+	data, err := store.Get(ctx, changedKey)
+	require.NoError(t, err)
+	assert.Equal(t, pages["https://example.com/changed"], data)
+
+	body, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(body), `"status":"changed"`))
+}
@@ -0,0 +1,179 @@
+// Package relay forwards job completions from the API to a message broker.
+package relay
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots/events"
+)
+
+// DefaultPollInterval is how often Run lists jobs in polling mode by default.
+const DefaultPollInterval = 30 * time.Second
+
+// config holds the settings applied by Options.
+type config struct {
+	pollInterval time.Duration
+	onError      func(error)
+	lastEventID  string
+}
+
+// Option configures Run.
+type Option func(*config)
+
+// WithPolling makes Run list jobs every interval instead of subscribing to
+// the server-sent event stream, e.g. where long-lived connections are cut
+// by a proxy. Jobs that had already finished when Run started are not
+// published.
+func WithPolling(interval time.Duration) Option {
+	return func(c *config) {
+		if interval <= 0 {
+			interval = DefaultPollInterval
+		}
+		c.pollInterval = interval
+	}
+}
+
+// WithErrorHandler sets a function called when publishing a message or
+// polling fails. Run keeps going after such errors; without a handler they
+// are dropped.
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+// WithLastEventID resumes the event stream after the given event, so
+// completions that happened while the relay was down are published.
+func WithLastEventID(id string) Option {
+	return func(c *config) {
+		c.lastEventID = id
+	}
+}
+
+// Run publishes a JobCompleted message for every job that completes, fails,
+// or is cancelled, until ctx is done. By default it follows the API's
+// event stream, which reconnects by itself; see WithPolling for the
+// alternative.
+//
+// Delivery is at most once: a message that fails to publish is reported to
+// the error handler and not retried.
+//
+// Run returns ctx's error once ctx is done, or an error if the event stream
+// cannot be opened or fails permanently (e.g. the API key is revoked).
+//
+// Example:
+//
+//	nc, _ := nats.Connect(nats.DefaultURL)
+//	err := relay.Run(ctx, client, events.NewNATS(nc, "screenshots"),
+//	    relay.WithErrorHandler(func(err error) { log.Printf("relay: %v", err) }),
+//	)
+func Run(ctx context.Context, client *allscreenshots.Client, publisher events.Publisher, opts ...Option) error {
+	if client == nil || publisher == nil {
+		return errors.New("relay: client and publisher are required")
+	}
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.pollInterval > 0 {
+		return poll(ctx, client, publisher, cfg)
+	}
+	return stream(ctx, client, publisher, cfg)
+}
+
+// stream publishes completions from the job event stream.
+func stream(ctx context.Context, client *allscreenshots.Client, publisher events.Publisher, cfg config) error {
+	jobEvents, err := client.SubscribeJobEvents(ctx, allscreenshots.SubscribeOptions{
+		Statuses: []allscreenshots.JobStatus{
+			allscreenshots.JobStatusCompleted,
+			allscreenshots.JobStatusFailed,
+			allscreenshots.JobStatusCancelled,
+		},
+		LastEventID: cfg.lastEventID,
+	})
+	if err != nil {
+		return err
+	}
+	for event := range jobEvents {
+		if event.Err != nil {
+			return event.Err
+		}
+		publish(ctx, publisher, cfg, event.Job)
+	}
+	return ctx.Err()
+}
+
+// poll publishes completions found by listing jobs periodically.
+func poll(ctx context.Context, client *allscreenshots.Client, publisher events.Publisher, cfg config) error {
+	// seen holds the finished jobs from the latest listing; nil until the
+	// first listing, whose finished jobs predate the relay.
+	var seen map[string]bool
+
+	ticker := time.NewTicker(cfg.pollInterval)
+	defer ticker.Stop()
+	for {
+		jobs, err := client.ListJobs(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			report(cfg, err)
+		} else {
+			current := make(map[string]bool, len(jobs))
+			for i := range jobs {
+				job := &jobs[i]
+				if _, final := events.FromJob(job); !final {
+					continue
+				}
+				current[job.ID] = true
+				if seen != nil && !seen[job.ID] {
+					publish(ctx, publisher, cfg, job)
+				}
+			}
+			seen = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// publish sends the message for job, reporting failures.
+func publish(ctx context.Context, publisher events.Publisher, cfg config, job *allscreenshots.JobResponse) {
+	msg, ok := events.FromJob(job)
+	if !ok {
+		return
+	}
+	if err := publisher.Publish(ctx, msg); err != nil && ctx.Err() == nil {
+		report(cfg, &PublishError{JobID: job.ID, Err: err})
+	}
+}
+
+// report passes err to the error handler, if any.
+func report(cfg config, err error) {
+	if cfg.onError != nil {
+		cfg.onError(err)
+	}
+}
+
+// PublishError is reported when a message could not be published.
+type PublishError struct {
+	// JobID of the job the message was about
+	JobID string
+	// Err is the publisher's error
+	Err error
+}
+
+func (e *PublishError) Error() string {
+	return "relay: failed to publish job " + e.JobID + ": " + e.Err.Error()
+}
+
+func (e *PublishError) Unwrap() error {
+	return e.Err
+}
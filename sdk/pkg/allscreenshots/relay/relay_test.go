@@ -0,0 +1,121 @@
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu   sync.Mutex
+	msgs []events.JobCompleted
+}
+
+func (r *recorder) Publish(ctx context.Context, msg events.JobCompleted) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.msgs = append(r.msgs, msg)
+	return nil
+}
+
+func (r *recorder) ids() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ids []string
+	for _, m := range r.msgs {
+		ids = append(ids, m.JobID)
+	}
+	return ids
+}
+
+func TestRun_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots/jobs/events", r.URL.Path)
+		assert.Equal(t, "COMPLETED,FAILED,CANCELLED", r.URL.Query().Get("statuses"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "id: 1\nevent: job.updated\ndata: {\"id\":\"job-1\",\"status\":\"COMPLETED\",\"url\":\"https://example.com\",\"resultUrl\":\"https://cdn/1.png\"}\n\n")
+		fmt.Fprint(w, "id: 2\nevent: job.updated\ndata: {\"id\":\"job-2\",\"status\":\"FAILED\",\"errorCode\":\"TIMEOUT\"}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(server.URL))
+	rec := &recorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- Run(ctx, client, rec) }()
+
+	require.Eventually(t, func() bool { return len(rec.ids()) == 2 }, 2*time.Second, 5*time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	assert.Equal(t, events.TypeJobCompleted, rec.msgs[0].Type)
+	assert.Equal(t, "https://cdn/1.png", rec.msgs[0].ResultURL)
+	assert.Equal(t, events.TypeJobFailed, rec.msgs[1].Type)
+	assert.Equal(t, "TIMEOUT", rec.msgs[1].ErrorCode)
+}
+
+func TestRun_Polling(t *testing.T) {
+	var mu sync.Mutex
+	jobs := []allscreenshots.JobResponse{
+		{ID: "old", Status: allscreenshots.JobStatusCompleted},
+		{ID: "running", Status: allscreenshots.JobStatusProcessing},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		json.NewEncoder(w).Encode(jobs)
+	}))
+	defer server.Close()
+
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(server.URL))
+	rec := &recorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go Run(ctx, client, rec, WithPolling(10*time.Millisecond))
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Empty(t, rec.ids(), "jobs finished before the relay started are not published")
+
+	mu.Lock()
+	jobs[1].Status = allscreenshots.JobStatusCompleted
+	mu.Unlock()
+	require.Eventually(t, func() bool { return len(rec.ids()) == 1 }, time.Second, 5*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, []string{"running"}, rec.ids(), "each job is published once")
+}
+
+func TestRun_PublishErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]allscreenshots.JobResponse{{ID: "job-1", Status: allscreenshots.JobStatusProcessing}})
+	}))
+	defer server.Close()
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(server.URL))
+
+	var job = &allscreenshots.JobResponse{ID: "job-1", Status: allscreenshots.JobStatusCompleted}
+	var reported error
+	cfg := config{onError: func(err error) { reported = err }}
+	failing := events.PublisherFunc(func(ctx context.Context, msg events.JobCompleted) error {
+		return errors.New("broker down")
+	})
+	publish(context.Background(), failing, cfg, job)
+
+	var pubErr *PublishError
+	require.ErrorAs(t, reported, &pubErr)
+	assert.Equal(t, "job-1", pubErr.JobID)
+	assert.EqualError(t, reported, "relay: failed to publish job job-1: broker down")
+
+	assert.EqualError(t, Run(context.Background(), client, nil), "relay: client and publisher are required")
+}
@@ -0,0 +1,112 @@
+package allscreenshots
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// RequestKey returns a stable hash of the normalized parameters of req.
+// Requests that render the same image have the same key, even when they
+// differ in URL spelling (host case, default port, query parameter order,
+// fragment), in the order of unordered lists such as HideSelectors, or in
+// fields that only affect delivery, such as webhooks, tags, and cache
+// control. It returns "" for a nil request.
+//
+// Example:
+//
+//	key := allscreenshots.RequestKey(&allscreenshots.ScreenshotRequest{URL: "https://Example.com:443/?b=2&a=1"})
+//	// same as allscreenshots.RequestKey(&allscreenshots.ScreenshotRequest{URL: "https://example.com/?a=1&b=2"})
+func RequestKey(req *ScreenshotRequest) string {
+	if req == nil {
+		return ""
+	}
+	n := *req
+	n.URL = normalizeKeyURL(n.URL)
+	n.Format = strings.ToLower(n.Format)
+	if n.Format == "jpg" {
+		n.Format = "jpeg"
+	}
+
+	// Delivery and bookkeeping options do not change the image.
+	n.WebhookURL = ""
+	n.WebhookSecret = ""
+	n.ResponseType = ""
+	n.Tags = nil
+	n.Cache = nil
+	n.Fresh = false
+	n.Storage = nil
+
+	n.HideSelectors = sortedStrings(n.HideSelectors)
+	n.BlockRequests = sortedStrings(n.BlockRequests)
+	n.BlockResourceTypes = sortedStrings(n.BlockResourceTypes)
+	if len(n.FailOnStatus) > 0 {
+		n.FailOnStatus = append([]int(nil), n.FailOnStatus...)
+		sort.Ints(n.FailOnStatus)
+	}
+	if len(n.Headers) > 0 {
+		headers := make(map[string]string, len(n.Headers))
+		for k, v := range n.Headers {
+			headers[http.CanonicalHeaderKey(k)] = v
+		}
+		n.Headers = headers
+	}
+
+	// encoding/json writes struct fields in declaration order and map keys
+	// sorted, so the encoding is deterministic.
+	data, _ := json.Marshal(&n)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// deliveryKey returns the key under which the client caches and coalesces
+// captures of req: RequestKey, extended with the options that have effects
+// beyond the image, such as the storage upload, the webhook, and the job's
+// tags, so requests that differ in them never share a result.
+func deliveryKey(req *ScreenshotRequest) string {
+	key := RequestKey(req)
+	delivery := struct {
+		Storage       *StorageConfig `json:"storage,omitempty"`
+		WebhookURL    string         `json:"webhookUrl,omitempty"`
+		WebhookSecret string         `json:"webhookSecret,omitempty"`
+		Tags          []string       `json:"tags,omitempty"`
+	}{req.Storage, req.WebhookURL, req.WebhookSecret, sortedStrings(req.Tags)}
+	if delivery.Storage == nil && delivery.WebhookURL == "" && len(delivery.Tags) == 0 {
+		return key
+	}
+	data, _ := json.Marshal(&delivery)
+	sum := sha256.Sum256(data)
+	return key + ":" + hex.EncodeToString(sum[:])
+}
+
+// normalizeKeyURL lowercases the scheme and host, drops default ports and
+// the fragment, and sorts the query parameters. URLs that do not parse are
+// returned unchanged.
+func normalizeKeyURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	canonicalizeHost(u, false)
+	u.Fragment = ""
+	u.RawFragment = ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.RawQuery = u.Query().Encode()
+	return u.String()
+}
+
+// sortedStrings returns a sorted copy of s.
+func sortedStrings(s []string) []string {
+	if len(s) == 0 {
+		return s
+	}
+	s = append([]string(nil), s...)
+	sort.Strings(s)
+	return s
+}
@@ -0,0 +1,93 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// DefaultCacheTTL is how long WithCache keeps a capture when the request does
+// not set Cache.TTLSeconds.
+const DefaultCacheTTL = time.Hour
+
+// ScreenshotCache stores captured images on the client side, keyed by
+// RequestKey. Implementations must be safe for concurrent use; the cache
+// package provides in-memory and Redis implementations.
+type ScreenshotCache interface {
+	// Get returns the image stored under key and whether it was found. The
+	// caller may modify the returned slice, so implementations that keep
+	// images in memory must return a copy.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores data under key for ttl.
+	Set(ctx context.Context, key string, data []byte, ttl time.Duration) error
+}
+
+// WithCache serves Screenshot calls from cache when an identical request
+// was captured recently, and stores new captures in it. Entries live for the
+// request's Cache.TTLSeconds, or DefaultCacheTTL if unset; requests with
+// Fresh, Storage, or WebhookURL set always reach the API, the latter two so
+// the upload and the webhook happen. The cache is best effort: if it fails,
+// the capture goes to the API and the failure is not reported.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(
+//	    allscreenshots.WithCache(cache.NewMemory(256 << 20)),
+//	)
+func WithCache(cache ScreenshotCache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// screenshot captures req, consulting the client's cache first and
+// coalescing concurrent identical captures when enabled.
+func (c *Client) screenshot(ctx context.Context, req *ScreenshotRequest) ([]byte, error) {
+	send := c.applyAutoTimeout(ctx, req)
+	capture := func(ctx context.Context) ([]byte, error) {
+		release, err := c.acquireRender(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return c.requestBinary(ctx, http.MethodPost, c.endpoint("/screenshots"), send)
+	}
+	if c.cache == nil && c.flights == nil {
+		return capture(ctx)
+	}
+
+	// The key is derived before the automatic timeout, which varies with
+	// the caller's deadline but does not change the image.
+	key := deliveryKey(req)
+	useCache := c.cache != nil && !req.Fresh && req.Storage == nil && req.WebhookURL == ""
+	if useCache {
+		if data, ok, err := c.cache.Get(ctx, key); err == nil && ok {
+			return data, nil
+		}
+	}
+
+	var data []byte
+	var err error
+	if c.flights != nil {
+		flightKey := key
+		if req.Fresh {
+			flightKey += ":fresh"
+		}
+		data, err = c.flights.do(ctx, flightKey, capture)
+	} else {
+		data, err = capture(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if useCache {
+		ttl := DefaultCacheTTL
+		if req.Cache != nil && req.Cache.TTLSeconds > 0 {
+			ttl = time.Duration(req.Cache.TTLSeconds) * time.Second
+		}
+		// The caller owns data; the cache must not see later changes to it.
+		_ = c.cache.Set(ctx, key, append([]byte(nil), data...), ttl)
+	}
+	return data, nil
+}
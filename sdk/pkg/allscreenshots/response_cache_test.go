@@ -0,0 +1,136 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapCache struct {
+	mu   sync.Mutex
+	data map[string][]byte
+	ttls map[string]time.Duration
+}
+
+func (m *mapCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.data[key]
+	return data, ok, nil
+}
+
+func (m *mapCache) Set(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[key] = data
+	m.ttls[key] = ttl
+	return nil
+}
+
+func TestClient_WithCache(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("image"))
+	}))
+	defer server.Close()
+
+	cache := &mapCache{data: map[string][]byte{}, ttls: map[string]time.Duration{}}
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithCache(cache))
+	ctx := context.Background()
+
+	for _, u := range []string{"https://example.com/?a=1&b=2", "https://EXAMPLE.com/?b=2&a=1"} {
+		data, err := client.Screenshot(ctx, &ScreenshotRequest{URL: u})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("image"), data)
+	}
+	assert.Equal(t, 1, calls, "the second request is served from the cache")
+	assert.Equal(t, DefaultCacheTTL, cache.ttls[RequestKey(&ScreenshotRequest{URL: "https://example.com/?a=1&b=2"})])
+
+	_, err := client.Screenshot(ctx, &ScreenshotRequest{URL: "https://example.com/?a=1&b=2", Fresh: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "fresh requests bypass the cache")
+
+	_, err = client.Screenshot(ctx, &ScreenshotRequest{URL: "https://example.com/other", Cache: &CacheConfig{Enabled: true, TTLSeconds: 120}})
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, cache.ttls[RequestKey(&ScreenshotRequest{URL: "https://example.com/other"})])
+}
+
+func TestClient_WithCache_CallerModifiesImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("image"))
+	}))
+	defer server.Close()
+
+	cache := &mapCache{data: map[string][]byte{}, ttls: map[string]time.Duration{}}
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithCache(cache))
+	req := &ScreenshotRequest{URL: "https://example.com"}
+
+	data, err := client.Screenshot(context.Background(), req)
+	require.NoError(t, err)
+	copy(data, "XXXXX")
+
+	data, err = client.Screenshot(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("image"), data, "the cached image is not the caller's slice")
+}
+
+func TestClient_WithCache_Storage(t *testing.T) {
+	var mu sync.Mutex
+	var buckets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ScreenshotRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		mu.Lock()
+		buckets = append(buckets, req.Storage.Bucket)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("image"))
+	}))
+	defer server.Close()
+
+	cache := &mapCache{data: map[string][]byte{}, ttls: map[string]time.Duration{}}
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL), WithCache(cache), WithRequestCoalescing())
+	for _, bucket := range []string{"customer-a", "customer-b", "customer-b"} {
+		_, err := client.Screenshot(context.Background(), &ScreenshotRequest{
+			URL:     "https://example.com",
+			Storage: &StorageConfig{Provider: "s3", Bucket: bucket},
+		})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"customer-a", "customer-b", "customer-b"}, buckets, "every upload reaches the API")
+	assert.Empty(t, cache.data)
+}
+
+func TestRequestKey(t *testing.T) {
+	assert.Equal(t, "", RequestKey(nil))
+	assert.Equal(t,
+		RequestKey(&ScreenshotRequest{URL: "https://example.com/", Format: "jpeg", Tags: []string{"a"}}),
+		RequestKey(&ScreenshotRequest{URL: "https://example.com:443", Format: "JPG"}))
+	assert.NotEqual(t,
+		RequestKey(&ScreenshotRequest{URL: "https://example.com/"}),
+		RequestKey(&ScreenshotRequest{URL: "https://example.com/", DarkMode: true}))
+}
+
+func TestDeliveryKey(t *testing.T) {
+	plain := &ScreenshotRequest{URL: "https://example.com/"}
+	assert.Equal(t, RequestKey(plain), deliveryKey(plain))
+	assert.Equal(t,
+		deliveryKey(&ScreenshotRequest{URL: "https://example.com/", Tags: []string{"a", "b"}}),
+		deliveryKey(&ScreenshotRequest{URL: "https://example.com", Tags: []string{"b", "a"}}))
+	assert.NotEqual(t,
+		deliveryKey(&ScreenshotRequest{URL: "https://example.com/", Tags: []string{"a"}}),
+		deliveryKey(plain))
+	assert.NotEqual(t,
+		deliveryKey(&ScreenshotRequest{URL: "https://example.com/", Storage: &StorageConfig{Provider: "s3", Bucket: "a"}}),
+		deliveryKey(&ScreenshotRequest{URL: "https://example.com/", Storage: &StorageConfig{Provider: "s3", Bucket: "b"}}))
+}
@@ -0,0 +1,212 @@
+package allscreenshots
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrResultNotFound is returned by a ResultStore when a key does not exist.
+var ErrResultNotFound = errors.New("allscreenshots: result not found")
+
+// ResultMeta describes a result kept in a ResultStore.
+type ResultMeta struct {
+	// Key the result is stored under
+	Key string `json:"key"`
+	// ContentType of the result, e.g. "image/png"
+	ContentType string `json:"contentType,omitempty"`
+	// Size of the result in bytes
+	Size int64 `json:"size"`
+	// SourceURL is the page that was captured
+	SourceURL string `json:"sourceUrl,omitempty"`
+	// JobID of the job that produced the result, if any
+	JobID string `json:"jobId,omitempty"`
+	// CreatedAt is when the result was stored
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ResultStore persists capture results with their metadata. Pools, bulk
+// jobs, and schedules can all save into a ResultStore, so downstream code
+// reads results the same way wherever they came from. The resultstore
+// package provides filesystem and in-memory implementations.
+// Implementations must be safe for concurrent use.
+type ResultStore interface {
+	// Put stores data under key, replacing any previous result. meta.Key
+	// and meta.Size are set from key and data.
+	Put(ctx context.Context, key string, data []byte, meta ResultMeta) error
+	// Get returns the result stored under key, or ErrResultNotFound.
+	Get(ctx context.Context, key string) ([]byte, *ResultMeta, error)
+	// Delete removes the result stored under key, or returns ErrResultNotFound.
+	Delete(ctx context.Context, key string) error
+	// List returns the metadata of every result whose key starts with
+	// prefix, sorted by key.
+	List(ctx context.Context, prefix string) ([]ResultMeta, error)
+}
+
+// ResultExtension returns the file extension, including the dot, for a
+// result of the given content type, e.g. ".png"; "" if unknown.
+func ResultExtension(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/webp":
+		return ".webp"
+	case "image/gif":
+		return ".gif"
+	case "application/pdf":
+		return ".pdf"
+	case "video/mp4":
+		return ".mp4"
+	}
+	return ""
+}
+
+// SaveBulkResults downloads the result of every completed job in a bulk job
+// and stores it under "bulk/<bulk id>/<job id><ext>". Jobs without a result
+// are skipped. It returns the metadata of the stored results; the error
+// joins the failures of individual downloads.
+//
+// Example:
+//
+//	store, _ := resultstore.NewFS("./results")
+//	saved, err := client.SaveBulkResults(ctx, bulk.ID, store)
+func (c *Client) SaveBulkResults(ctx context.Context, bulkID string, store ResultStore) ([]ResultMeta, error) {
+	if store == nil {
+		return nil, &ValidationError{Field: "store", Message: "store is required"}
+	}
+	status, err := c.GetBulkJob(ctx, bulkID)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved []ResultMeta
+	var errs []error
+	for _, job := range status.Jobs {
+		if job.ResultURL == "" || job.ErrorCode != "" {
+			continue
+		}
+		meta, err := c.saveResult(ctx, store, job.ResultURL, "bulk/"+bulkID+"/"+job.ID, ResultMeta{SourceURL: job.URL, JobID: job.ID})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %s (%s): %w", job.ID, job.URL, err))
+			continue
+		}
+		saved = append(saved, *meta)
+	}
+	return saved, errors.Join(errs...)
+}
+
+// SaveScheduleResults downloads the results of a schedule's most recent
+// successful executions (up to limit, 0 for the API default) and stores
+// them under "schedules/<schedule id>/<execution id><ext>", or
+// "schedules/<schedule id>/<execution id>/<n><ext>" for schedules with
+// several URLs. Results already in the store are not downloaded again, so
+// calling it periodically keeps the store in sync. It returns the metadata
+// of newly stored results; the error joins the failures of individual
+// downloads.
+//
+// Example:
+//
+//	saved, err := client.SaveScheduleResults(ctx, schedule.ID, 20, store)
+func (c *Client) SaveScheduleResults(ctx context.Context, scheduleID string, limit int, store ResultStore) ([]ResultMeta, error) {
+	if store == nil {
+		return nil, &ValidationError{Field: "store", Message: "store is required"}
+	}
+	history, err := c.GetScheduleHistory(ctx, scheduleID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := "schedules/" + scheduleID + "/"
+	existing, err := store.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	stored := make(map[string]bool, len(existing))
+	for _, m := range existing {
+		stored[trimExtension(m.Key)] = true
+	}
+
+	var saved []ResultMeta
+	var errs []error
+	save := func(resultURL, key, sourceURL string) {
+		if resultURL == "" || stored[key] {
+			return
+		}
+		meta, err := c.saveResult(ctx, store, resultURL, key, ResultMeta{SourceURL: sourceURL})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+			return
+		}
+		saved = append(saved, *meta)
+	}
+	for _, exec := range history.Executions {
+		if len(exec.Results) == 0 {
+			if exec.ErrorCode == "" {
+				save(exec.ResultURL, prefix+exec.ID, "")
+			}
+			continue
+		}
+		for i, r := range exec.Results {
+			if r.ErrorCode == "" {
+				save(r.ResultURL, fmt.Sprintf("%s%s/%d", prefix, exec.ID, i), r.URL)
+			}
+		}
+	}
+	return saved, errors.Join(errs...)
+}
+
+// saveResult downloads resultURL and stores it under key plus the extension
+// of its content type.
+func (c *Client) saveResult(ctx context.Context, store ResultStore, resultURL, key string, meta ResultMeta) (*ResultMeta, error) {
+	var buf bytes.Buffer
+	var contentType string
+	err := c.requestRaw(ctx, http.MethodGet, resultURL, nil, func(resp *http.Response) error {
+		contentType = resp.Header.Get("Content-Type")
+		_, err := buf.ReadFrom(resp.Body)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(buf.Bytes())
+	}
+
+	key += ResultExtension(contentType)
+	meta.Key = key
+	meta.ContentType = contentType
+	meta.Size = int64(buf.Len())
+	meta.CreatedAt = time.Now()
+	if err := store.Put(ctx, key, buf.Bytes(), meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// trimExtension removes a file extension from the last segment of key.
+func trimExtension(key string) string {
+	for i := len(key) - 1; i >= 0 && key[i] != '/'; i-- {
+		if key[i] == '.' {
+			return key[:i]
+		}
+	}
+	return key
+}
+
+// storeKey is the default key for a pool result: the request key, under a
+// readable host prefix when the request has a URL.
+func storeKey(req *ScreenshotRequest, contentType string) string {
+	key := RequestKey(req)
+	if u, err := url.Parse(req.URL); err == nil && u.Host != "" {
+		key = u.Host + "/" + key
+	}
+	return key + ResultExtension(contentType)
+}
@@ -0,0 +1,158 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapResultStore is a minimal ResultStore for tests.
+type mapResultStore struct {
+	mu    sync.Mutex
+	data  map[string][]byte
+	metas map[string]ResultMeta
+}
+
+func newMapResultStore() *mapResultStore {
+	return &mapResultStore{data: map[string][]byte{}, metas: map[string]ResultMeta{}}
+}
+
+func (s *mapResultStore) Put(ctx context.Context, key string, data []byte, meta ResultMeta) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	meta.Key, meta.Size = key, int64(len(data))
+	s.data[key], s.metas[key] = data, meta
+	return nil
+}
+
+func (s *mapResultStore) Get(ctx context.Context, key string) ([]byte, *ResultMeta, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	meta, ok := s.metas[key]
+	if !ok {
+		return nil, nil, ErrResultNotFound
+	}
+	return s.data[key], &meta, nil
+}
+
+func (s *mapResultStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+	delete(s.metas, key)
+	return nil
+}
+
+func (s *mapResultStore) List(ctx context.Context, prefix string) ([]ResultMeta, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var metas []ResultMeta
+	for key, meta := range s.metas {
+		if strings.HasPrefix(key, prefix) {
+			metas = append(metas, meta)
+		}
+	}
+	sort.Slice(metas, func(i, j int) bool { return metas[i].Key < metas[j].Key })
+	return metas, nil
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\nrest")
+
+func TestPool_Store(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(pngHeader)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	store := newMapResultStore()
+	pool := NewPool(client, PoolOptions{Store: store})
+
+	req := &ScreenshotRequest{URL: "https://example.com/a"}
+	results, err := pool.CaptureAll(context.Background(), []*ScreenshotRequest{req})
+	require.NoError(t, err)
+
+	key := "example.com/" + RequestKey(req) + ".png"
+	assert.Equal(t, key, results[0].Key)
+	data, meta, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, pngHeader, data)
+	assert.Equal(t, "image/png", meta.ContentType)
+	assert.Equal(t, "https://example.com/a", meta.SourceURL)
+}
+
+func TestClient_SaveBulkResults(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/screenshots/bulk/bulk-1":
+			json.NewEncoder(w).Encode(BulkStatusResponse{ID: "bulk-1", Jobs: []BulkJobDetailInfo{
+				{ID: "job-1", URL: "https://example.com", Status: "COMPLETED", ResultURL: server.URL + "/results/job-1"},
+				{ID: "job-2", URL: "https://example.org", Status: "FAILED", ErrorCode: "TIMEOUT"},
+			}})
+		case "/results/job-1":
+			w.Header().Set("Content-Type", "image/jpeg")
+			w.Write([]byte("jpeg"))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	store := newMapResultStore()
+
+	saved, err := client.SaveBulkResults(context.Background(), "bulk-1", store)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "bulk/bulk-1/job-1.jpg", saved[0].Key)
+	assert.Equal(t, "job-1", saved[0].JobID)
+	assert.Equal(t, []byte("jpeg"), store.data["bulk/bulk-1/job-1.jpg"])
+}
+
+func TestClient_SaveScheduleResults(t *testing.T) {
+	downloads := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/schedules/s-1/history":
+			json.NewEncoder(w).Encode(ScheduleHistoryResponse{ScheduleID: "s-1", Executions: []ScheduleExecutionResponse{
+				{ID: "e-1", ResultURL: server.URL + "/results/e-1"},
+				{ID: "e-2", ErrorCode: "TIMEOUT"},
+				{ID: "e-3", Results: []ScheduleURLResult{
+					{URL: "https://example.com/a", ResultURL: server.URL + "/results/e-3-0"},
+					{URL: "https://example.com/b", ErrorCode: "TIMEOUT"},
+				}},
+			}})
+		case strings.HasPrefix(r.URL.Path, "/results/"):
+			downloads++
+			w.Write(pngHeader)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	store := newMapResultStore()
+
+	saved, err := client.SaveScheduleResults(context.Background(), "s-1", 10, store)
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.Equal(t, "schedules/s-1/e-1.png", saved[0].Key)
+	assert.Equal(t, "schedules/s-1/e-3/0.png", saved[1].Key)
+	assert.Equal(t, "https://example.com/a", saved[1].SourceURL)
+
+	saved, err = client.SaveScheduleResults(context.Background(), "s-1", 10, store)
+	require.NoError(t, err)
+	assert.Empty(t, saved, "results already stored are skipped")
+	assert.Equal(t, 2, downloads)
+}
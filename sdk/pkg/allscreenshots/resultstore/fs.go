@@ -0,0 +1,193 @@
+package resultstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+// metaSuffix is appended to a result's file name to name its metadata file.
+const metaSuffix = ".meta.json"
+
+// FS keeps results as files in a directory. Keys map to paths below the
+// directory, so "bulk/b-1/job-1.png" is stored at <dir>/bulk/b-1/job-1.png,
+// with its metadata next to it in job-1.png.meta.json.
+type FS struct {
+	dir string
+}
+
+// NewFS returns a store rooted at dir, creating it if needed.
+//
+// Example:
+//
+//	store, err := resultstore.NewFS("./results")
+func NewFS(dir string) (*FS, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("resultstore: failed to create directory: %w", err)
+	}
+	return &FS{dir: dir}, nil
+}
+
+// Put stores data under key. The metadata is written after the data, so
+// List never reports a result whose data is incomplete.
+func (s *FS) Put(ctx context.Context, key string, data []byte, meta allscreenshots.ResultMeta) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	meta.Key = key
+	meta.Size = int64(len(data))
+	metaData, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("resultstore: failed to encode metadata for %s: %w", key, err)
+	}
+
+	p := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("resultstore: failed to write %s: %w", key, err)
+	}
+	if err := writeFile(p, data); err != nil {
+		return fmt.Errorf("resultstore: failed to write %s: %w", key, err)
+	}
+	if err := writeFile(p+metaSuffix, metaData); err != nil {
+		return fmt.Errorf("resultstore: failed to write %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get returns the result stored under key.
+func (s *FS) Get(ctx context.Context, key string) ([]byte, *allscreenshots.ResultMeta, error) {
+	if err := validateKey(key); err != nil {
+		return nil, nil, err
+	}
+	meta, err := s.readMeta(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, allscreenshots.ErrResultNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("resultstore: failed to read %s: %w", key, err)
+	}
+	return data, meta, nil
+}
+
+// Delete removes the result stored under key.
+func (s *FS) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	p := s.path(key)
+	err := os.Remove(p + metaSuffix)
+	if errors.Is(err, fs.ErrNotExist) {
+		return allscreenshots.ErrResultNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("resultstore: failed to delete %s: %w", key, err)
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("resultstore: failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+// List returns the metadata of results whose key starts with prefix.
+func (s *FS) List(ctx context.Context, prefix string) ([]allscreenshots.ResultMeta, error) {
+	var metas []allscreenshots.ResultMeta
+	err := filepath.WalkDir(s.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, metaSuffix) {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, strings.TrimSuffix(p, metaSuffix))
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		meta, err := s.readMeta(key)
+		if err != nil {
+			return err
+		}
+		metas = append(metas, *meta)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resultstore: failed to list %q: %w", prefix, err)
+	}
+	sort.Slice(metas, func(i, j int) bool { return metas[i].Key < metas[j].Key })
+	return metas, nil
+}
+
+// readMeta reads the metadata of the result stored under key.
+func (s *FS) readMeta(key string) (*allscreenshots.ResultMeta, error) {
+	data, err := os.ReadFile(s.path(key) + metaSuffix)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, allscreenshots.ErrResultNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resultstore: failed to read %s: %w", key, err)
+	}
+	var meta allscreenshots.ResultMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("resultstore: invalid metadata for %s: %w", key, err)
+	}
+	return &meta, nil
+}
+
+func (s *FS) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
+
+// writeFile writes data to a temporary file and renames it into place, so
+// readers never see a partial file.
+func writeFile(name string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// validateKey rejects keys that would escape the store's root or collide
+// with metadata files.
+func validateKey(key string) error {
+	if key == "" {
+		return errors.New("resultstore: key is required")
+	}
+	if strings.HasPrefix(key, "/") || strings.Contains(key, `\`) || path.Clean(key) != key {
+		return fmt.Errorf("resultstore: invalid key %q", key)
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == ".." {
+			return fmt.Errorf("resultstore: invalid key %q", key)
+		}
+	}
+	if strings.HasSuffix(key, metaSuffix) {
+		return fmt.Errorf("resultstore: key %q must not end in %s", key, metaSuffix)
+	}
+	return nil
+}
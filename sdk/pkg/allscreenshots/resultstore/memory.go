@@ -0,0 +1,80 @@
+// Package resultstore provides allscreenshots.ResultStore implementations:
+// a local directory and an in-process map.
+package resultstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+// Memory keeps results in memory. It suits tests and short-lived processes.
+type Memory struct {
+	mu      sync.RWMutex
+	results map[string]memoryResult
+}
+
+// memoryResult is a stored result in a Memory store.
+type memoryResult struct {
+	data []byte
+	meta allscreenshots.ResultMeta
+}
+
+// NewMemory creates an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{results: make(map[string]memoryResult)}
+}
+
+// Put stores data under key.
+func (m *Memory) Put(ctx context.Context, key string, data []byte, meta allscreenshots.ResultMeta) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	meta.Key = key
+	meta.Size = int64(len(data))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results[key] = memoryResult{data: append([]byte(nil), data...), meta: meta}
+	return nil
+}
+
+// Get returns the result stored under key.
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, *allscreenshots.ResultMeta, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	r, ok := m.results[key]
+	if !ok {
+		return nil, nil, allscreenshots.ErrResultNotFound
+	}
+	meta := r.meta
+	return append([]byte(nil), r.data...), &meta, nil
+}
+
+// Delete removes the result stored under key.
+func (m *Memory) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.results[key]; !ok {
+		return allscreenshots.ErrResultNotFound
+	}
+	delete(m.results, key)
+	return nil
+}
+
+// List returns the metadata of results whose key starts with prefix.
+func (m *Memory) List(ctx context.Context, prefix string) ([]allscreenshots.ResultMeta, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var metas []allscreenshots.ResultMeta
+	for key, r := range m.results {
+		if strings.HasPrefix(key, prefix) {
+			metas = append(metas, r.meta)
+		}
+	}
+	sort.Slice(metas, func(i, j int) bool { return metas[i].Key < metas[j].Key })
+	return metas, nil
+}
@@ -0,0 +1,70 @@
+package resultstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	fsStore, err := NewFS(t.TempDir())
+	require.NoError(t, err)
+
+	stores := map[string]allscreenshots.ResultStore{
+		"fs":     fsStore,
+		"memory": NewMemory(),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			require.NoError(t, store.Put(ctx, "bulk/b-1/job-1.png", []byte("one"), allscreenshots.ResultMeta{
+				ContentType: "image/png",
+				SourceURL:   "https://example.com",
+				JobID:       "job-1",
+				CreatedAt:   created,
+			}))
+			require.NoError(t, store.Put(ctx, "bulk/b-1/job-2.png", []byte("two!"), allscreenshots.ResultMeta{}))
+			require.NoError(t, store.Put(ctx, "other.png", []byte("x"), allscreenshots.ResultMeta{}))
+
+			data, meta, err := store.Get(ctx, "bulk/b-1/job-1.png")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("one"), data)
+			assert.Equal(t, allscreenshots.ResultMeta{
+				Key:         "bulk/b-1/job-1.png",
+				ContentType: "image/png",
+				Size:        3,
+				SourceURL:   "https://example.com",
+				JobID:       "job-1",
+				CreatedAt:   created,
+			}, *meta)
+
+			metas, err := store.List(ctx, "bulk/")
+			require.NoError(t, err)
+			require.Len(t, metas, 2)
+			assert.Equal(t, "bulk/b-1/job-1.png", metas[0].Key)
+			assert.Equal(t, int64(4), metas[1].Size)
+
+			require.NoError(t, store.Delete(ctx, "bulk/b-1/job-1.png"))
+			_, _, err = store.Get(ctx, "bulk/b-1/job-1.png")
+			assert.ErrorIs(t, err, allscreenshots.ErrResultNotFound)
+			assert.ErrorIs(t, store.Delete(ctx, "bulk/b-1/job-1.png"), allscreenshots.ErrResultNotFound)
+
+			all, err := store.List(ctx, "")
+			require.NoError(t, err)
+			assert.Len(t, all, 2)
+		})
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"", "/abs.png", "../escape.png", "a/../../b", "a//b", "a.png.meta.json"} {
+		assert.Error(t, validateKey(key), key)
+	}
+	assert.NoError(t, validateKey("schedules/s-1/e-1/0.png"))
+}
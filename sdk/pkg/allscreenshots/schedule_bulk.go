@@ -0,0 +1,162 @@
+package allscreenshots
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CloneSchedule creates a new schedule with the settings of an existing one,
+// replacing any fields set in overrides. Without an overridden name the clone
+// is named after the original with " (copy)" appended.
+//
+// The API never returns webhook secrets, so set overrides.WebhookSecret if
+// the clone should sign its webhook deliveries.
+//
+// Example:
+//
+//	clone, err := client.CloneSchedule(ctx, "schedule-id", &allscreenshots.UpdateScheduleRequest{
+//	    Name: "Pricing page",
+//	    URL:  "https://example.com/pricing",
+//	})
+func (c *Client) CloneSchedule(ctx context.Context, id string, overrides *UpdateScheduleRequest) (*ScheduleResponse, error) {
+	original, err := c.GetSchedule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &CreateScheduleRequest{
+		Name:          original.Name + " (copy)",
+		URL:           original.URL,
+		URLs:          original.URLs,
+		Schedule:      original.Schedule,
+		Timezone:      original.Timezone,
+		Options:       original.Options,
+		WebhookURL:    original.WebhookURL,
+		RetentionDays: original.RetentionDays,
+		StartsAt:      original.StartsAt,
+		EndsAt:        original.EndsAt,
+	}
+	if o := overrides; o != nil {
+		if o.Name != "" {
+			req.Name = o.Name
+		}
+		if o.URL != "" {
+			req.URL, req.URLs = o.URL, nil
+		}
+		if len(o.URLs) > 0 {
+			req.URL, req.URLs = "", o.URLs
+		}
+		if o.Schedule != "" {
+			req.Schedule = o.Schedule
+		}
+		if o.Timezone != "" {
+			req.Timezone = o.Timezone
+		}
+		if o.Options != nil {
+			req.Options = o.Options
+		}
+		if o.WebhookURL != "" {
+			req.WebhookURL = o.WebhookURL
+		}
+		if o.WebhookSecret != "" {
+			req.WebhookSecret = o.WebhookSecret
+		}
+		if o.RetentionDays != 0 {
+			req.RetentionDays = o.RetentionDays
+		}
+		if o.StartsAt != nil {
+			req.StartsAt = o.StartsAt
+		}
+		if o.EndsAt != nil {
+			req.EndsAt = o.EndsAt
+		}
+	}
+
+	return c.CreateSchedule(ctx, req)
+}
+
+// ScheduleFilter selects schedules for BulkUpdateSchedules. A schedule must
+// satisfy every criterion that is set; an empty filter selects all schedules.
+type ScheduleFilter struct {
+	// IDs limits the selection to these schedules
+	IDs []string
+	// NamePrefix selects schedules whose name starts with this prefix
+	NamePrefix string
+	// Status selects schedules with this status, e.g. "ACTIVE" or "PAUSED"
+	Status string
+	// Match, if set, is called for every schedule that passes the other criteria
+	Match func(s *ScheduleResponse) bool
+}
+
+// matches reports whether s satisfies the filter.
+func (f *ScheduleFilter) matches(s *ScheduleResponse) bool {
+	if len(f.IDs) > 0 {
+		found := false
+		for _, id := range f.IDs {
+			if id == s.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.NamePrefix != "" && !strings.HasPrefix(s.Name, f.NamePrefix) {
+		return false
+	}
+	if f.Status != "" && !strings.EqualFold(s.Status, f.Status) {
+		return false
+	}
+	return f.Match == nil || f.Match(s)
+}
+
+// BulkUpdateSchedules applies patch to every schedule selected by filter and
+// returns the updated schedules. Every selected schedule is attempted; the
+// returned error joins the failures, if any.
+//
+// Example:
+//
+//	// Switch every marketing monitor to a new webhook
+//	updated, err := client.BulkUpdateSchedules(ctx, &allscreenshots.ScheduleFilter{
+//	    NamePrefix: "marketing/",
+//	}, &allscreenshots.UpdateScheduleRequest{
+//	    WebhookURL: "https://hooks.example.com/screenshots",
+//	})
+func (c *Client) BulkUpdateSchedules(ctx context.Context, filter *ScheduleFilter, patch *UpdateScheduleRequest) ([]ScheduleResponse, error) {
+	if patch == nil {
+		return nil, &ValidationError{Field: "patch", Message: "patch cannot be nil"}
+	}
+	if err := validateTimezone("timezone", patch.Timezone); err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		filter = &ScheduleFilter{}
+	}
+
+	list, err := c.ListSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated []ScheduleResponse
+	var errs []error
+	for i := range list.Schedules {
+		s := &list.Schedules[i]
+		if !filter.matches(s) {
+			continue
+		}
+		result, err := c.UpdateSchedule(ctx, s.ID, patch)
+		if err != nil {
+			if ctx.Err() != nil {
+				return updated, err
+			}
+			errs = append(errs, fmt.Errorf("schedule %s (%s): %w", s.ID, s.Name, err))
+			continue
+		}
+		updated = append(updated, *result)
+	}
+	return updated, errors.Join(errs...)
+}
@@ -0,0 +1,111 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CloneSchedule(t *testing.T) {
+	var created CreateScheduleRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/schedules/s-1":
+			json.NewEncoder(w).Encode(ScheduleResponse{
+				ID: "s-1", Name: "Homepage", URL: "https://example.com", Schedule: "0 9 * * *",
+				Timezone: "Europe/Amsterdam", RetentionDays: 30, WebhookURL: "https://hooks.example.com",
+				Options: &ScheduleScreenshotOptions{Device: "Desktop HD", FullPage: true},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/schedules":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			json.NewEncoder(w).Encode(ScheduleResponse{ID: "s-2", Name: created.Name, URL: created.URL})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	clone, err := client.CloneSchedule(context.Background(), "s-1", &UpdateScheduleRequest{URL: "https://example.com/pricing"})
+	require.NoError(t, err)
+	assert.Equal(t, "s-2", clone.ID)
+	assert.Equal(t, "Homepage (copy)", created.Name)
+	assert.Equal(t, "https://example.com/pricing", created.URL)
+	assert.Equal(t, "0 9 * * *", created.Schedule)
+	assert.Equal(t, "Europe/Amsterdam", created.Timezone)
+	assert.Equal(t, 30, created.RetentionDays)
+	assert.Equal(t, "https://hooks.example.com", created.WebhookURL)
+	require.NotNil(t, created.Options)
+	assert.Equal(t, "Desktop HD", created.Options.Device)
+	assert.True(t, created.Options.FullPage)
+
+	_, err = client.CloneSchedule(context.Background(), "", nil)
+	assert.True(t, IsValidationError(err))
+}
+
+func TestClient_BulkUpdateSchedules(t *testing.T) {
+	var mu sync.Mutex
+	var updatedIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/schedules":
+			json.NewEncoder(w).Encode(ScheduleListResponse{Schedules: []ScheduleResponse{
+				{ID: "s-1", Name: "marketing/home", Status: "ACTIVE"},
+				{ID: "s-2", Name: "marketing/pricing", Status: "PAUSED"},
+				{ID: "s-3", Name: "marketing/broken", Status: "ACTIVE"},
+				{ID: "s-4", Name: "docs/home", Status: "ACTIVE"},
+			}})
+		case r.Method == http.MethodPut:
+			id := strings.TrimPrefix(r.URL.Path, "/v1/schedules/")
+			if id == "s-3" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			var req UpdateScheduleRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "https://hooks.example.com", req.WebhookURL)
+			mu.Lock()
+			updatedIDs = append(updatedIDs, id)
+			mu.Unlock()
+			json.NewEncoder(w).Encode(ScheduleResponse{ID: id, WebhookURL: req.WebhookURL})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	patch := &UpdateScheduleRequest{WebhookURL: "https://hooks.example.com"}
+
+	updated, err := client.BulkUpdateSchedules(context.Background(), &ScheduleFilter{NamePrefix: "marketing/", Status: "active"}, patch)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "schedule s-3 (marketing/broken)")
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	require.Len(t, updated, 1)
+	assert.Equal(t, "s-1", updated[0].ID)
+
+	updatedIDs = nil
+	updated, err = client.BulkUpdateSchedules(context.Background(), &ScheduleFilter{
+		IDs:   []string{"s-2", "s-4"},
+		Match: func(s *ScheduleResponse) bool { return s.Name != "docs/home" },
+	}, patch)
+	require.NoError(t, err)
+	assert.Len(t, updated, 1)
+	assert.Equal(t, []string{"s-2"}, updatedIDs)
+
+	_, err = client.BulkUpdateSchedules(context.Background(), nil, nil)
+	assert.True(t, IsValidationError(err))
+}
@@ -0,0 +1,31 @@
+package allscreenshots
+
+import "encoding/json"
+
+// scheduleOptionsFields aliases ScheduleScreenshotOptions without its JSON methods.
+type scheduleOptionsFields ScheduleScreenshotOptions
+
+// UnmarshalJSON decodes the known options and keeps every other key in Extra,
+// so options added to the API later survive a read-modify-write round trip.
+func (o *ScheduleScreenshotOptions) UnmarshalJSON(data []byte) error {
+	var fields scheduleOptionsFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	extra, err := unknownJSONFields(data, fields)
+	if err != nil {
+		return err
+	}
+	fields.Extra = extra
+	*o = ScheduleScreenshotOptions(fields)
+	return nil
+}
+
+// MarshalJSON encodes the known options together with the keys in Extra.
+func (o ScheduleScreenshotOptions) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(scheduleOptionsFields(o))
+	if err != nil {
+		return nil, err
+	}
+	return mergeJSONFields(data, o.Extra)
+}
@@ -0,0 +1,38 @@
+package allscreenshots
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleResponse_Options(t *testing.T) {
+	input := `{"id":"s-1","name":"Homepage","options":{"device":"Desktop HD","fullPage":true,"quality":80,"hideSelectors":[".ad"],"stealth":true}}`
+
+	var schedule ScheduleResponse
+	require.NoError(t, json.Unmarshal([]byte(input), &schedule))
+	require.NotNil(t, schedule.Options)
+	assert.Equal(t, "Desktop HD", schedule.Options.Device)
+	assert.True(t, schedule.Options.FullPage)
+	assert.Equal(t, 80, schedule.Options.Quality)
+	assert.Equal(t, []string{".ad"}, schedule.Options.HideSelectors)
+	assert.Equal(t, map[string]interface{}{"stealth": true}, schedule.Options.Extra)
+
+	// Reusing the options in an update keeps the unknown keys.
+	out, err := json.Marshal(UpdateScheduleRequest{Options: schedule.Options})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"options":{"device":"Desktop HD","fullPage":true,"quality":80,"hideSelectors":[".ad"],"stealth":true}}`, string(out))
+}
+
+func TestScheduleScreenshotOptions_NoExtra(t *testing.T) {
+	var opts ScheduleScreenshotOptions
+	require.NoError(t, json.Unmarshal([]byte(`{"format":"jpeg"}`), &opts))
+	assert.Equal(t, "jpeg", opts.Format)
+	assert.Nil(t, opts.Extra)
+
+	out, err := json.Marshal(&opts)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"format":"jpeg"}`, string(out))
+}
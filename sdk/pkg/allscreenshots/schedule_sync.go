@@ -0,0 +1,209 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// ScheduleSyncAction describes what SyncSchedules did (or would do) with a schedule.
+type ScheduleSyncAction string
+
+const (
+	ScheduleSyncCreate    ScheduleSyncAction = "create"
+	ScheduleSyncUpdate    ScheduleSyncAction = "update"
+	ScheduleSyncDelete    ScheduleSyncAction = "delete"
+	ScheduleSyncUnchanged ScheduleSyncAction = "unchanged"
+)
+
+// ScheduleSyncOptions configures SyncSchedules.
+type ScheduleSyncOptions struct {
+	// Prune deletes existing schedules whose names are not in the desired set.
+	Prune bool
+	// DryRun computes the plan without creating, updating, or deleting anything.
+	DryRun bool
+}
+
+// ScheduleSyncChange describes a single planned or applied change.
+type ScheduleSyncChange struct {
+	Action ScheduleSyncAction `json:"action"`
+	Name   string             `json:"name"`
+	// ID of the affected schedule (empty for creates in dry-run mode)
+	ID string `json:"id,omitempty"`
+	// Schedule is the schedule as returned by the API after the change
+	Schedule *ScheduleResponse `json:"schedule,omitempty"`
+}
+
+// ScheduleSyncResult is the outcome of SyncSchedules.
+type ScheduleSyncResult struct {
+	Changes []ScheduleSyncChange `json:"changes"`
+}
+
+// Count returns the number of changes with the given action.
+func (r *ScheduleSyncResult) Count(action ScheduleSyncAction) int {
+	n := 0
+	for _, c := range r.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// SyncSchedules reconciles the account's schedules with a desired set.
+//
+// Schedules are matched by name: missing schedules are created, schedules
+// whose URL, cron expression, timezone, webhook, retention, or options differ
+// are updated, and with Prune set, schedules not in the desired set are
+// deleted. Options are compared only on the keys set in the desired request,
+// so server-side defaults do not cause spurious updates.
+//
+// Example:
+//
+//	result, err := client.SyncSchedules(ctx, []allscreenshots.CreateScheduleRequest{
+//	    {Name: "Homepage", URL: "https://example.com", Schedule: "0 9 * * *"},
+//	}, &allscreenshots.ScheduleSyncOptions{Prune: true})
+func (c *Client) SyncSchedules(ctx context.Context, desired []CreateScheduleRequest, opts *ScheduleSyncOptions) (*ScheduleSyncResult, error) {
+	if opts == nil {
+		opts = &ScheduleSyncOptions{}
+	}
+
+	seen := make(map[string]bool, len(desired))
+	for i := range desired {
+		if err := validateCreateScheduleRequest(&desired[i]); err != nil {
+			return nil, err
+		}
+		if seen[desired[i].Name] {
+			return nil, &ValidationError{Field: fmt.Sprintf("schedules[%d].name", i), Message: "duplicate schedule name " + desired[i].Name}
+		}
+		seen[desired[i].Name] = true
+	}
+
+	list, err := c.ListSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]ScheduleResponse, len(list.Schedules))
+	for _, s := range list.Schedules {
+		existing[s.Name] = s
+	}
+
+	result := &ScheduleSyncResult{}
+	for i := range desired {
+		want := &desired[i]
+		current, ok := existing[want.Name]
+		if !ok {
+			change := ScheduleSyncChange{Action: ScheduleSyncCreate, Name: want.Name}
+			if !opts.DryRun {
+				created, err := c.CreateSchedule(ctx, want)
+				if err != nil {
+					return result, err
+				}
+				change.ID = created.ID
+				change.Schedule = created
+			}
+			result.Changes = append(result.Changes, change)
+			continue
+		}
+
+		if !scheduleDiffers(&current, want) {
+			result.Changes = append(result.Changes, ScheduleSyncChange{Action: ScheduleSyncUnchanged, Name: want.Name, ID: current.ID, Schedule: &current})
+			continue
+		}
+
+		change := ScheduleSyncChange{Action: ScheduleSyncUpdate, Name: want.Name, ID: current.ID}
+		if !opts.DryRun {
+			updated, err := c.UpdateSchedule(ctx, current.ID, &UpdateScheduleRequest{
+				Name:          want.Name,
+				URL:           want.URL,
+				Schedule:      want.Schedule,
+				Timezone:      want.Timezone,
+				Options:       want.Options,
+				WebhookURL:    want.WebhookURL,
+				WebhookSecret: want.WebhookSecret,
+				RetentionDays: want.RetentionDays,
+				StartsAt:      want.StartsAt,
+				EndsAt:        want.EndsAt,
+			})
+			if err != nil {
+				return result, err
+			}
+			change.Schedule = updated
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	if opts.Prune {
+		for _, s := range list.Schedules {
+			if seen[s.Name] {
+				continue
+			}
+			change := ScheduleSyncChange{Action: ScheduleSyncDelete, Name: s.Name, ID: s.ID}
+			if !opts.DryRun {
+				if err := c.DeleteSchedule(ctx, s.ID); err != nil {
+					return result, err
+				}
+			}
+			result.Changes = append(result.Changes, change)
+		}
+	}
+
+	return result, nil
+}
+
+// scheduleDiffers reports whether an existing schedule needs updating to match want.
+func scheduleDiffers(current *ScheduleResponse, want *CreateScheduleRequest) bool {
+	if current.URL != want.URL || current.Schedule != want.Schedule || !slices.Equal(current.URLs, want.URLs) {
+		return true
+	}
+	if want.Timezone != "" && current.Timezone != want.Timezone {
+		return true
+	}
+	if want.WebhookURL != current.WebhookURL {
+		return true
+	}
+	if want.RetentionDays != 0 && want.RetentionDays != current.RetentionDays {
+		return true
+	}
+	if want.StartsAt != nil && (current.StartsAt == nil || !current.StartsAt.Equal(*want.StartsAt)) {
+		return true
+	}
+	if want.EndsAt != nil && (current.EndsAt == nil || !current.EndsAt.Equal(*want.EndsAt)) {
+		return true
+	}
+	if want.Options != nil {
+		wantOpts, err := optionsMap(want.Options)
+		if err != nil {
+			return true
+		}
+		currentOpts, err := optionsMap(current.Options)
+		if err != nil {
+			return true
+		}
+		for k, v := range wantOpts {
+			if !reflect.DeepEqual(currentOpts[k], v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// optionsMap returns the JSON form of opts as a map, so that options can be
+// compared key by key.
+func optionsMap(opts *ScheduleScreenshotOptions) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	if opts == nil {
+		return m, nil
+	}
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,95 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SyncSchedules(t *testing.T) {
+	newServer := func(t *testing.T, calls *[]string) *httptest.Server {
+		var mu sync.Mutex
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			*calls = append(*calls, r.Method+" "+r.URL.Path)
+			mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/v1/schedules":
+				json.NewEncoder(w).Encode(ScheduleListResponse{
+					Schedules: []ScheduleResponse{
+						{ID: "s-1", Name: "Same", URL: "https://same.com", Schedule: "0 9 * * *",
+							Options: &ScheduleScreenshotOptions{Device: "Desktop HD", Quality: 80}},
+						{ID: "s-2", Name: "Changed", URL: "https://old.com", Schedule: "0 9 * * *"},
+						{ID: "s-3", Name: "Stale", URL: "https://stale.com", Schedule: "0 9 * * *"},
+					},
+					Total: 3,
+				})
+			case r.Method == http.MethodPost && r.URL.Path == "/v1/schedules":
+				var req CreateScheduleRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				json.NewEncoder(w).Encode(ScheduleResponse{ID: "s-new", Name: req.Name, URL: req.URL})
+			case r.Method == http.MethodPut:
+				var req UpdateScheduleRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				json.NewEncoder(w).Encode(ScheduleResponse{ID: "s-2", Name: req.Name, URL: req.URL})
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	}
+
+	desired := []CreateScheduleRequest{
+		{Name: "Same", URL: "https://same.com", Schedule: "0 9 * * *", Options: &ScheduleScreenshotOptions{Device: "Desktop HD"}},
+		{Name: "Changed", URL: "https://new.com", Schedule: "0 9 * * *"},
+		{Name: "Brand new", URL: "https://new.com", Schedule: "0 * * * *"},
+	}
+
+	t.Run("applies changes", func(t *testing.T) {
+		var calls []string
+		server := newServer(t, &calls)
+		defer server.Close()
+
+		client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+		result, err := client.SyncSchedules(context.Background(), desired, &ScheduleSyncOptions{Prune: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Count(ScheduleSyncUnchanged))
+		assert.Equal(t, 1, result.Count(ScheduleSyncUpdate))
+		assert.Equal(t, 1, result.Count(ScheduleSyncCreate))
+		assert.Equal(t, 1, result.Count(ScheduleSyncDelete))
+		assert.Contains(t, calls, "PUT /v1/schedules/s-2")
+		assert.Contains(t, calls, "POST /v1/schedules")
+		assert.Contains(t, calls, "DELETE /v1/schedules/s-3")
+	})
+
+	t.Run("dry run only lists", func(t *testing.T) {
+		var calls []string
+		server := newServer(t, &calls)
+		defer server.Close()
+
+		client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+		result, err := client.SyncSchedules(context.Background(), desired, &ScheduleSyncOptions{Prune: true, DryRun: true})
+
+		require.NoError(t, err)
+		assert.Len(t, result.Changes, 4)
+		assert.Equal(t, []string{"GET /v1/schedules"}, calls)
+	})
+
+	t.Run("rejects duplicate names", func(t *testing.T) {
+		client := NewClient(WithAPIKey("test-api-key"))
+		_, err := client.SyncSchedules(context.Background(), []CreateScheduleRequest{desired[0], desired[0]}, nil)
+
+		require.Error(t, err)
+		assert.True(t, IsValidationError(err))
+	})
+}
@@ -0,0 +1,118 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// StorageState is browser state restored before the page loads, such as
+// the cookies and local storage of a logged-in user.
+type StorageState struct {
+	// Cookies to restore (max 50)
+	Cookies []Cookie `json:"cookies,omitempty"`
+	// LocalStorage entries set on the target URL's origin
+	LocalStorage map[string]string `json:"localStorage,omitempty"`
+}
+
+// LoginScript describes how to log in to a site; see CreateSession.
+type LoginScript struct {
+	// URL of the login page (required)
+	URL string `json:"url"`
+	// Actions that fill in and submit the login form (required, max 50)
+	Actions []Action `json:"actions"`
+	// WaitFor is a CSS selector that appears once the login succeeded
+	WaitFor string `json:"waitFor,omitempty"`
+	// TTLSeconds is how long the API keeps the session (default set by the API)
+	TTLSeconds int `json:"ttlSeconds,omitempty"`
+}
+
+// Session is a logged-in browser state kept by the API.
+type Session struct {
+	// Token identifies the session in ScreenshotRequest.Session
+	Token string `json:"token"`
+	// ExpiresAt is when the API discards the session
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// State is the browser state the login produced, to store and pass as
+	// ScreenshotRequest.SessionState once the session expires
+	State *StorageState `json:"state,omitempty"`
+}
+
+// CreateSession runs a login script in the browser and keeps the resulting
+// cookies and local storage as a session. Captures that set
+// ScreenshotRequest.Session to the returned token start from that state, so
+// pages behind a login can be captured repeatedly without logging in for
+// every request.
+//
+// Example:
+//
+//	session, err := client.CreateSession(ctx, &allscreenshots.LoginScript{
+//	    URL: "https://app.example.com/login",
+//	    Actions: []allscreenshots.Action{
+//	        allscreenshots.Type("#email", email),
+//	        allscreenshots.Type("#password", password),
+//	        allscreenshots.Click("button[type=submit]"),
+//	    },
+//	    WaitFor: "#dashboard",
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	image, err := client.Screenshot(ctx, &allscreenshots.ScreenshotRequest{
+//	    URL:     "https://app.example.com/reports",
+//	    Session: session.Token,
+//	})
+func (c *Client) CreateSession(ctx context.Context, script *LoginScript) (*Session, error) {
+	if err := validateLoginScript(script); err != nil {
+		return nil, err
+	}
+
+	var result Session
+	err := c.request(ctx, http.MethodPost, c.endpoint("/sessions"), script, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteSession discards a session before it expires, e.g. after logging
+// out the account it belongs to.
+func (c *Client) DeleteSession(ctx context.Context, token string) error {
+	if token == "" {
+		return &ValidationError{Field: "token", Message: "session token is required"}
+	}
+	return c.request(ctx, http.MethodDelete, c.endpoint("/sessions/"+url.PathEscape(token)), nil, nil)
+}
+
+// validateLoginScript validates a login script.
+func validateLoginScript(script *LoginScript) error {
+	if script == nil {
+		return &ValidationError{Field: "script", Message: "login script cannot be nil"}
+	}
+	if script.URL == "" {
+		return &ValidationError{Field: "url", Message: "URL is required"}
+	}
+	if !strings.HasPrefix(script.URL, "http://") && !strings.HasPrefix(script.URL, "https://") {
+		return &ValidationError{Field: "url", Message: "URL must start with http:// or https://"}
+	}
+	if len(script.Actions) == 0 {
+		return &ValidationError{Field: "actions", Message: "at least one action is required"}
+	}
+	if script.TTLSeconds < 0 {
+		return &ValidationError{Field: "ttlSeconds", Message: "ttlSeconds must not be negative"}
+	}
+	return validateActions(script.Actions)
+}
+
+// validateSessionState validates the session options of a screenshot request.
+func validateSessionState(req *ScreenshotRequest) error {
+	if req.SessionState == nil {
+		return nil
+	}
+	if req.Session != "" {
+		return &ValidationError{Field: "sessionState", Message: "sessionState cannot be combined with session"}
+	}
+	return validateCookies(req.SessionState.Cookies)
+}
@@ -0,0 +1,91 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/v1/sessions", r.URL.Path)
+			var script LoginScript
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&script))
+			assert.Equal(t, "https://app.example.com/login", script.URL)
+			require.Len(t, script.Actions, 2)
+			assert.Equal(t, ActionType, script.Actions[0].Type)
+			assert.Equal(t, "#dashboard", script.WaitFor)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"token":"sess-1","expiresAt":"2026-01-02T00:00:00Z","state":{"cookies":[{"name":"sid","value":"abc","domain":"app.example.com"}],"localStorage":{"theme":"dark"}}}`))
+		case http.MethodDelete:
+			assert.Equal(t, "/v1/sessions/sess-1", r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	session, err := client.CreateSession(context.Background(), &LoginScript{
+		URL:     "https://app.example.com/login",
+		Actions: []Action{Type("#email", "me@example.com"), Click("button[type=submit]")},
+		WaitFor: "#dashboard",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "sess-1", session.Token)
+	require.NotNil(t, session.ExpiresAt)
+	require.NotNil(t, session.State)
+	assert.Equal(t, "sid", session.State.Cookies[0].Name)
+	assert.Equal(t, "dark", session.State.LocalStorage["theme"])
+
+	require.NoError(t, client.DeleteSession(context.Background(), "sess-1"))
+	assert.True(t, IsValidationError(client.DeleteSession(context.Background(), "")))
+}
+
+func TestValidateLoginScript(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  *LoginScript
+		wantErr string
+	}{
+		{name: "valid", script: &LoginScript{URL: "https://example.com/login", Actions: []Action{Click("#login")}}},
+		{name: "nil script", wantErr: "login script cannot be nil"},
+		{name: "missing URL", script: &LoginScript{Actions: []Action{Click("#login")}}, wantErr: "URL is required"},
+		{name: "invalid URL", script: &LoginScript{URL: "example.com", Actions: []Action{Click("#login")}}, wantErr: "URL must start with http:// or https://"},
+		{name: "no actions", script: &LoginScript{URL: "https://example.com/login"}, wantErr: "at least one action is required"},
+		{name: "negative TTL", script: &LoginScript{URL: "https://example.com/login", Actions: []Action{Click("#login")}, TTLSeconds: -1}, wantErr: "ttlSeconds must not be negative"},
+		{name: "invalid action", script: &LoginScript{URL: "https://example.com/login", Actions: []Action{{Type: "hover"}}}, wantErr: "actions[0].type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateLoginScript(tt.script)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateScreenshotRequest_Session(t *testing.T) {
+	state := &StorageState{Cookies: []Cookie{{Name: "sid", Value: "abc"}}, LocalStorage: map[string]string{"theme": "dark"}}
+
+	assert.NoError(t, validateScreenshotRequest(&ScreenshotRequest{URL: "https://example.com", Session: "sess-1"}))
+	assert.NoError(t, validateScreenshotRequest(&ScreenshotRequest{URL: "https://example.com", SessionState: state}))
+
+	err := validateScreenshotRequest(&ScreenshotRequest{URL: "https://example.com", Session: "sess-1", SessionState: state})
+	assert.EqualError(t, err, "allscreenshots: validation error for field 'sessionState': sessionState cannot be combined with session")
+
+	err = validateScreenshotRequest(&ScreenshotRequest{URL: "https://example.com", SessionState: &StorageState{Cookies: []Cookie{{Value: "abc"}}}})
+	assert.True(t, IsValidationError(err))
+}
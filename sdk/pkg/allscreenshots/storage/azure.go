@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// azureVersion is the Blob service REST API version requests are made with.
+const azureVersion = "2021-08-06"
+
+// AzureConfig configures an Azure Blob Storage uploader. Authenticate with
+// either AccountKey or SASToken.
+type AzureConfig struct {
+	// Account is the storage account name (required)
+	Account string
+	// Container to upload to (required)
+	Container string
+	// AccountKey is the base64 account access key, used for Shared Key authorization
+	AccountKey string
+	// SASToken is a shared access signature query string with write
+	// permission, with or without the leading "?"
+	SASToken string
+	// Endpoint of the Blob service (default https://<account>.blob.core.windows.net)
+	Endpoint string
+	// PublicURL is the base of the URLs returned by Upload; empty means the
+	// container's URL
+	PublicURL string
+	// HTTPClient sends the requests (default http.DefaultClient)
+	HTTPClient *http.Client
+}
+
+// Azure uploads block blobs to Azure Blob Storage.
+type Azure struct {
+	cfg AzureConfig
+	key []byte
+	now func() time.Time
+}
+
+// NewAzure returns an uploader for an Azure Blob Storage container.
+//
+// Example:
+//
+//	uploader, err := storage.NewAzure(storage.AzureConfig{
+//	    Account:    "mystorage",
+//	    Container:  "screenshots",
+//	    AccountKey: os.Getenv("AZURE_STORAGE_KEY"),
+//	})
+func NewAzure(cfg AzureConfig) (*Azure, error) {
+	if cfg.Account == "" || cfg.Container == "" {
+		return nil, errors.New("storage: azure account and container are required")
+	}
+	if (cfg.AccountKey == "") == (cfg.SASToken == "") {
+		return nil, errors.New("storage: azure requires exactly one of account key or SAS token")
+	}
+	a := &Azure{cfg: cfg, now: time.Now}
+	if cfg.AccountKey != "" {
+		key, err := base64.StdEncoding.DecodeString(cfg.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("storage: invalid azure account key: %w", err)
+		}
+		a.key = key
+	}
+	a.cfg.SASToken = strings.TrimPrefix(cfg.SASToken, "?")
+	if a.cfg.Endpoint == "" {
+		a.cfg.Endpoint = "https://" + cfg.Account + ".blob.core.windows.net"
+	}
+	a.cfg.Endpoint = strings.TrimSuffix(a.cfg.Endpoint, "/")
+	if a.cfg.PublicURL == "" {
+		a.cfg.PublicURL = a.cfg.Endpoint + "/" + cfg.Container
+	}
+	a.cfg.PublicURL = strings.TrimSuffix(a.cfg.PublicURL, "/")
+	return a, nil
+}
+
+// Upload stores body as a block blob under key and returns its URL.
+func (a *Azure) Upload(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error) {
+	body, size, err := sizedBody(body, size)
+	if err != nil {
+		return "", err
+	}
+	path := "/" + a.cfg.Container + "/" + escapePath(strings.TrimPrefix(key, "/"))
+	blobURL := a.cfg.Endpoint + path
+	if a.cfg.SASToken != "" {
+		blobURL += "?" + a.cfg.SASToken
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, blobURL, body)
+	if err != nil {
+		return "", fmt.Errorf("storage: failed to create request: %w", err)
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("X-Ms-Blob-Type", "BlockBlob")
+	req.Header.Set("X-Ms-Version", azureVersion)
+	req.Header.Set("X-Ms-Date", a.now().UTC().Format(http.TimeFormat))
+	if a.key != nil {
+		req.Header.Set("Authorization", "SharedKey "+a.cfg.Account+":"+a.sign(req, path, size))
+	}
+
+	resp, err := httpClient(a.cfg.HTTPClient).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("storage: azure upload failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", uploadError("azure", resp)
+	}
+	return a.cfg.PublicURL + "/" + escapePath(strings.TrimPrefix(key, "/")), nil
+}
+
+// sign returns the Shared Key signature of a Put Blob request.
+func (a *Azure) sign(req *http.Request, path string, size int64) string {
+	length := ""
+	if size > 0 {
+		length = strconv.FormatInt(size, 10)
+	}
+
+	var msHeaders []string
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-ms-") {
+			msHeaders = append(msHeaders, lower+":"+strings.TrimSpace(strings.Join(values, ",")))
+		}
+	}
+	sort.Strings(msHeaders)
+
+	stringToSign := strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		length,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date; x-ms-date is used instead
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+	}, "\n") + "\n" + strings.Join(msHeaders, "\n") + "\n/" + a.cfg.Account + path
+
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
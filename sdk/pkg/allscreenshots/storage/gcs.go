@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultGCSEndpoint is the Google Cloud Storage API endpoint.
+const DefaultGCSEndpoint = "https://storage.googleapis.com"
+
+// GCSConfig configures a Google Cloud Storage uploader.
+type GCSConfig struct {
+	// Bucket to upload to (required)
+	Bucket string
+	// Token returns an OAuth 2.0 access token with write access to the bucket
+	// (required), e.g. from golang.org/x/oauth2/google:
+	//
+	//	ts, _ := google.DefaultTokenSource(ctx, "https://www.googleapis.com/auth/devstorage.read_write")
+	//	Token: func(ctx context.Context) (string, error) {
+	//	    t, err := ts.Token()
+	//	    if err != nil {
+	//	        return "", err
+	//	    }
+	//	    return t.AccessToken, nil
+	//	}
+	Token func(ctx context.Context) (string, error)
+	// Endpoint of the API (default DefaultGCSEndpoint)
+	Endpoint string
+	// PublicURL is the base of the URLs returned by Upload; empty means
+	// https://storage.googleapis.com/<bucket>
+	PublicURL string
+	// HTTPClient sends the requests (default http.DefaultClient)
+	HTTPClient *http.Client
+}
+
+// GCS uploads objects to Google Cloud Storage.
+type GCS struct {
+	cfg GCSConfig
+}
+
+// NewGCS returns an uploader for a Google Cloud Storage bucket.
+//
+// Example:
+//
+//	uploader, err := storage.NewGCS(storage.GCSConfig{Bucket: "screenshots", Token: token})
+func NewGCS(cfg GCSConfig) (*GCS, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("storage: gcs bucket is required")
+	}
+	if cfg.Token == nil {
+		return nil, errors.New("storage: gcs token source is required")
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultGCSEndpoint
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	if cfg.PublicURL == "" {
+		cfg.PublicURL = DefaultGCSEndpoint + "/" + cfg.Bucket
+	}
+	cfg.PublicURL = strings.TrimSuffix(cfg.PublicURL, "/")
+	return &GCS{cfg: cfg}, nil
+}
+
+// Upload stores body under key and returns its URL. Bodies of unknown size
+// are streamed with chunked transfer encoding.
+func (g *GCS) Upload(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error) {
+	key = strings.TrimPrefix(key, "/")
+	token, err := g.cfg.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("storage: gcs token: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("uploadType", "media")
+	params.Set("name", key)
+	uploadURL := g.cfg.Endpoint + "/upload/storage/v1/b/" + url.PathEscape(g.cfg.Bucket) + "/o?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, body)
+	if err != nil {
+		return "", fmt.Errorf("storage: failed to create request: %w", err)
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := httpClient(g.cfg.HTTPClient).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("storage: gcs upload failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", uploadError("gcs", resp)
+	}
+	return g.cfg.PublicURL + "/" + escapePath(key), nil
+}
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Config configures an S3 uploader.
+type S3Config struct {
+	// Bucket to upload to (required)
+	Bucket string
+	// Region of the bucket, e.g. "eu-central-1" (required; "auto" for R2)
+	Region string
+	// AccessKeyID and SecretAccessKey are the credentials to sign requests with (required)
+	AccessKeyID     string
+	SecretAccessKey string
+	// SessionToken for temporary credentials, if any
+	SessionToken string
+	// Endpoint of an S3-compatible service, e.g. "https://<account>.r2.cloudflarestorage.com";
+	// empty means Amazon S3. Custom endpoints use path-style addressing
+	Endpoint string
+	// PublicURL is the base of the URLs returned by Upload, e.g. a CDN in
+	// front of the bucket; empty means the object's S3 URL
+	PublicURL string
+	// ACL is a canned ACL applied to uploaded objects, e.g. "public-read"
+	ACL string
+	// HTTPClient sends the requests (default http.DefaultClient)
+	HTTPClient *http.Client
+}
+
+// S3 uploads objects to Amazon S3 or an S3-compatible service.
+type S3 struct {
+	cfg S3Config
+	now func() time.Time
+}
+
+// NewS3 returns an uploader for an S3 bucket.
+//
+// Example:
+//
+//	uploader, err := storage.NewS3(storage.S3Config{
+//	    Bucket:          "screenshots",
+//	    Region:          "eu-central-1",
+//	    AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+//	    SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+//	})
+func NewS3(cfg S3Config) (*S3, error) {
+	if cfg.Bucket == "" || cfg.Region == "" {
+		return nil, errors.New("storage: s3 bucket and region are required")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("storage: s3 credentials are required")
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	cfg.PublicURL = strings.TrimSuffix(cfg.PublicURL, "/")
+	return &S3{cfg: cfg, now: time.Now}, nil
+}
+
+// objectURL returns the URL of the object stored under key.
+func (s *S3) objectURL(key string) string {
+	path := escapePath(strings.TrimPrefix(key, "/"))
+	if s.cfg.Endpoint != "" {
+		return s.cfg.Endpoint + "/" + s.cfg.Bucket + "/" + path
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.cfg.Bucket, s.cfg.Region, path)
+}
+
+// Upload stores body under key and returns its URL.
+func (s *S3) Upload(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error) {
+	body, size, err := sizedBody(body, size)
+	if err != nil {
+		return "", err
+	}
+	objectURL := s.objectURL(key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL, body)
+	if err != nil {
+		return "", fmt.Errorf("storage: failed to create request: %w", err)
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if s.cfg.ACL != "" {
+		req.Header.Set("X-Amz-Acl", s.cfg.ACL)
+	}
+	s.sign(req, "UNSIGNED-PAYLOAD", s.now())
+
+	resp, err := httpClient(s.cfg.HTTPClient).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("storage: s3 upload failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", uploadError("s3", resp)
+	}
+
+	if s.cfg.PublicURL != "" {
+		return s.cfg.PublicURL + "/" + escapePath(strings.TrimPrefix(key, "/")), nil
+	}
+	return objectURL, nil
+}
+
+// sign adds AWS Signature Version 4 headers to req. Every header already set
+// on req is signed, along with the host.
+func (s *S3) sign(req *http.Request, payloadHash string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if s.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.cfg.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data under key.
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
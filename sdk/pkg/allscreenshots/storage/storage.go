@@ -0,0 +1,65 @@
+// Package storage uploads capture results to object storage. It provides
+// allscreenshots.Uploader implementations for Amazon S3 (and S3-compatible
+// services such as MinIO and Cloudflare R2), Google Cloud Storage, and Azure
+// Blob Storage, using only the services' HTTP APIs.
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody is how much of an error response is included in upload errors.
+const maxErrorBody = 512
+
+// sizedBody returns body with a known length, buffering it in memory when
+// size is negative.
+func sizedBody(body io.Reader, size int64) (io.Reader, int64, error) {
+	if size >= 0 {
+		return body, size, nil
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("storage: failed to read body: %w", err)
+	}
+	return bytes.NewReader(data), int64(len(data)), nil
+}
+
+// uploadError describes a failed upload response.
+func uploadError(service string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	msg := strings.TrimSpace(string(body))
+	if msg == "" {
+		return fmt.Errorf("storage: %s upload failed: %s", service, resp.Status)
+	}
+	return fmt.Errorf("storage: %s upload failed: %s: %s", service, resp.Status, msg)
+}
+
+// escapePath escapes each segment of an object key for use in a URL path,
+// leaving the slashes between segments intact. Only unreserved characters
+// are left unescaped, which is what S3's request signing expects.
+func escapePath(key string) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// httpClient returns c, or http.DefaultClient if c is nil.
+func httpClient(c *http.Client) *http.Client {
+	if c == nil {
+		return http.DefaultClient
+	}
+	return c
+}
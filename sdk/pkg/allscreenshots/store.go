@@ -0,0 +1,63 @@
+package allscreenshots
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// Uploader stores capture results in object storage. The storage package
+// provides implementations for S3, Google Cloud Storage, and Azure Blob
+// Storage.
+type Uploader interface {
+	// Upload stores body under key and returns the object's URL. size is
+	// the body's length in bytes, or -1 if unknown.
+	Upload(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error)
+}
+
+// ScreenshotAndStore captures a screenshot synchronously and streams it
+// straight to object storage under key, without holding the whole image in
+// memory where the uploader allows. It returns the stored object's URL.
+//
+// To have the API upload results of asynchronous jobs itself, set
+// ScreenshotRequest.Storage instead.
+//
+// Example:
+//
+//	uploader, _ := storage.NewS3(storage.S3Config{Bucket: "screenshots", Region: "eu-central-1", ...})
+//	url, err := client.ScreenshotAndStore(ctx, &allscreenshots.ScreenshotRequest{
+//	    URL: "https://example.com",
+//	}, uploader, "captures/example.png")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println("stored at", url)
+func (c *Client) ScreenshotAndStore(ctx context.Context, req *ScreenshotRequest, uploader Uploader, key string) (string, error) {
+	req = c.normalizeRequestURL(req)
+	if err := validateScreenshotRequest(req); err != nil {
+		return "", err
+	}
+	if uploader == nil {
+		return "", &ValidationError{Field: "uploader", Message: "uploader is required"}
+	}
+	if key == "" {
+		return "", &ValidationError{Field: "key", Message: "key is required"}
+	}
+
+	release, err := c.acquireRender(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	var storedURL string
+	err = c.requestRaw(ctx, http.MethodPost, c.endpoint("/screenshots"), c.applyAutoTimeout(ctx, c.applyDefaults(req)), func(resp *http.Response) error {
+		var err error
+		storedURL, err = uploader.Upload(ctx, key, resp.Body, resp.ContentLength, resp.Header.Get("Content-Type"))
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return storedURL, nil
+}
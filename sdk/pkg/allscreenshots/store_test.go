@@ -0,0 +1,60 @@
+package allscreenshots
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingUploader struct {
+	key         string
+	data        []byte
+	size        int64
+	contentType string
+	err         error
+}
+
+func (u *recordingUploader) Upload(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error) {
+	if u.err != nil {
+		return "", u.err
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	u.key, u.data, u.size, u.contentType = key, data, size, contentType
+	return "https://cdn.example.com/" + key, nil
+}
+
+func TestClient_ScreenshotAndStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/screenshots", r.URL.Path)
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png-bytes"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	uploader := &recordingUploader{}
+
+	url, err := client.ScreenshotAndStore(context.Background(), &ScreenshotRequest{URL: "https://example.com"}, uploader, "shots/example.png")
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/shots/example.png", url)
+	assert.Equal(t, "shots/example.png", uploader.key)
+	assert.Equal(t, []byte("png-bytes"), uploader.data)
+	assert.Equal(t, int64(9), uploader.size)
+	assert.Equal(t, "image/png", uploader.contentType)
+
+	uploader.err = errors.New("bucket not found")
+	_, err = client.ScreenshotAndStore(context.Background(), &ScreenshotRequest{URL: "https://example.com"}, uploader, "shots/example.png")
+	assert.EqualError(t, err, "bucket not found")
+
+	_, err = client.ScreenshotAndStore(context.Background(), &ScreenshotRequest{URL: "https://example.com"}, uploader, "")
+	assert.ErrorContains(t, err, "key is required")
+}
@@ -0,0 +1,156 @@
+package allscreenshots
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TransportOptions tunes the HTTP transport for high-throughput use. Zero
+// fields keep the net/http defaults.
+type TransportOptions struct {
+	// MaxIdleConnsPerHost is the number of keep-alive connections kept open
+	// to the API (net/http default 2, which forces new connections, and
+	// TLS handshakes, when running many captures concurrently)
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is how long an idle keep-alive connection stays open
+	IdleConnTimeout time.Duration
+	// DialTimeout limits how long establishing a TCP connection may take
+	DialTimeout time.Duration
+	// ForceHTTP2 makes the transport attempt HTTP/2 despite its custom
+	// dialer; the net/http default transport the tuned one starts from
+	// already does, so false keeps that default rather than disabling it
+	ForceHTTP2 bool
+	// DNSCacheTTL caches host name lookups in process for this long; zero
+	// disables the cache
+	DNSCacheTTL time.Duration
+}
+
+// WithTransportTuning replaces the HTTP client's transport with one tuned by
+// opts. Apply it after WithHTTPClient if both are used; the client passed to
+// WithHTTPClient is copied, not modified.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(
+//	    allscreenshots.WithTransportTuning(allscreenshots.TransportOptions{
+//	        MaxIdleConnsPerHost: 100,
+//	        IdleConnTimeout:     90 * time.Second,
+//	        DialTimeout:         5 * time.Second,
+//	        ForceHTTP2:          true,
+//	        DNSCacheTTL:         time.Minute,
+//	    }),
+//	)
+func WithTransportTuning(opts TransportOptions) ClientOption {
+	return func(c *Client) {
+		httpClient := *c.httpClient
+		httpClient.Transport = newTransport(opts)
+		c.httpClient = &httpClient
+	}
+}
+
+// newTransport builds a transport from the net/http defaults and opts.
+func newTransport(opts TransportOptions) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if opts.DialTimeout > 0 {
+		dialer.Timeout = opts.DialTimeout
+	}
+	t.DialContext = dialer.DialContext
+	if opts.DNSCacheTTL > 0 {
+		cache := &dnsCache{ttl: opts.DNSCacheTTL, lookup: net.DefaultResolver.LookupHost}
+		t.DialContext = cache.dialContext(dialer)
+	}
+
+	if opts.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+		if t.MaxIdleConns < opts.MaxIdleConnsPerHost {
+			t.MaxIdleConns = opts.MaxIdleConnsPerHost
+		}
+	}
+	if opts.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	if opts.ForceHTTP2 {
+		t.ForceAttemptHTTP2 = true
+	}
+	return t
+}
+
+// dnsCache caches host name lookups for a fixed time.
+type dnsCache struct {
+	ttl    time.Duration
+	lookup func(ctx context.Context, host string) ([]string, error)
+
+	mu      sync.Mutex
+	entries map[string]dnsEntry
+}
+
+// dnsEntry is a cached lookup result.
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+// dialContext returns a dial function that resolves host names through the
+// cache, trying each cached address in turn.
+func (d *dnsCache) dialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil || net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		addrs, err := d.resolve(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		var lastErr error
+		for _, ip := range addrs {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		// Addresses that all fail may be stale; look them up afresh next time.
+		d.forget(host)
+		return nil, lastErr
+	}
+}
+
+// resolve returns the addresses of host, from the cache if still fresh.
+func (d *dnsCache) resolve(ctx context.Context, host string) ([]string, error) {
+	d.mu.Lock()
+	entry, ok := d.entries[host]
+	d.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := d.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	d.mu.Lock()
+	if d.entries == nil {
+		d.entries = make(map[string]dnsEntry)
+	}
+	d.entries[host] = dnsEntry{addrs: addrs, expires: time.Now().Add(d.ttl)}
+	d.mu.Unlock()
+	return addrs, nil
+}
+
+// forget drops host from the cache.
+func (d *dnsCache) forget(host string) {
+	d.mu.Lock()
+	delete(d.entries, host)
+	d.mu.Unlock()
+}
@@ -0,0 +1,90 @@
+package allscreenshots
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTransportTuning(t *testing.T) {
+	client := NewClient(
+		WithTimeout(10*time.Second),
+		WithTransportTuning(TransportOptions{
+			MaxIdleConnsPerHost: 200,
+			IdleConnTimeout:     2 * time.Minute,
+			ForceHTTP2:          true,
+		}),
+	)
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 200, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 200, transport.MaxIdleConns)
+	assert.Equal(t, 2*time.Minute, transport.IdleConnTimeout)
+	assert.True(t, transport.ForceAttemptHTTP2)
+	assert.Equal(t, 10*time.Second, client.httpClient.Timeout)
+	assert.NotSame(t, http.DefaultTransport, transport)
+}
+
+func TestWithTransportTuning_Defaults(t *testing.T) {
+	client := NewClient(WithTransportTuning(TransportOptions{MaxIdleConnsPerHost: 200}))
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.True(t, transport.ForceAttemptHTTP2, "HTTP/2 stays on as in net/http's default transport")
+}
+
+func TestWithTransportTuning_HTTPClient(t *testing.T) {
+	shared := &http.Client{Timeout: 7 * time.Second}
+	client := NewClient(WithHTTPClient(shared), WithTransportTuning(TransportOptions{MaxIdleConnsPerHost: 50}))
+
+	assert.Nil(t, shared.Transport, "the caller's client is not modified")
+	assert.NotSame(t, shared, client.httpClient)
+	assert.Equal(t, 7*time.Second, client.httpClient.Timeout)
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 50, transport.MaxIdleConnsPerHost)
+}
+
+func TestDNSCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	_, port, err := net.SplitHostPort(serverURL.Host)
+	require.NoError(t, err)
+
+	lookups := 0
+	cache := &dnsCache{ttl: time.Minute, lookup: func(ctx context.Context, host string) ([]string, error) {
+		lookups++
+		assert.Equal(t, "api.test", host)
+		return []string{"127.0.0.1"}, nil
+	}}
+	transport := newTransport(TransportOptions{})
+	transport.DialContext = cache.dialContext(&net.Dialer{})
+	transport.DisableKeepAlives = true
+	httpClient := &http.Client{Transport: transport}
+
+	for i := 0; i < 3; i++ {
+		resp, err := httpClient.Get("http://api.test:" + port + "/")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+	assert.Equal(t, 1, lookups)
+
+	cache.entries["api.test"] = dnsEntry{addrs: []string{"127.0.0.1"}, expires: time.Now().Add(-time.Second)}
+	resp, err := httpClient.Get("http://api.test:" + port + "/")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 2, lookups)
+}
@@ -0,0 +1,179 @@
+package allscreenshots
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultDNSTimeout bounds the host lookup of ValidateURL with ResolveDNS.
+const DefaultDNSTimeout = 5 * time.Second
+
+// URLCheckOption configures ValidateURL.
+type URLCheckOption func(*urlCheck)
+
+// urlCheck holds ValidateURL settings.
+type urlCheck struct {
+	assumeHTTPS  bool
+	resolve      bool
+	allowPrivate bool
+	lookup       func(ctx context.Context, host string) ([]string, error)
+}
+
+// AssumeHTTPS makes ValidateURL add https:// to URLs without a scheme, such
+// as "example.com/pricing".
+func AssumeHTTPS() URLCheckOption {
+	return func(c *urlCheck) {
+		c.assumeHTTPS = true
+	}
+}
+
+// ResolveDNS makes ValidateURL look up the host, rejecting hosts that do not
+// resolve and, unless AllowPrivate is given, hosts that resolve to private
+// or loopback addresses.
+func ResolveDNS() URLCheckOption {
+	return func(c *urlCheck) {
+		c.resolve = true
+	}
+}
+
+// AllowPrivate makes ValidateURL accept private, loopback, and link-local
+// addresses and single-label host names such as "intranet".
+func AllowPrivate() URLCheckOption {
+	return func(c *urlCheck) {
+		c.allowPrivate = true
+	}
+}
+
+// ValidateURL checks that raw is a URL the API can capture and returns it
+// normalized: surrounding whitespace removed, scheme and host lowercased,
+// and default ports dropped. The scheme must be http or https and the host
+// syntactically valid. Private, loopback, and link-local IP addresses and
+// names like "localhost" are rejected, since the API cannot reach them.
+// Errors are *ValidationError.
+//
+// Example:
+//
+//	for _, row := range rows {
+//	    u, err := allscreenshots.ValidateURL(row, allscreenshots.AssumeHTTPS())
+//	    if err != nil {
+//	        log.Printf("skipping %q: %v", row, err)
+//	        continue
+//	    }
+//	    urls = append(urls, allscreenshots.BulkURLRequest{URL: u})
+//	}
+func ValidateURL(raw string, opts ...URLCheckOption) (string, error) {
+	check := urlCheck{lookup: net.DefaultResolver.LookupHost}
+	for _, opt := range opts {
+		opt(&check)
+	}
+	return check.validate(raw)
+}
+
+// validate implements ValidateURL.
+func (c urlCheck) validate(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", &ValidationError{Field: "url", Message: "URL is required"}
+	}
+	if c.assumeHTTPS {
+		raw = withDefaultScheme(raw)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", &ValidationError{Field: "url", Message: "URL is malformed"}
+	}
+	if s := strings.ToLower(u.Scheme); s != "http" && s != "https" {
+		return "", &ValidationError{Field: "url", Message: "URL must start with http:// or https://"}
+	}
+	if u.User != nil {
+		return "", &ValidationError{Field: "url", Message: "URL must not contain credentials; use Auth instead"}
+	}
+	if u.Hostname() == "" {
+		return "", &ValidationError{Field: "url", Message: "URL has no host"}
+	}
+	canonicalizeHost(u, false)
+	host := u.Hostname()
+
+	if ip := net.ParseIP(host); ip != nil {
+		if !c.allowPrivate && isPrivateIP(ip) {
+			return "", &ValidationError{Field: "url", Message: "URL points to a private or loopback address"}
+		}
+	} else {
+		if !isHostname(host) {
+			return "", &ValidationError{Field: "url", Message: "URL host is not a valid host name"}
+		}
+		if !c.allowPrivate && isPrivateHostname(host) {
+			return "", &ValidationError{Field: "url", Message: "URL host is not publicly reachable"}
+		}
+		if c.resolve {
+			if err := c.resolveHost(host); err != nil {
+				return "", err
+			}
+		}
+	}
+	return u.String(), nil
+}
+
+// resolveHost looks up host and checks its addresses.
+func (c urlCheck) resolveHost(host string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultDNSTimeout)
+	defer cancel()
+
+	addrs, err := c.lookup(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return &ValidationError{Field: "url", Message: "URL host does not resolve"}
+	}
+	if c.allowPrivate {
+		return nil
+	}
+	for _, addr := range addrs {
+		if ip := net.ParseIP(addr); ip != nil && isPrivateIP(ip) {
+			return &ValidationError{Field: "url", Message: "URL host resolves to a private or loopback address"}
+		}
+	}
+	return nil
+}
+
+// isPrivateIP reports whether ip is not reachable from the public internet.
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast()
+}
+
+// isPrivateHostname reports whether host is a name that only resolves on
+// local networks.
+func isPrivateHostname(host string) bool {
+	if !strings.Contains(host, ".") {
+		return true
+	}
+	for _, suffix := range []string{".localhost", ".local", ".internal", ".lan", ".home.arpa"} {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHostname reports whether host is a syntactically valid DNS name.
+// Internationalized labels are accepted as is.
+func isHostname(host string) bool {
+	host = strings.TrimSuffix(host, ".")
+	if host == "" || len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if r != '-' && r != '_' && (r < 'a' || r > 'z') && (r < '0' || r > '9') && r < 0x80 {
+				return false
+			}
+		}
+	}
+	return true
+}
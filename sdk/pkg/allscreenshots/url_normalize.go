@@ -0,0 +1,138 @@
+package allscreenshots
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// NormalizeOptions selects how WithURLNormalization rewrites request URLs.
+type NormalizeOptions struct {
+	// AssumeHTTPS adds https:// to URLs without a scheme, such as
+	// "example.com/pricing"
+	AssumeHTTPS bool
+	// StripFragments removes the "#section" part, which the browser does
+	// not send to the page's server
+	StripFragments bool
+	// PunycodeHosts converts internationalized host names to their ASCII
+	// form, e.g. "bücher.example" to "xn--bcher-kva.example". Such hosts
+	// are also lowercased and lose the scheme's default port
+	PunycodeHosts bool
+}
+
+// WithURLNormalization rewrites the URLs of screenshot, bulk, and DOM
+// snapshot requests before they are validated, so bare input such as
+// "example.com/page#section" from a user form is captured instead of
+// failing the http(s) check. Surrounding whitespace is always trimmed. The
+// caller's request is never modified.
+//
+// Example:
+//
+//	client := allscreenshots.NewClient(
+//	    allscreenshots.WithURLNormalization(allscreenshots.NormalizeOptions{
+//	        AssumeHTTPS:    true,
+//	        StripFragments: true,
+//	        PunycodeHosts:  true,
+//	    }),
+//	)
+func WithURLNormalization(opts NormalizeOptions) ClientOption {
+	return func(c *Client) {
+		c.urlNormalization = &opts
+	}
+}
+
+// normalizeRequestURL returns req with its URL normalized, when URL
+// normalization is enabled.
+func (c *Client) normalizeRequestURL(req *ScreenshotRequest) *ScreenshotRequest {
+	if c.urlNormalization == nil || req == nil || req.URL == "" {
+		return req
+	}
+	out := *req
+	out.URL = c.urlNormalization.normalize(req.URL)
+	return &out
+}
+
+// normalizeBulkURLs returns req with the URL of each entry normalized, when
+// URL normalization is enabled.
+func (c *Client) normalizeBulkURLs(req *BulkRequest) *BulkRequest {
+	if c.urlNormalization == nil || req == nil {
+		return req
+	}
+	out := *req
+	out.URLs = make([]BulkURLRequest, len(req.URLs))
+	for i, u := range req.URLs {
+		if u.URL != "" {
+			u.URL = c.urlNormalization.normalize(u.URL)
+		}
+		out.URLs[i] = u
+	}
+	return &out
+}
+
+// normalize applies o to raw. URLs that do not parse are returned trimmed
+// but otherwise unchanged, for validation to report.
+func (o *NormalizeOptions) normalize(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if o.AssumeHTTPS {
+		raw = withDefaultScheme(raw)
+	}
+	if o.StripFragments {
+		raw, _, _ = strings.Cut(raw, "#")
+	}
+	if !o.PunycodeHosts {
+		return raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || isASCII(u.Hostname()) || !canonicalizeHost(u, true) {
+		return raw
+	}
+	return u.String()
+}
+
+// withDefaultScheme adds https:// to raw if it has no scheme.
+func withDefaultScheme(raw string) string {
+	if raw == "" || strings.Contains(raw, "://") {
+		return raw
+	}
+	return "https://" + strings.TrimPrefix(raw, "//")
+}
+
+// canonicalizeHost lowercases the scheme and host of u and drops the
+// scheme's default port. If toASCII is set, an internationalized host is
+// converted to its "xn--" form; canonicalizeHost reports false if that
+// fails.
+func canonicalizeHost(u *url.URL, toASCII bool) bool {
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if toASCII && !isASCII(host) {
+		ascii, err := idna.Lookup.ToASCII(host)
+		if err != nil {
+			return false
+		}
+		host = ascii
+	}
+	port := u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	u.Host = host
+	if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]"
+	}
+	if port != "" {
+		u.Host += ":" + port
+	}
+	return true
+}
+
+// isASCII reports whether s contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeOptions(t *testing.T) {
+	all := NormalizeOptions{AssumeHTTPS: true, StripFragments: true, PunycodeHosts: true}
+
+	tests := []struct {
+		name string
+		opts NormalizeOptions
+		raw  string
+		want string
+	}{
+		{name: "trimmed only", raw: "  example.com/page#section ", want: "example.com/page#section"},
+		{name: "bare host", opts: all, raw: "example.com/page#section", want: "https://example.com/page"},
+		{name: "scheme-relative", opts: all, raw: "//example.com/page", want: "https://example.com/page"},
+		{name: "scheme kept", opts: all, raw: "http://example.com/", want: "http://example.com/"},
+		{name: "fragment kept", opts: NormalizeOptions{AssumeHTTPS: true}, raw: "example.com/#top", want: "https://example.com/#top"},
+		{name: "punycode", opts: all, raw: "bücher.example/katalog?q=1", want: "https://xn--bcher-kva.example/katalog?q=1"},
+		{name: "punycode with port", opts: all, raw: "https://München.example:8443/", want: "https://xn--mnchen-3ya.example:8443/"},
+		{name: "punycode only non-ascii labels", opts: all, raw: "https://shop.例え.jp/", want: "https://shop.xn--r8jz45g.jp/"},
+		{name: "punycode default port", opts: all, raw: "https://bücher.example:443/", want: "https://xn--bcher-kva.example/"},
+		{name: "invalid idn unchanged", opts: all, raw: "https://bü cher.example/", want: "https://bü cher.example/"},
+		{name: "ascii host untouched", opts: all, raw: "https://Example.com/Path", want: "https://Example.com/Path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.opts.normalize(tt.raw))
+		})
+	}
+}
+
+func TestClient_WithURLNormalization(t *testing.T) {
+	var urls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/screenshots":
+			var body ScreenshotRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			urls = append(urls, body.URL)
+			w.Write([]byte{0x89, 0x50, 0x4E, 0x47})
+		case "/v1/screenshots/bulk":
+			var body BulkRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			for _, u := range body.URLs {
+				urls = append(urls, u.URL)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"bulk-1","status":"QUEUED"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIKey("test-api-key"),
+		WithBaseURL(server.URL),
+		WithURLNormalization(NormalizeOptions{AssumeHTTPS: true, StripFragments: true}),
+	)
+
+	req := &ScreenshotRequest{URL: "example.com/page#section"}
+	_, err := client.Screenshot(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/page#section", req.URL)
+
+	bulk := &BulkRequest{URLs: []BulkURLRequest{{URL: "example.com/a"}, {URL: "https://example.com/b#top"}}}
+	_, err = client.CreateBulkJob(context.Background(), bulk)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/a", bulk.URLs[0].URL)
+
+	assert.Equal(t, []string{"https://example.com/page", "https://example.com/a", "https://example.com/b"}, urls)
+
+	_, err = NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL)).Screenshot(context.Background(), &ScreenshotRequest{URL: "example.com"})
+	assert.True(t, IsValidationError(err))
+}
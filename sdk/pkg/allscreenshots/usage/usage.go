@@ -0,0 +1,148 @@
+// Package usage provides helpers for keeping an eye on account usage, such
+// as alerting when the quota runs low.
+package usage
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+)
+
+// DefaultWatchInterval is how often WatchQuota polls by default.
+const DefaultWatchInterval = 5 * time.Minute
+
+// DefaultThresholds are the usage percentages WatchQuota alerts on by default.
+var DefaultThresholds = []int{80, 95}
+
+// WatchOptions configures WatchQuota.
+type WatchOptions struct {
+	// Interval between quota checks (default 5 minutes)
+	Interval time.Duration
+	// Thresholds are usage percentages (1-100) to alert on (default 80 and 95)
+	Thresholds []int
+	// OnThreshold is called when usage crosses a threshold (required)
+	OnThreshold func(status allscreenshots.QuotaStatusResponse, threshold int)
+	// OnError is called when a quota check fails; polling continues afterwards
+	OnError func(err error)
+}
+
+// WatchQuota starts a goroutine that polls GetQuotaStatus and calls
+// OnThreshold when usage crosses one of the thresholds. Usage is the higher
+// of the screenshot and bandwidth percentages.
+//
+// Each threshold fires once. When a single check crosses several thresholds
+// at once, only the highest is reported. When usage falls back below a
+// threshold, as it does when a new billing period starts, that threshold is
+// armed again. The watcher stops when ctx is cancelled.
+//
+// Example:
+//
+//	err := usage.WatchQuota(ctx, client, usage.WatchOptions{
+//	    Interval:   10 * time.Minute,
+//	    Thresholds: []int{80, 95},
+//	    OnThreshold: func(status allscreenshots.QuotaStatusResponse, threshold int) {
+//	        postToSlack(fmt.Sprintf("Screenshot quota %d%% used (%s tier)", threshold, status.Tier))
+//	    },
+//	})
+func WatchQuota(ctx context.Context, client *allscreenshots.Client, opts WatchOptions) error {
+	if client == nil {
+		return errors.New("usage: client is required")
+	}
+	if opts.OnThreshold == nil {
+		return errors.New("usage: OnThreshold is required")
+	}
+	if opts.Interval < 0 {
+		return errors.New("usage: interval must not be negative")
+	}
+	if opts.Interval == 0 {
+		opts.Interval = DefaultWatchInterval
+	}
+	thresholds := append([]int(nil), opts.Thresholds...)
+	if len(thresholds) == 0 {
+		thresholds = append(thresholds, DefaultThresholds...)
+	}
+	for _, t := range thresholds {
+		if t < 1 || t > 100 {
+			return errors.New("usage: thresholds must be between 1 and 100")
+		}
+	}
+	sort.Ints(thresholds)
+
+	w := &quotaWatcher{client: client, opts: opts, thresholds: thresholds, fired: map[int]bool{}}
+	go w.run(ctx)
+	return nil
+}
+
+// quotaWatcher holds the state of a WatchQuota goroutine.
+type quotaWatcher struct {
+	client     *allscreenshots.Client
+	opts       WatchOptions
+	thresholds []int
+	fired      map[int]bool
+}
+
+// run checks the quota immediately and then on every tick until ctx is done.
+func (w *quotaWatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if !w.check(ctx) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check polls the quota once and fires any newly crossed threshold. It
+// reports false once the client is closed, since no later check can succeed.
+func (w *quotaWatcher) check(ctx context.Context) bool {
+	status, err := w.client.GetQuotaStatus(ctx)
+	if errors.Is(err, allscreenshots.ErrClientClosed) {
+		return false
+	}
+	if err != nil {
+		if ctx.Err() == nil && w.opts.OnError != nil {
+			w.opts.OnError(err)
+		}
+		return true
+	}
+
+	used := PercentUsed(status)
+	crossed := 0
+	for _, t := range w.thresholds {
+		if used < t {
+			// Re-arm thresholds that usage has fallen below.
+			delete(w.fired, t)
+			continue
+		}
+		if !w.fired[t] {
+			crossed = t
+		}
+		w.fired[t] = true
+	}
+	if crossed > 0 {
+		w.opts.OnThreshold(*status, crossed)
+	}
+	return true
+}
+
+// PercentUsed returns the higher of the screenshot and bandwidth usage
+// percentages in status.
+func PercentUsed(status *allscreenshots.QuotaStatusResponse) int {
+	used := 0
+	if status.Screenshots != nil {
+		used = status.Screenshots.PercentUsed
+	}
+	if status.Bandwidth != nil && status.Bandwidth.PercentUsed > used {
+		used = status.Bandwidth.PercentUsed
+	}
+	return used
+}
@@ -0,0 +1,109 @@
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchQuota(t *testing.T) {
+	// Usage climbs past both thresholds, drops after a period reset, and
+	// climbs again.
+	percents := []int{50, 82, 85, 97, 99, 10, 90}
+
+	var mu sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		i := calls
+		if i >= len(percents) {
+			i = len(percents) - 1
+		}
+		calls++
+		mu.Unlock()
+
+		assert.Equal(t, "/v1/usage/quota", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(allscreenshots.QuotaStatusResponse{
+			Tier:        "pro",
+			Screenshots: &allscreenshots.QuotaDetailResponse{PercentUsed: percents[i]},
+			Bandwidth:   &allscreenshots.BandwidthQuotaResponse{PercentUsed: 5},
+		})
+	}))
+	defer server.Close()
+
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(server.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fired := make(chan int, 10)
+	err := WatchQuota(ctx, client, WatchOptions{
+		Interval: 5 * time.Millisecond,
+		OnThreshold: func(status allscreenshots.QuotaStatusResponse, threshold int) {
+			assert.Equal(t, "pro", status.Tier)
+			fired <- threshold
+		},
+	})
+	require.NoError(t, err)
+
+	var got []int
+	for len(got) < 3 {
+		select {
+		case threshold := <-fired:
+			got = append(got, threshold)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out; thresholds fired so far: %v", got)
+		}
+	}
+	assert.Equal(t, []int{80, 95, 80}, got)
+}
+
+func TestWatchQuota_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"), allscreenshots.WithBaseURL(server.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 10)
+	require.NoError(t, WatchQuota(ctx, client, WatchOptions{
+		Interval:    time.Hour,
+		OnThreshold: func(allscreenshots.QuotaStatusResponse, int) {},
+		OnError:     func(err error) { errs <- err },
+	}))
+
+	select {
+	case err := <-errs:
+		assert.True(t, allscreenshots.IsUnauthorized(err))
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnError was not called")
+	}
+}
+
+func TestWatchQuota_InvalidOptions(t *testing.T) {
+	client := allscreenshots.NewClient(allscreenshots.WithAPIKey("test-api-key"))
+	noop := func(allscreenshots.QuotaStatusResponse, int) {}
+
+	assert.ErrorContains(t, WatchQuota(context.Background(), client, WatchOptions{}), "OnThreshold is required")
+	assert.ErrorContains(t, WatchQuota(context.Background(), nil, WatchOptions{OnThreshold: noop}), "client is required")
+	assert.ErrorContains(t, WatchQuota(context.Background(), client, WatchOptions{OnThreshold: noop, Thresholds: []int{0}}), "between 1 and 100")
+}
+
+func TestPercentUsed(t *testing.T) {
+	assert.Equal(t, 0, PercentUsed(&allscreenshots.QuotaStatusResponse{}))
+	assert.Equal(t, 70, PercentUsed(&allscreenshots.QuotaStatusResponse{
+		Screenshots: &allscreenshots.QuotaDetailResponse{PercentUsed: 40},
+		Bandwidth:   &allscreenshots.BandwidthQuotaResponse{PercentUsed: 70},
+	}))
+}
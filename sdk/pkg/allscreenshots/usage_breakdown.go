@@ -0,0 +1,78 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Usage periods for GetUsageBreakdown. A specific month can be given as
+// "YYYY-MM" instead, e.g. "2026-09".
+const (
+	UsagePeriodCurrent  = "current"
+	UsagePeriodPrevious = "previous"
+)
+
+// OperationUsage counts the screenshots of a period by how they were
+// requested.
+type OperationUsage struct {
+	// Sync is the number of synchronous screenshots
+	Sync int64 `json:"sync"`
+	// Async is the number of asynchronous screenshot jobs
+	Async int64 `json:"async"`
+	// Bulk is the number of screenshots taken by bulk jobs
+	Bulk int64 `json:"bulk"`
+	// Compose is the number of screenshots taken by compose jobs
+	Compose int64 `json:"compose"`
+	// Scheduled is the number of screenshots taken by schedules
+	Scheduled int64 `json:"scheduled"`
+}
+
+// UsageBreakdownResponse represents the usage of a period split by
+// operation and output format, as shown on the billing page.
+type UsageBreakdownResponse struct {
+	PeriodStart string `json:"periodStart"`
+	PeriodEnd   string `json:"periodEnd"`
+	// ScreenshotsCount is the total number of screenshots in the period
+	ScreenshotsCount int64 `json:"screenshotsCount"`
+	// ByOperation splits ScreenshotsCount by how the screenshots were requested
+	ByOperation OperationUsage `json:"byOperation"`
+	// ByFormat maps output formats, such as "png" or "pdf", to their count
+	ByFormat map[string]int64 `json:"byFormat,omitempty"`
+}
+
+// GetUsageBreakdown returns the screenshot counts of a billing period split
+// by operation (sync, async, bulk, compose, scheduled) and by output format.
+// period is UsagePeriodCurrent, UsagePeriodPrevious, or a month as
+// "YYYY-MM"; an empty period means the current one.
+//
+// Example:
+//
+//	breakdown, err := client.GetUsageBreakdown(ctx, allscreenshots.UsagePeriodCurrent)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("bulk: %d, scheduled: %d, pdf: %d\n",
+//	    breakdown.ByOperation.Bulk, breakdown.ByOperation.Scheduled, breakdown.ByFormat["pdf"])
+func (c *Client) GetUsageBreakdown(ctx context.Context, period string) (*UsageBreakdownResponse, error) {
+	switch period {
+	case "", UsagePeriodCurrent, UsagePeriodPrevious:
+	default:
+		if _, err := time.Parse("2006-01", period); err != nil {
+			return nil, &ValidationError{Field: "period", Message: "period must be current, previous, or a month as YYYY-MM"}
+		}
+	}
+
+	path := c.endpoint("/usage/breakdown")
+	if period != "" {
+		path += "?period=" + url.QueryEscape(period)
+	}
+
+	var result UsageBreakdownResponse
+	err := c.request(ctx, http.MethodGet, path, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,48 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetUsageBreakdown(t *testing.T) {
+	var periods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v1/usage/breakdown", r.URL.Path)
+		periods = append(periods, r.URL.Query().Get("period"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"periodStart": "2026-09-01",
+			"periodEnd": "2026-09-30",
+			"screenshotsCount": 1500,
+			"byOperation": {"sync": 700, "async": 300, "bulk": 350, "compose": 50, "scheduled": 100},
+			"byFormat": {"png": 1200, "webp": 250, "pdf": 50}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	breakdown, err := client.GetUsageBreakdown(context.Background(), "2026-09")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-09-01", breakdown.PeriodStart)
+	assert.Equal(t, int64(1500), breakdown.ScreenshotsCount)
+	assert.Equal(t, OperationUsage{Sync: 700, Async: 300, Bulk: 350, Compose: 50, Scheduled: 100}, breakdown.ByOperation)
+	assert.Equal(t, int64(50), breakdown.ByFormat["pdf"])
+
+	_, err = client.GetUsageBreakdown(context.Background(), "")
+	require.NoError(t, err)
+	_, err = client.GetUsageBreakdown(context.Background(), UsagePeriodPrevious)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2026-09", "", "previous"}, periods)
+
+	_, err = client.GetUsageBreakdown(context.Background(), "last-month")
+	assert.True(t, IsValidationError(err))
+}
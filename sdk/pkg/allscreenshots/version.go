@@ -0,0 +1,97 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of this SDK. It is sent in the User-Agent header
+// and included in API errors. Builds from a fork or an unreleased commit can
+// set it at link time:
+//
+//	go build -ldflags "-X github.com/allscreenshots/allscreenshots-sdk-go/pkg/allscreenshots.Version=1.2.3-acme"
+var Version = "1.0.0"
+
+// sdkName is the SDK's product token in the User-Agent header.
+const sdkName = "allscreenshots-sdk-go"
+
+// defaultUserAgent returns the User-Agent sent unless WithUserAgent is given.
+func defaultUserAgent() string {
+	return sdkName + "/" + Version
+}
+
+// SDKVersionInfo describes the running SDK version relative to the latest
+// release.
+type SDKVersionInfo struct {
+	// Current is the running SDK's Version
+	Current string `json:"current"`
+	// Latest is the most recent release
+	Latest string `json:"latest"`
+	// MinimumSupported is the oldest release the API still supports, if any
+	MinimumSupported string `json:"minimumSupported,omitempty"`
+	// ReleaseURL links to the release notes of Latest
+	ReleaseURL string `json:"releaseUrl,omitempty"`
+}
+
+// UpdateAvailable reports whether a newer release than Current exists.
+func (v *SDKVersionInfo) UpdateAvailable() bool {
+	return compareVersions(v.Current, v.Latest) < 0
+}
+
+// Supported reports whether Current is at least MinimumSupported.
+func (v *SDKVersionInfo) Supported() bool {
+	return v.MinimumSupported == "" || compareVersions(v.Current, v.MinimumSupported) >= 0
+}
+
+// CheckLatestVersion asks the API for the latest release of this SDK, so
+// long-running services can log when they fall behind. It is never called
+// automatically.
+//
+// Example:
+//
+//	info, err := client.CheckLatestVersion(ctx)
+//	if err == nil && info.UpdateAvailable() {
+//	    log.Printf("allscreenshots SDK %s is available (running %s): %s", info.Latest, info.Current, info.ReleaseURL)
+//	}
+func (c *Client) CheckLatestVersion(ctx context.Context) (*SDKVersionInfo, error) {
+	var result SDKVersionInfo
+	path := c.endpoint("/sdk/versions/go") + "?current=" + url.QueryEscape(Version)
+	err := c.request(ctx, http.MethodGet, path, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	result.Current = Version
+	return &result, nil
+}
+
+// compareVersions compares two semantic versions by their major, minor, and
+// patch numbers, returning -1, 0, or 1. A leading "v" and any pre-release
+// or build suffix are ignored, as are parts that are not numbers.
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := range pa {
+		switch {
+		case pa[i] < pb[i]:
+			return -1
+		case pa[i] > pb[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionParts returns the major, minor, and patch numbers of v.
+func versionParts(v string) [3]int {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	var parts [3]int
+	for i, s := range strings.SplitN(v, ".", 3) {
+		parts[i], _ = strconv.Atoi(s)
+	}
+	return parts
+}
@@ -0,0 +1,73 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CheckLatestVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/sdk/versions/go", r.URL.Path)
+		assert.Equal(t, Version, r.URL.Query().Get("current"))
+		assert.Equal(t, "allscreenshots-sdk-go/"+Version, r.Header.Get("User-Agent"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"latest":"1.4.0","minimumSupported":"1.0.0","releaseUrl":"https://example.com/releases/v1.4.0"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	info, err := client.CheckLatestVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Version, info.Current)
+	assert.Equal(t, "1.4.0", info.Latest)
+	assert.Equal(t, "https://example.com/releases/v1.4.0", info.ReleaseURL)
+
+	info.Current = "1.3.9"
+	assert.True(t, info.UpdateAvailable())
+	assert.True(t, info.Supported())
+	info.Current = "0.9.2"
+	assert.False(t, info.Supported())
+	info.Current = "v1.4.0"
+	assert.False(t, info.UpdateAvailable())
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "1.0.1", -1},
+		{"1.10.0", "1.9.3", 1},
+		{"v2.0.0", "1.99.99", 1},
+		{"1.2", "1.2.0", 0},
+		{"1.2.3-rc.1", "1.2.3", 0},
+		{"1.2.3+acme", "1.2.4", -1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, compareVersions(tt.a, tt.b), "%s vs %s", tt.a, tt.b)
+	}
+}
+
+func TestAPIError_SDKVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":"INVALID_URL","message":"bad url"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	_, err := client.GetQuotaStatus(context.Background())
+
+	apiErr, ok := AsAPIError(err)
+	require.True(t, ok)
+	assert.Equal(t, Version, apiErr.SDKVersion)
+	assert.EqualError(t, err, "allscreenshots: API error 400 (INVALID_URL): bad url (sdk-go "+Version+")")
+}
@@ -0,0 +1,133 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Video formats supported by CaptureVideo.
+const (
+	VideoFormatMP4  = "mp4"
+	VideoFormatGIF  = "gif"
+	VideoFormatWebP = "webp"
+)
+
+// VideoRequest represents a request to record a scrolling video of a page.
+type VideoRequest struct {
+	// URL is the target URL to record (required, must start with http:// or https://)
+	URL string `json:"url"`
+	// Viewport configuration for custom dimensions
+	Viewport *ViewportConfig `json:"viewport,omitempty"`
+	// Device preset name (e.g., "Desktop HD", "iPhone 14", "iPad")
+	Device string `json:"device,omitempty"`
+	// Format of the output: mp4, gif, or webp (default mp4)
+	Format string `json:"format,omitempty"`
+	// DurationMs is the length of the recording in milliseconds (1000-60000)
+	DurationMs int `json:"durationMs,omitempty"`
+	// ScrollSpeed is the scroll speed in pixels per second (50-5000); 0 records without scrolling
+	ScrollSpeed int `json:"scrollSpeed,omitempty"`
+	// FPS is the frame rate (1-60, at most 30 for gif)
+	FPS int `json:"fps,omitempty"`
+	// Delay in milliseconds before recording starts (0-30000)
+	Delay int `json:"delay,omitempty"`
+	// DarkMode enables dark mode for the recording
+	DarkMode bool `json:"darkMode,omitempty"`
+	// CustomCSS to inject into the page (max 10000 chars)
+	CustomCSS string `json:"customCss,omitempty"`
+	// BlockAds enables ad blocking
+	BlockAds bool `json:"blockAds,omitempty"`
+	// BlockCookieBanners enables cookie banner blocking
+	BlockCookieBanners bool `json:"blockCookieBanners,omitempty"`
+	// WebhookURL for async notification
+	WebhookURL string `json:"webhookUrl,omitempty"`
+	// WebhookSecret for webhook authentication (max 255 chars)
+	WebhookSecret string `json:"webhookSecret,omitempty"`
+}
+
+// CaptureVideo records a scrolling video of a page synchronously and returns
+// the encoded video bytes.
+//
+// Example:
+//
+//	video, err := client.CaptureVideo(ctx, &allscreenshots.VideoRequest{
+//	    URL:         "https://github.com",
+//	    DurationMs:  8000,
+//	    ScrollSpeed: 400,
+//	    Format:      allscreenshots.VideoFormatMP4,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	os.WriteFile("preview.mp4", video, 0644)
+func (c *Client) CaptureVideo(ctx context.Context, req *VideoRequest) ([]byte, error) {
+	if err := validateVideoRequest(req); err != nil {
+		return nil, err
+	}
+
+	return c.requestBinary(ctx, http.MethodPost, c.endpoint("/animations"), req)
+}
+
+// CaptureVideoAsync starts an asynchronous video recording. Poll the job with
+// GetJob and download the video with GetJobResult.
+//
+// Example:
+//
+//	job, err := client.CaptureVideoAsync(ctx, &allscreenshots.VideoRequest{
+//	    URL:    "https://github.com",
+//	    Format: allscreenshots.VideoFormatGIF,
+//	})
+func (c *Client) CaptureVideoAsync(ctx context.Context, req *VideoRequest) (*AsyncJobCreatedResponse, error) {
+	if err := validateVideoRequest(req); err != nil {
+		return nil, err
+	}
+
+	var result AsyncJobCreatedResponse
+	err := c.request(ctx, http.MethodPost, c.endpoint("/animations/async"), req, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// validateVideoRequest validates a video request.
+func validateVideoRequest(req *VideoRequest) error {
+	if req == nil {
+		return &ValidationError{Field: "request", Message: "request cannot be nil"}
+	}
+	if req.URL == "" {
+		return &ValidationError{Field: "url", Message: "URL is required"}
+	}
+	if !strings.HasPrefix(req.URL, "http://") && !strings.HasPrefix(req.URL, "https://") {
+		return &ValidationError{Field: "url", Message: "URL must start with http:// or https://"}
+	}
+	switch req.Format {
+	case "", VideoFormatMP4, VideoFormatGIF, VideoFormatWebP:
+	default:
+		return &ValidationError{Field: "format", Message: "format must be mp4, gif, or webp"}
+	}
+	if req.DurationMs != 0 && (req.DurationMs < 1000 || req.DurationMs > 60000) {
+		return &ValidationError{Field: "durationMs", Message: "durationMs must be between 1000 and 60000"}
+	}
+	if req.ScrollSpeed != 0 && (req.ScrollSpeed < 50 || req.ScrollSpeed > 5000) {
+		return &ValidationError{Field: "scrollSpeed", Message: "scrollSpeed must be between 50 and 5000"}
+	}
+	if req.FPS != 0 && (req.FPS < 1 || req.FPS > 60) {
+		return &ValidationError{Field: "fps", Message: "fps must be between 1 and 60"}
+	}
+	if req.Format == VideoFormatGIF && req.FPS > 30 {
+		return &ValidationError{Field: "fps", Message: "fps must be at most 30 for gif"}
+	}
+	if req.Delay != 0 && (req.Delay < 0 || req.Delay > 30000) {
+		return &ValidationError{Field: "delay", Message: "delay must be between 0 and 30000"}
+	}
+	if len(req.CustomCSS) > maxCustomCodeLength {
+		return &ValidationError{Field: "customCss", Message: "customCss must be at most 10000 characters"}
+	}
+	if req.Viewport != nil {
+		if err := validateViewport(req.Viewport); err != nil {
+			return err
+		}
+	}
+	return nil
+}
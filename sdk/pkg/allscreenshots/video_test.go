@@ -0,0 +1,109 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideoRequest_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *VideoRequest
+		wantErr string
+	}{
+		{
+			name:    "nil request",
+			req:     nil,
+			wantErr: "request cannot be nil",
+		},
+		{
+			name:    "empty URL",
+			req:     &VideoRequest{},
+			wantErr: "URL is required",
+		},
+		{
+			name:    "valid request",
+			req:     &VideoRequest{URL: "https://example.com", DurationMs: 8000, ScrollSpeed: 400, Format: VideoFormatMP4},
+			wantErr: "",
+		},
+		{
+			name:    "unknown format",
+			req:     &VideoRequest{URL: "https://example.com", Format: "avi"},
+			wantErr: "format must be mp4, gif, or webp",
+		},
+		{
+			name:    "duration too long",
+			req:     &VideoRequest{URL: "https://example.com", DurationMs: 120000},
+			wantErr: "durationMs must be between 1000 and 60000",
+		},
+		{
+			name:    "scroll speed too low",
+			req:     &VideoRequest{URL: "https://example.com", ScrollSpeed: 10},
+			wantErr: "scrollSpeed must be between 50 and 5000",
+		},
+		{
+			name:    "gif frame rate too high",
+			req:     &VideoRequest{URL: "https://example.com", Format: VideoFormatGIF, FPS: 60},
+			wantErr: "fps must be at most 30 for gif",
+		},
+		{
+			name:    "invalid viewport",
+			req:     &VideoRequest{URL: "https://example.com", Viewport: &ViewportConfig{Width: 5000}},
+			wantErr: "width must be between 100 and 4096",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVideoRequest(tt.req)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_CaptureVideo(t *testing.T) {
+	videoData := []byte{0x00, 0x00, 0x00, 0x18, 'f', 't', 'y', 'p'}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/animations":
+			var req VideoRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, 8000, req.DurationMs)
+			assert.Equal(t, VideoFormatMP4, req.Format)
+			w.Header().Set("Content-Type", "video/mp4")
+			w.Write(videoData)
+		case "/v1/animations/async":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(AsyncJobCreatedResponse{ID: "job-video", Status: JobStatusQueued})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	data, err := client.CaptureVideo(context.Background(), &VideoRequest{
+		URL:        "https://example.com",
+		DurationMs: 8000,
+		Format:     VideoFormatMP4,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, videoData, data)
+
+	job, err := client.CaptureVideoAsync(context.Background(), &VideoRequest{URL: "https://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "job-video", job.ID)
+}
@@ -0,0 +1,218 @@
+// Package visdiff compares two screenshots pixel by pixel for visual
+// regression testing.
+//
+// Images may be PNG, JPEG, or GIF. Compare reports how many pixels changed,
+// where they changed, and renders a diff image that highlights the changes
+// in red over a faded copy of the first image.
+package visdiff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"  // register GIF decoder
+	_ "image/jpeg" // register JPEG decoder
+	"image/png"
+)
+
+// Options configures a comparison.
+type Options struct {
+	// Threshold is the per-pixel color difference (0-1) tolerated before a
+	// pixel counts as changed; 0 treats any difference as a change
+	Threshold float64
+	// IgnoreRegions are areas excluded from the comparison, such as
+	// timestamps, carousels, or ads
+	IgnoreRegions []image.Rectangle
+}
+
+// DiffResult is the outcome of a comparison.
+type DiffResult struct {
+	// Width of the compared area (the larger of the two images)
+	Width int
+	// Height of the compared area (the larger of the two images)
+	Height int
+	// ChangedPixels is the number of pixels that differ
+	ChangedPixels int
+	// ChangedPercent is ChangedPixels as a percentage of the compared pixels
+	ChangedPercent float64
+	// Regions are the bounding boxes of connected areas of change
+	Regions []image.Rectangle
+	// Image is a PNG highlighting the changed pixels in red
+	Image []byte
+}
+
+// Identical reports whether no pixels changed.
+func (r *DiffResult) Identical() bool {
+	return r.ChangedPixels == 0
+}
+
+var (
+	diffColor   = color.RGBA{R: 255, A: 255}
+	ignoreColor = color.RGBA{R: 128, G: 128, B: 128, A: 255}
+)
+
+// Compare decodes a and b and compares them pixel by pixel. When the images
+// differ in size, the pixels covered by only one of them count as changed.
+//
+// Example:
+//
+//	diff, err := visdiff.Compare(baseline, current, visdiff.Options{Threshold: 0.1})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if diff.ChangedPercent > 0.5 {
+//	    os.WriteFile("diff.png", diff.Image, 0644)
+//	}
+func Compare(a, b []byte, opts Options) (*DiffResult, error) {
+	imgA, err := decode(a, "first")
+	if err != nil {
+		return nil, err
+	}
+	imgB, err := decode(b, "second")
+	if err != nil {
+		return nil, err
+	}
+	return CompareImages(imgA, imgB, opts)
+}
+
+// CompareImages compares two decoded images. See Compare.
+func CompareImages(a, b image.Image, opts Options) (*DiffResult, error) {
+	if opts.Threshold < 0 || opts.Threshold > 1 {
+		return nil, errors.New("visdiff: threshold must be between 0 and 1")
+	}
+
+	ba, bb := a.Bounds(), b.Bounds()
+	width := max(ba.Dx(), bb.Dx())
+	height := max(ba.Dy(), bb.Dy())
+	full := image.Rect(0, 0, width, height)
+
+	out := image.NewRGBA(full)
+	changed := make([]bool, width*height)
+	result := &DiffResult{Width: width, Height: height}
+	compared := 0
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if ignored(x, y, opts.IgnoreRegions) {
+				out.SetRGBA(x, y, ignoreColor)
+				continue
+			}
+			compared++
+
+			inA := x < ba.Dx() && y < ba.Dy()
+			inB := x < bb.Dx() && y < bb.Dy()
+			if !inA || !inB {
+				changed[y*width+x] = true
+				result.ChangedPixels++
+				out.SetRGBA(x, y, diffColor)
+				continue
+			}
+
+			ca := a.At(ba.Min.X+x, ba.Min.Y+y)
+			cb := b.At(bb.Min.X+x, bb.Min.Y+y)
+			if distance(ca, cb) > opts.Threshold {
+				changed[y*width+x] = true
+				result.ChangedPixels++
+				out.SetRGBA(x, y, diffColor)
+				continue
+			}
+			out.SetRGBA(x, y, faded(ca))
+		}
+	}
+
+	if compared > 0 {
+		result.ChangedPercent = float64(result.ChangedPixels) / float64(compared) * 100
+	}
+	result.Regions = regions(changed, width, height)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, out); err != nil {
+		return nil, fmt.Errorf("visdiff: failed to encode diff image: %w", err)
+	}
+	result.Image = buf.Bytes()
+	return result, nil
+}
+
+// decode decodes an image, naming it in errors.
+func decode(data []byte, which string) (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("visdiff: failed to decode %s image: %w", which, err)
+	}
+	return img, nil
+}
+
+// ignored reports whether (x, y) falls inside any ignore region.
+func ignored(x, y int, regions []image.Rectangle) bool {
+	p := image.Pt(x, y)
+	for _, r := range regions {
+		if p.In(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// distance returns the largest per-channel difference between two colors,
+// scaled to 0-1.
+func distance(a, b color.Color) float64 {
+	r1, g1, b1, a1 := a.RGBA()
+	r2, g2, b2, a2 := b.RGBA()
+	d := max(absDiff(r1, r2), absDiff(g1, g2), absDiff(b1, b2), absDiff(a1, a2))
+	return float64(d) / 0xffff
+}
+
+func absDiff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// faded returns a light grayscale version of c for unchanged pixels.
+func faded(c color.Color) color.RGBA {
+	gray := color.GrayModel.Convert(c).(color.Gray).Y
+	v := 255 - (255-gray)/4
+	return color.RGBA{R: v, G: v, B: v, A: 255}
+}
+
+// regions returns the bounding boxes of 8-connected areas of changed pixels.
+func regions(changed []bool, width, height int) []image.Rectangle {
+	var boxes []image.Rectangle
+	seen := make([]bool, len(changed))
+	var stack []int
+
+	for start, isChanged := range changed {
+		if !isChanged || seen[start] {
+			continue
+		}
+		box := image.Rect(start%width, start/width, start%width+1, start/width+1)
+		seen[start] = true
+		stack = append(stack[:0], start)
+
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			x, y := i%width, i/width
+			box = box.Union(image.Rect(x, y, x+1, y+1))
+
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					nx, ny := x+dx, y+dy
+					if nx < 0 || ny < 0 || nx >= width || ny >= height {
+						continue
+					}
+					j := ny*width + nx
+					if changed[j] && !seen[j] {
+						seen[j] = true
+						stack = append(stack, j)
+					}
+				}
+			}
+		}
+		boxes = append(boxes, box)
+	}
+	return boxes
+}
@@ -0,0 +1,105 @@
+package visdiff
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func solid(w, h int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func encode(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestCompare_Identical(t *testing.T) {
+	img := encode(t, solid(10, 10, color.White))
+
+	diff, err := Compare(img, img, Options{})
+	require.NoError(t, err)
+	assert.True(t, diff.Identical())
+	assert.Zero(t, diff.ChangedPercent)
+	assert.Empty(t, diff.Regions)
+
+	decoded, err := png.Decode(bytes.NewReader(diff.Image))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 10, 10), decoded.Bounds())
+}
+
+func TestCompare_Regions(t *testing.T) {
+	a := solid(20, 10, color.White)
+	b := solid(20, 10, color.White)
+	for y := 1; y < 3; y++ {
+		for x := 1; x < 4; x++ {
+			b.Set(x, y, color.Black)
+		}
+	}
+	b.Set(15, 8, color.Black)
+
+	diff, err := Compare(encode(t, a), encode(t, b), Options{})
+	require.NoError(t, err)
+	assert.Equal(t, 7, diff.ChangedPixels)
+	assert.InDelta(t, 3.5, diff.ChangedPercent, 0.001)
+	assert.Equal(t, []image.Rectangle{
+		image.Rect(1, 1, 4, 3),
+		image.Rect(15, 8, 16, 9),
+	}, diff.Regions)
+}
+
+func TestCompare_Threshold(t *testing.T) {
+	a := solid(4, 4, color.RGBA{R: 100, G: 100, B: 100, A: 255})
+	b := solid(4, 4, color.RGBA{R: 110, G: 100, B: 100, A: 255})
+
+	diff, err := Compare(encode(t, a), encode(t, b), Options{Threshold: 0.1})
+	require.NoError(t, err)
+	assert.True(t, diff.Identical())
+
+	diff, err = Compare(encode(t, a), encode(t, b), Options{Threshold: 0.01})
+	require.NoError(t, err)
+	assert.Equal(t, 16, diff.ChangedPixels)
+}
+
+func TestCompare_IgnoreRegions(t *testing.T) {
+	a := solid(10, 10, color.White)
+	b := solid(10, 10, color.White)
+	b.Set(2, 2, color.Black)
+
+	diff, err := Compare(encode(t, a), encode(t, b), Options{
+		IgnoreRegions: []image.Rectangle{image.Rect(0, 0, 5, 5)},
+	})
+	require.NoError(t, err)
+	assert.True(t, diff.Identical())
+}
+
+func TestCompare_SizeMismatch(t *testing.T) {
+	diff, err := Compare(encode(t, solid(10, 10, color.White)), encode(t, solid(10, 12, color.White)), Options{})
+	require.NoError(t, err)
+	assert.Equal(t, 12, diff.Height)
+	assert.Equal(t, 20, diff.ChangedPixels)
+	assert.Equal(t, []image.Rectangle{image.Rect(0, 10, 10, 12)}, diff.Regions)
+}
+
+func TestCompare_Errors(t *testing.T) {
+	img := encode(t, solid(2, 2, color.White))
+
+	_, err := Compare([]byte("not an image"), img, Options{})
+	assert.ErrorContains(t, err, "failed to decode first image")
+
+	_, err = Compare(img, img, Options{Threshold: 2})
+	assert.ErrorContains(t, err, "threshold must be between 0 and 1")
+}
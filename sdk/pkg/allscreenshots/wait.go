@@ -0,0 +1,293 @@
+package allscreenshots
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultPollInterval is the initial interval between job status checks.
+	DefaultPollInterval = 1 * time.Second
+	// DefaultMaxPollInterval is the longest interval between job status checks.
+	DefaultMaxPollInterval = 10 * time.Second
+)
+
+// PollOption configures how WaitForJob, WaitForComposeJob,
+// ScreenshotAsyncAndWait, and IterateBulkResults poll.
+type PollOption func(*pollConfig)
+
+// pollConfig holds polling settings.
+type pollConfig struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	timeout     time.Duration
+	progress    func(completed, total int)
+}
+
+// WithPollInterval sets the initial interval between status checks. The
+// interval grows by half after every check, up to the maximum interval.
+func WithPollInterval(d time.Duration) PollOption {
+	return func(p *pollConfig) {
+		p.interval = d
+	}
+}
+
+// WithMaxPollInterval caps the interval between status checks.
+func WithMaxPollInterval(d time.Duration) PollOption {
+	return func(p *pollConfig) {
+		p.maxInterval = d
+	}
+}
+
+// WithPollTimeout sets how long to wait for the job before giving up with a
+// *TimeoutError. By default waiting is bounded only by the context.
+func WithPollTimeout(d time.Duration) PollOption {
+	return func(p *pollConfig) {
+		p.timeout = d
+	}
+}
+
+// WithPollProgress calls fn with the number of completed captures of an
+// awaited compose job whenever it changes, and with the number of finished
+// jobs of a bulk job on every poll of IterateBulkResults.
+func WithPollProgress(fn func(completed, total int)) PollOption {
+	return func(p *pollConfig) {
+		p.progress = fn
+	}
+}
+
+// newPollConfig applies opts over the defaults.
+func newPollConfig(opts []PollOption) pollConfig {
+	p := pollConfig{
+		interval:    DefaultPollInterval,
+		maxInterval: DefaultMaxPollInterval,
+	}
+	for _, opt := range opts {
+		opt(&p)
+	}
+	if p.interval <= 0 {
+		p.interval = DefaultPollInterval
+	}
+	if p.maxInterval < p.interval {
+		p.maxInterval = p.interval
+	}
+	return p
+}
+
+// poll calls check until it reports done, sleeping between calls with a
+// growing interval. what describes the awaited resource in timeout errors.
+func (p pollConfig) poll(ctx context.Context, what string, check func(context.Context) (bool, error)) error {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	timedOut := func(err error) error {
+		if p.timeout > 0 && ctx.Err() == context.DeadlineExceeded {
+			return &TimeoutError{Message: fmt.Sprintf("%s did not finish within %s", what, p.timeout), Cause: err}
+		}
+		return timeoutError(err)
+	}
+
+	interval := p.interval
+	for {
+		done, err := check(ctx)
+		if err != nil {
+			return timedOut(err)
+		}
+		if done {
+			return nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return timedOut(ctx.Err())
+		case <-timer.C:
+		}
+
+		interval += interval / 2
+		if interval > p.maxInterval {
+			interval = p.maxInterval
+		}
+	}
+}
+
+// poller paces the status checks of long-running watchers that emit results
+// as they go: the interval grows while nothing changes and is reset when
+// something does.
+type poller struct {
+	cfg      pollConfig
+	what     string
+	interval time.Duration
+	deadline time.Time
+}
+
+// newPoller starts pacing checks of the resource described by what.
+func (p pollConfig) newPoller(what string) *poller {
+	pl := &poller{cfg: p, what: what, interval: p.interval}
+	if p.timeout > 0 {
+		pl.deadline = time.Now().Add(p.timeout)
+	}
+	return pl
+}
+
+// wait sleeps until the next check is due, failing with a *TimeoutError if
+// it would fall after the poll timeout.
+func (pl *poller) wait(ctx context.Context) error {
+	if !pl.deadline.IsZero() && time.Now().Add(pl.interval).After(pl.deadline) {
+		return &TimeoutError{Message: fmt.Sprintf("%s did not finish within %s", pl.what, pl.cfg.timeout)}
+	}
+	timer := time.NewTimer(pl.interval)
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return timeoutError(ctx.Err())
+	case <-timer.C:
+	}
+	pl.interval += pl.interval / 2
+	if pl.interval > pl.cfg.maxInterval {
+		pl.interval = pl.cfg.maxInterval
+	}
+	return nil
+}
+
+// reset returns to the initial interval after a change was observed.
+func (pl *poller) reset() {
+	pl.interval = pl.cfg.interval
+}
+
+// WaitForJob polls a job until it completes, fails, or is cancelled. A failed
+// or cancelled job is returned together with a *JobFailedError.
+//
+// Example:
+//
+//	job, err := client.WaitForJob(ctx, "job-123", allscreenshots.WithPollTimeout(2*time.Minute))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(job.ResultURL)
+func (c *Client) WaitForJob(ctx context.Context, id string, opts ...PollOption) (*JobResponse, error) {
+	if id == "" {
+		return nil, &ValidationError{Field: "id", Message: "job ID is required"}
+	}
+
+	var job *JobResponse
+	err := newPollConfig(opts).poll(ctx, "job "+id, func(ctx context.Context) (bool, error) {
+		var err error
+		job, err = c.GetJob(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		switch job.Status {
+		case JobStatusCompleted:
+			return true, nil
+		case JobStatusFailed, JobStatusCancelled:
+			return true, &JobFailedError{Job: job}
+		}
+		return false, nil
+	})
+	return job, err
+}
+
+// WaitForComposeJob polls a compose job until it completes, fails, or is
+// cancelled, and returns the composed image. A failed or cancelled job is
+// reported as a *JobFailedError. Use WithPollProgress to follow the job's
+// captures as they complete.
+//
+// Example:
+//
+//	job, err := client.ComposeAsync(ctx, req)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	result, err := client.WaitForComposeJob(ctx, job.JobID,
+//	    allscreenshots.WithPollProgress(func(completed, total int) {
+//	        fmt.Printf("%d/%d captures\n", completed, total)
+//	    }),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Println(result.URL)
+func (c *Client) WaitForComposeJob(ctx context.Context, jobID string, opts ...PollOption) (*ComposeResponse, error) {
+	if jobID == "" {
+		return nil, &ValidationError{Field: "jobId", Message: "job ID is required"}
+	}
+
+	p := newPollConfig(opts)
+	var job *ComposeJobStatusResponse
+	reported := -1
+	err := p.poll(ctx, "compose job "+jobID, func(ctx context.Context) (bool, error) {
+		var err error
+		job, err = c.GetComposeJob(ctx, jobID)
+		if err != nil {
+			return false, err
+		}
+		if p.progress != nil && job.CompletedCaptures != reported {
+			reported = job.CompletedCaptures
+			p.progress(job.CompletedCaptures, job.TotalCaptures)
+		}
+		switch JobStatus(job.Status) {
+		case JobStatusCompleted:
+			return true, nil
+		case JobStatusFailed, JobStatusCancelled:
+			return true, composeJobFailed(job)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if job.Result == nil {
+		return nil, fmt.Errorf("allscreenshots: compose job %s completed without a result", jobID)
+	}
+	return job.Result, nil
+}
+
+// composeJobFailed returns the error reported for a failed or cancelled
+// compose job.
+func composeJobFailed(job *ComposeJobStatusResponse) *JobFailedError {
+	return &JobFailedError{Job: &JobResponse{
+		ID:           job.JobID,
+		Status:       JobStatus(job.Status),
+		ErrorCode:    job.ErrorCode,
+		ErrorMessage: job.ErrorMessage,
+		CreatedAt:    job.CreatedAt,
+		CompletedAt:  job.CompletedAt,
+	}}
+}
+
+// ScreenshotAsyncAndWait starts an asynchronous capture, waits for it to
+// finish, and downloads the result.
+//
+// Example:
+//
+//	imageData, job, err := client.ScreenshotAsyncAndWait(ctx, &allscreenshots.ScreenshotRequest{
+//	    URL:      "https://github.com",
+//	    FullPage: true,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("job %s: %d bytes\n", job.ID, len(imageData))
+func (c *Client) ScreenshotAsyncAndWait(ctx context.Context, req *ScreenshotRequest, opts ...PollOption) ([]byte, *JobResponse, error) {
+	created, err := c.ScreenshotAsync(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	job, err := c.WaitForJob(ctx, created.ID, opts...)
+	if err != nil {
+		return nil, job, err
+	}
+
+	data, err := c.GetJobResult(ctx, job.ID)
+	if err != nil {
+		return nil, job, err
+	}
+	return data, job, nil
+}
@@ -0,0 +1,164 @@
+package allscreenshots
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ScreenshotAsyncAndWait(t *testing.T) {
+	imageData := []byte{0x89, 0x50, 0x4E, 0x47}
+	polls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/screenshots/async":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(AsyncJobCreatedResponse{ID: "job-123", Status: JobStatusQueued})
+		case "/v1/screenshots/jobs/job-123":
+			polls++
+			status := JobStatusProcessing
+			if polls >= 3 {
+				status = JobStatusCompleted
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(JobResponse{ID: "job-123", Status: status})
+		case "/v1/screenshots/jobs/job-123/result":
+			w.Write(imageData)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+	data, job, err := client.ScreenshotAsyncAndWait(context.Background(),
+		&ScreenshotRequest{URL: "https://example.com"},
+		WithPollInterval(time.Millisecond),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, imageData, data)
+	assert.Equal(t, JobStatusCompleted, job.Status)
+	assert.Equal(t, 3, polls)
+}
+
+func TestClient_WaitForJob(t *testing.T) {
+	t.Run("returns JobFailedError for failed jobs", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(JobResponse{
+				ID:           "job-123",
+				Status:       JobStatusFailed,
+				ErrorCode:    ErrCodeTargetStatus,
+				ErrorMessage: "Target page responded with 404",
+			})
+		}))
+		defer server.Close()
+
+		client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+		job, err := client.WaitForJob(context.Background(), "job-123")
+		require.Error(t, err)
+		assert.True(t, IsJobFailedError(err))
+		assert.True(t, IsTargetStatusError(err))
+		assert.Contains(t, err.Error(), "job job-123 failed (TARGET_STATUS)")
+		assert.Equal(t, JobStatusFailed, job.Status)
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(JobResponse{ID: "job-123", Status: JobStatusProcessing})
+		}))
+		defer server.Close()
+
+		client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+		_, err := client.WaitForJob(context.Background(), "job-123",
+			WithPollInterval(time.Millisecond),
+			WithPollTimeout(20*time.Millisecond),
+		)
+		require.Error(t, err)
+		assert.True(t, IsTimeoutError(err))
+	})
+
+	t.Run("requires job ID", func(t *testing.T) {
+		client := NewClient(WithAPIKey("test-api-key"))
+		_, err := client.WaitForJob(context.Background(), "")
+		assert.True(t, IsValidationError(err))
+	})
+}
+
+func TestNewPollConfig(t *testing.T) {
+	p := newPollConfig(nil)
+	assert.Equal(t, DefaultPollInterval, p.interval)
+	assert.Equal(t, DefaultMaxPollInterval, p.maxInterval)
+
+	p = newPollConfig([]PollOption{WithPollInterval(30 * time.Second)})
+	assert.Equal(t, 30*time.Second, p.maxInterval, "max interval must not be below the interval")
+}
+
+func TestClient_WaitForComposeJob(t *testing.T) {
+	t.Run("reports progress and returns the result", func(t *testing.T) {
+		polls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/screenshots/compose/jobs/compose-1", r.URL.Path)
+			polls++
+			job := ComposeJobStatusResponse{JobID: "compose-1", Status: "PROCESSING", TotalCaptures: 3, CompletedCaptures: polls - 1}
+			if polls >= 4 {
+				job.Status = "COMPLETED"
+				job.CompletedCaptures = 3
+				job.Result = &ComposeResponse{URL: "https://cdn.example.com/compose.png", Width: 1920}
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(job)
+		}))
+		defer server.Close()
+
+		client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+
+		var progress [][2]int
+		result, err := client.WaitForComposeJob(context.Background(), "compose-1",
+			WithPollInterval(time.Millisecond),
+			WithPollProgress(func(completed, total int) {
+				progress = append(progress, [2]int{completed, total})
+			}),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "https://cdn.example.com/compose.png", result.URL)
+		assert.Equal(t, [][2]int{{0, 3}, {1, 3}, {2, 3}, {3, 3}}, progress)
+	})
+
+	t.Run("returns JobFailedError for failed jobs", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(ComposeJobStatusResponse{
+				JobID:        "compose-1",
+				Status:       "FAILED",
+				ErrorCode:    ErrCodeURLUnreachable,
+				ErrorMessage: "capture 2 failed",
+			})
+		}))
+		defer server.Close()
+
+		client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+		result, err := client.WaitForComposeJob(context.Background(), "compose-1")
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, IsJobFailedError(err))
+		assert.Contains(t, err.Error(), "capture 2 failed")
+	})
+
+	t.Run("requires a job ID", func(t *testing.T) {
+		client := NewClient(WithAPIKey("test-api-key"))
+		_, err := client.WaitForComposeJob(context.Background(), "")
+		assert.True(t, IsValidationError(err))
+	})
+}
@@ -0,0 +1,170 @@
+package allscreenshots
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader is the request header carrying the webhook signature.
+	WebhookSignatureHeader = "X-Allscreenshots-Signature"
+	// MaxWebhookPayloadSize is the largest webhook body accepted by ParseWebhookRequest.
+	MaxWebhookPayloadSize = 1 << 20
+)
+
+// Webhook event types sent by the API.
+const (
+	WebhookEventJobCompleted     = "job.completed"
+	WebhookEventJobFailed        = "job.failed"
+	WebhookEventBulkCompleted    = "bulk.completed"
+	WebhookEventComposeCompleted = "compose.completed"
+	WebhookEventScheduleExecuted = "schedule.executed"
+	WebhookEventScheduleFailed   = "schedule.failed"
+)
+
+const webhookSignaturePrefix = "sha256="
+
+// ErrInvalidWebhookSignature is returned when a webhook signature is missing or wrong.
+var ErrInvalidWebhookSignature = errors.New("allscreenshots: invalid webhook signature")
+
+// WebhookEvent represents a webhook notification delivered by the API.
+type WebhookEvent struct {
+	// ID is the unique event identifier
+	ID string `json:"id"`
+	// Type of the event, e.g. "job.completed"
+	Type string `json:"type"`
+	// CreatedAt timestamp
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	// Data is the event payload; its shape depends on Type
+	Data json.RawMessage `json:"data,omitempty"`
+	// Payload is the raw request body the event was decoded from
+	Payload []byte `json:"-"`
+	// Signature is the signature header the event was delivered with
+	Signature string `json:"-"`
+}
+
+// Job decodes the event data as a screenshot job.
+func (e *WebhookEvent) Job() (*JobResponse, error) {
+	var job JobResponse
+	if err := json.Unmarshal(e.Data, &job); err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to decode webhook job: %w", err)
+	}
+	return &job, nil
+}
+
+// ComputeWebhookSignature returns the signature the API sends for payload,
+// in the form "sha256=<hex HMAC-SHA256>".
+func ComputeWebhookSignature(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature reports whether signature is valid for payload.
+// The "sha256=" prefix is optional. Comparison is constant-time.
+func VerifyWebhookSignature(payload []byte, signature, secret string) bool {
+	if signature == "" || secret == "" {
+		return false
+	}
+	if !strings.HasPrefix(signature, webhookSignaturePrefix) {
+		signature = webhookSignaturePrefix + signature
+	}
+	return hmac.Equal([]byte(signature), []byte(ComputeWebhookSignature(payload, secret)))
+}
+
+// ParseWebhookEvent decodes a webhook payload without verifying its signature.
+func ParseWebhookEvent(payload []byte) (*WebhookEvent, error) {
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to decode webhook payload: %w", err)
+	}
+	event.Payload = payload
+	return &event, nil
+}
+
+// ParseWebhookRequest reads a webhook request, verifies its signature against
+// secret, and decodes the event.
+//
+// Example:
+//
+//	event, err := allscreenshots.ParseWebhookRequest(r, os.Getenv("WEBHOOK_SECRET"))
+//	if err != nil {
+//	    http.Error(w, "invalid webhook", http.StatusUnauthorized)
+//	    return
+//	}
+func ParseWebhookRequest(r *http.Request, secret string) (*WebhookEvent, error) {
+	if secret == "" {
+		return nil, &ValidationError{Field: "secret", Message: "webhook secret is required"}
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, MaxWebhookPayloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("allscreenshots: failed to read webhook body: %w", err)
+	}
+	if len(payload) > MaxWebhookPayloadSize {
+		return nil, fmt.Errorf("allscreenshots: webhook body exceeds %d bytes", MaxWebhookPayloadSize)
+	}
+
+	signature := r.Header.Get(WebhookSignatureHeader)
+	if !VerifyWebhookSignature(payload, signature, secret) {
+		return nil, ErrInvalidWebhookSignature
+	}
+
+	event, err := ParseWebhookEvent(payload)
+	if err != nil {
+		return nil, err
+	}
+	event.Signature = signature
+	return event, nil
+}
+
+// WebhookHandlerFunc processes a verified webhook event.
+type WebhookHandlerFunc func(ctx context.Context, event *WebhookEvent) error
+
+// NewWebhookHandler returns an http.Handler that verifies and decodes webhook
+// requests and passes them to fn.
+//
+// The handler responds 405 to non-POST requests, 401 when the signature is
+// invalid, 400 when the body cannot be decoded, 500 when fn returns an error
+// (so the API retries the delivery), and 204 otherwise.
+//
+// Example:
+//
+//	http.Handle("/webhooks/allscreenshots", allscreenshots.NewWebhookHandler(secret,
+//	    func(ctx context.Context, event *allscreenshots.WebhookEvent) error {
+//	        log.Printf("received %s", event.Type)
+//	        return nil
+//	    }))
+func NewWebhookHandler(secret string, fn WebhookHandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		event, err := ParseWebhookRequest(r, secret)
+		if errors.Is(err, ErrInvalidWebhookSignature) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := fn(r.Context(), event); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,124 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Webhook delivery statuses.
+const (
+	WebhookDeliveryPending   = "PENDING"
+	WebhookDeliverySucceeded = "SUCCEEDED"
+	WebhookDeliveryFailed    = "FAILED"
+)
+
+// WebhookDelivery represents one attempt-tracked delivery of a webhook event.
+type WebhookDelivery struct {
+	// ID of the delivery; pass it to RedeliverWebhook
+	ID string `json:"id"`
+	// EventID of the delivered event
+	EventID string `json:"eventId"`
+	// EventType of the delivered event, e.g. "job.completed"
+	EventType string `json:"eventType"`
+	// JobID the event relates to, if any
+	JobID string `json:"jobId,omitempty"`
+	// URL the event was delivered to
+	URL string `json:"url"`
+	// Status of the delivery: PENDING, SUCCEEDED, or FAILED
+	Status string `json:"status"`
+	// Attempts is the number of delivery attempts made
+	Attempts int `json:"attempts"`
+	// ResponseStatus is the HTTP status returned by the receiver on the last attempt
+	ResponseStatus int `json:"responseStatus,omitempty"`
+	// Error describes why the last attempt failed
+	Error string `json:"error,omitempty"`
+	// CreatedAt timestamp
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	// LastAttemptAt is when the last attempt was made
+	LastAttemptAt *time.Time `json:"lastAttemptAt,omitempty"`
+	// NextAttemptAt is when the next automatic retry is due, for pending deliveries
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+}
+
+// WebhookDeliveryListResponse represents a list of webhook deliveries.
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Total      int               `json:"total"`
+}
+
+// WebhookDeliveryFilter selects the deliveries returned by ListWebhookDeliveries.
+type WebhookDeliveryFilter struct {
+	Status    string
+	EventType string
+	JobID     string
+	Since     time.Time
+	Limit     int
+}
+
+// ListWebhookDeliveries returns webhook deliveries, most recent first. A nil
+// filter returns all deliveries.
+//
+// Example:
+//
+//	failed, err := client.ListWebhookDeliveries(ctx, &allscreenshots.WebhookDeliveryFilter{
+//	    Status: allscreenshots.WebhookDeliveryFailed,
+//	    Since:  outageStart,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, d := range failed.Deliveries {
+//	    client.RedeliverWebhook(ctx, d.ID)
+//	}
+func (c *Client) ListWebhookDeliveries(ctx context.Context, filter *WebhookDeliveryFilter) (*WebhookDeliveryListResponse, error) {
+	params := url.Values{}
+	if filter != nil {
+		if filter.Limit < 0 {
+			return nil, &ValidationError{Field: "limit", Message: "limit must not be negative"}
+		}
+		if filter.Status != "" {
+			params.Set("status", filter.Status)
+		}
+		if filter.EventType != "" {
+			params.Set("eventType", filter.EventType)
+		}
+		if filter.JobID != "" {
+			params.Set("jobId", filter.JobID)
+		}
+		if !filter.Since.IsZero() {
+			params.Set("since", filter.Since.UTC().Format(time.RFC3339))
+		}
+		if filter.Limit > 0 {
+			params.Set("limit", strconv.Itoa(filter.Limit))
+		}
+	}
+	path := c.endpoint("/webhooks/deliveries")
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var result WebhookDeliveryListResponse
+	err := c.request(ctx, http.MethodGet, path, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RedeliverWebhook sends a previously delivered webhook event again, with
+// the same payload and signature, and returns the new delivery.
+func (c *Client) RedeliverWebhook(ctx context.Context, deliveryID string) (*WebhookDelivery, error) {
+	if deliveryID == "" {
+		return nil, &ValidationError{Field: "deliveryID", Message: "delivery ID is required"}
+	}
+
+	var result WebhookDelivery
+	err := c.request(ctx, http.MethodPost, c.endpoint("/webhooks/deliveries/"+url.PathEscape(deliveryID)+"/redeliver"), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
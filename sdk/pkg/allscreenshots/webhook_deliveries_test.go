@@ -0,0 +1,61 @@
+package allscreenshots
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WebhookDeliveries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/webhooks/deliveries":
+			q := r.URL.Query()
+			if len(q) > 0 {
+				assert.Equal(t, "FAILED", q.Get("status"))
+				assert.Equal(t, "job.completed", q.Get("eventType"))
+				assert.Equal(t, "2024-03-01T08:00:00Z", q.Get("since"))
+				assert.Equal(t, "50", q.Get("limit"))
+			}
+			w.Write([]byte(`{"deliveries":[{"id":"dlv-1","eventId":"evt-1","eventType":"job.completed","jobId":"job-1","url":"https://hooks.example.com","status":"FAILED","attempts":5,"responseStatus":503,"error":"Service Unavailable"}],"total":1}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/webhooks/deliveries/dlv-1/redeliver":
+			w.Write([]byte(`{"id":"dlv-2","eventId":"evt-1","eventType":"job.completed","url":"https://hooks.example.com","status":"PENDING","attempts":0}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIKey("test-api-key"), WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	list, err := client.ListWebhookDeliveries(ctx, &WebhookDeliveryFilter{
+		Status:    WebhookDeliveryFailed,
+		EventType: WebhookEventJobCompleted,
+		Since:     time.Date(2024, 3, 1, 9, 0, 0, 0, time.FixedZone("CET", 3600)),
+		Limit:     50,
+	})
+	require.NoError(t, err)
+	require.Len(t, list.Deliveries, 1)
+	assert.Equal(t, 503, list.Deliveries[0].ResponseStatus)
+	assert.Equal(t, 5, list.Deliveries[0].Attempts)
+
+	_, err = client.ListWebhookDeliveries(ctx, nil)
+	require.NoError(t, err)
+
+	delivery, err := client.RedeliverWebhook(ctx, "dlv-1")
+	require.NoError(t, err)
+	assert.Equal(t, "dlv-2", delivery.ID)
+	assert.Equal(t, WebhookDeliveryPending, delivery.Status)
+
+	_, err = client.RedeliverWebhook(ctx, "")
+	assert.True(t, IsValidationError(err))
+	_, err = client.ListWebhookDeliveries(ctx, &WebhookDeliveryFilter{Limit: -1})
+	assert.True(t, IsValidationError(err))
+}
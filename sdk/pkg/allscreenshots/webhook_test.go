@@ -0,0 +1,77 @@
+package allscreenshots
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	payload := []byte(`{"id":"evt-1","type":"job.completed"}`)
+	sig := ComputeWebhookSignature(payload, "secret")
+
+	assert.True(t, VerifyWebhookSignature(payload, sig, "secret"))
+	assert.True(t, VerifyWebhookSignature(payload, sig[len("sha256="):], "secret"))
+	assert.False(t, VerifyWebhookSignature(payload, sig, "other"))
+	assert.False(t, VerifyWebhookSignature([]byte(`{}`), sig, "secret"))
+	assert.False(t, VerifyWebhookSignature(payload, "", "secret"))
+}
+
+func TestNewWebhookHandler(t *testing.T) {
+	payload := []byte(`{"id":"evt-1","type":"job.completed","data":{"id":"job-123","status":"COMPLETED"}}`)
+
+	newRequest := func(sig string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader(payload))
+		r.Header.Set(WebhookSignatureHeader, sig)
+		return r
+	}
+
+	t.Run("delivers verified events", func(t *testing.T) {
+		var got *WebhookEvent
+		handler := NewWebhookHandler("secret", func(ctx context.Context, event *WebhookEvent) error {
+			got = event
+			return nil
+		})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newRequest(ComputeWebhookSignature(payload, "secret")))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		require.NotNil(t, got)
+		assert.Equal(t, WebhookEventJobCompleted, got.Type)
+		job, err := got.Job()
+		require.NoError(t, err)
+		assert.Equal(t, "job-123", job.ID)
+		assert.Equal(t, payload, got.Payload)
+		assert.Equal(t, ComputeWebhookSignature(payload, "secret"), got.Signature)
+	})
+
+	t.Run("rejects bad signatures", func(t *testing.T) {
+		handler := NewWebhookHandler("secret", func(ctx context.Context, event *WebhookEvent) error {
+			t.Fatal("handler should not be called")
+			return nil
+		})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newRequest("sha256=deadbeef"))
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("reports handler errors", func(t *testing.T) {
+		handler := NewWebhookHandler("secret", func(ctx context.Context, event *WebhookEvent) error {
+			return errors.New("downstream unavailable")
+		})
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, newRequest(ComputeWebhookSignature(payload, "secret")))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}